@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/isinthesky/api-gateway/internal/auth"
 	"github.com/isinthesky/api-gateway/internal/config"
 	"github.com/isinthesky/api-gateway/internal/handler"
 	"github.com/isinthesky/api-gateway/internal/metrics"
@@ -45,32 +46,61 @@ func main() {
 
 	gin.SetMode(gin.DebugMode)
 
-	// 라우터 생성
-	router := gin.New()
-
-	// 기본 미들웨어 등록
-	router.Use(gin.Recovery())
-	router.Use(middleware.StructuredLogger())
-
-	// CORS 미들웨어 설정
-	router.Use(middleware.CORS(cfg.AllowedOrigins))
+	// 레이트 리미터 저장소 설정 (redis를 사용하면 여러 게이트웨이 인스턴스가 한도를 공유)
+	var rateLimitStore ratelimiter.Store
+	if cfg.RateLimitStore == config.RateLimitStoreRedis {
+		rateLimitStore = ratelimiter.NewRedisStore(ratelimiter.RedisConfig{
+			Addr:     cfg.RateLimitRedisAddr,
+			Password: cfg.RateLimitRedisPassword,
+			DB:       cfg.RateLimitRedisDB,
+			Timeout:  cfg.RateLimitRedisTimeout,
+		})
+	} else {
+		rateLimitStore = ratelimiter.NewMemoryStore(cfg.RateLimitWindow)
+	}
 
 	// 레이트 리미터 설정
-	rateLimiter := ratelimiter.New(cfg.RateLimitWindow, cfg.RateLimitMaxReqs)
-	router.Use(middleware.RateLimit(rateLimiter))
-	
-	// 요청 크기 제한 설정
-	router.Use(middleware.SizeLimitMiddleware(cfg))
-	
-	// 메트릭 설정
+	var rateLimiter ratelimiter.RateLimiter
+	failureMode := ratelimiter.FailureMode(cfg.RateLimitFailureMode)
+	if cfg.RateLimitAlgorithm == config.RateLimitAlgorithmSlidingWindow {
+		rateLimiter = ratelimiter.NewSlidingWindowWithStore(rateLimitStore, cfg.RateLimitWindow, cfg.RateLimitMaxReqs, failureMode)
+	} else {
+		rateLimiter = ratelimiter.NewWithStore(rateLimitStore, cfg.RateLimitWindow, cfg.RateLimitMaxReqs, failureMode)
+	}
+
+	// 메트릭 수집기 설정 (라우트 테이블 리로드 시에도 재사용)
+	var metricsCollector *metrics.Collector
 	if cfg.EnableMetrics {
-		metricsCollector := metrics.NewCollector()
-		router.Use(middleware.Metrics(metricsCollector))
-		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+		metricsCollector = metrics.NewCollector()
 	}
 
-	// 캐시 초기화
-	cacheProvider := cache.New(cfg.CacheTTL)
+	// 캐시 초기화 (redis는 여러 게이트웨이 인스턴스가 공유, disk는 재시작 후에도 유지)
+	cacheConfig := cache.Config{
+		DefaultTTL:   cfg.CacheTTL,
+		MaxEntries:   cfg.CacheMaxEntries,
+		MaxBytes:     cfg.CacheMaxBytes,
+		MaxItemBytes: cfg.CacheMaxItemBytes,
+	}
+	var cacheProvider cache.CacheProvider
+	switch cfg.CacheStore {
+	case config.CacheStoreRedis:
+		cacheProvider = cache.NewRedisCache(cache.RedisConfig{
+			Addr:         cfg.CacheRedisAddr,
+			Password:     cfg.CacheRedisPassword,
+			DB:           cfg.CacheRedisDB,
+			Timeout:      cfg.CacheRedisTimeout,
+			DefaultTTL:   cfg.CacheTTL,
+			MaxItemBytes: cfg.CacheMaxItemBytes,
+		})
+	case config.CacheStoreDisk:
+		diskCache, err := cache.NewDiskCache(cfg.CacheDiskDir, cacheConfig)
+		if err != nil {
+			log.Fatalf("디스크 캐시 초기화 실패: %v", err)
+		}
+		cacheProvider = diskCache
+	default:
+		cacheProvider = cache.NewWithConfig(cacheConfig)
+	}
 
 	// 부하 분산기 초기화
 	var lb loadbalancer.LoadBalancer
@@ -80,27 +110,153 @@ func main() {
 		lb = loadbalancer.NewSingle(cfg.DefaultBackend)
 	}
 
-	// 서킷 브레이커 초기화
-	cb := circuitbreaker.New(circuitbreaker.Config{
+	// 서킷 브레이커 레지스트리 초기화 (라우트/대상 서버별 서킷 브레이커의 기본 설정)
+	breakerDefaults := circuitbreaker.Config{
 		ErrorThreshold:   cfg.CircuitBreakerErrorThreshold,
 		MinRequests:      cfg.CircuitBreakerMinRequests,
 		TimeoutDuration:  cfg.CircuitBreakerTimeout,
 		HalfOpenMaxReqs:  cfg.CircuitBreakerHalfOpenReqs,
 		SuccessThreshold: cfg.CircuitBreakerSuccessThreshold,
-	})
+
+		FailureStatusCodes: cfg.CircuitBreakerFailureStatusCodes,
+		SlowCallDuration:   cfg.CircuitBreakerSlowCallThreshold,
+		FailureErrorTypes:  cfg.CircuitBreakerFailureErrors,
+
+		SlowCallRateThreshold: cfg.CircuitBreakerSlowCallRateThreshold,
+	}
+	breakerDefaults.SetWindow(cfg.CircuitBreakerWindowType, cfg.CircuitBreakerWindowSize)
+	breakers := circuitbreaker.NewRegistry(breakerDefaults)
 
 	// 핸들러 초기화
-	routeHandler := handler.NewRouteHandler(lb, cb, cacheProvider, cfg)
-	
-	// 라우트 설정
-	if err := routeHandler.RegisterRoutes(router); err != nil {
+	routeHandler := handler.NewRouteHandler(lb, breakers, cacheProvider, cfg)
+	routeHandler.SetMetricsCollector(metricsCollector)
+	routeHandler.SetRateLimitStore(rateLimitStore, failureMode)
+
+	// 인증기 설정 (공개 키 파일이나 JWKS를 지정하면 외부 발급자의 RS256/ES256/EdDSA 토큰도 검증)
+	authenticator, err := auth.NewWithConfig(auth.Config{
+		SecretKey:              cfg.JWTSecret,
+		Issuer:                 cfg.JWTIssuer,
+		AcceptedIssuers:        cfg.JWTAcceptedIssuers,
+		ExpirationDelta:        cfg.JWTExpirationDelta,
+		PublicKeyFiles:         cfg.JWTPublicKeyFiles,
+		JWKSURL:                cfg.JWTJWKSURL,
+		JWKSRefreshInterval:    cfg.JWTJWKSRefreshInterval,
+		JWKSMinRefreshInterval: cfg.JWTJWKSMinRefreshInterval,
+	})
+	if err != nil {
+		log.Fatalf("인증 설정 실패: %v", err)
+	}
+	if cfg.IntrospectionURL != "" {
+		// JWT 형식이 아닌 불투명 토큰은 인증 서비스의 introspection 엔드포인트로 검증
+		introspection := auth.NewIntrospectionAuthenticator(auth.IntrospectionConfig{
+			URL:              cfg.IntrospectionURL,
+			ClientID:         cfg.IntrospectionClientID,
+			ClientSecret:     cfg.IntrospectionClientSecret,
+			Timeout:          cfg.IntrospectionTimeout,
+			CacheTTL:         cfg.IntrospectionCacheTTL,
+			NegativeCacheTTL: cfg.IntrospectionNegativeCacheTTL,
+			CacheMaxEntries:  cfg.IntrospectionCacheMaxEntries,
+			Breaker:          circuitbreaker.New(breakerDefaults),
+		})
+		routeHandler.SetAuthenticator(auth.NewOpaqueTokenAuthenticator(authenticator, introspection))
+	} else {
+		routeHandler.SetAuthenticator(authenticator)
+	}
+
+	// API 키 인증기 설정 (authMode가 apiKey 또는 any인 라우트에 사용)
+	if cfg.APIKeysFile != "" {
+		apiKeys, err := auth.LoadAPIKeys(cfg.APIKeysFile)
+		if err != nil {
+			log.Fatalf("API 키 설정 실패: %v", err)
+		}
+		routeHandler.SetAPIKeyAuthenticator(auth.NewAPIKeyAuthenticator(apiKeys))
+	}
+
+	// 브라우저 로그인 설정 (인가 코드 + PKCE 흐름, 암호화된 세션 쿠키)
+	if cfg.OIDCIssuer != "" {
+		sessions, err := auth.NewSessionCodec(cfg.OIDCSessionSecret)
+		if err != nil {
+			log.Fatalf("OIDC 로그인 설정 실패: %v", err)
+		}
+		routeHandler.SetOIDCLogin(auth.NewOIDCProvider(auth.OIDCConfig{
+			Issuer:       cfg.OIDCIssuer,
+			ClientID:     cfg.OIDCClientID,
+			ClientSecret: cfg.OIDCClientSecret,
+			RedirectURL:  cfg.OIDCRedirectURL,
+			Scopes:       cfg.OIDCScopes,
+		}), sessions)
+	}
+
+	// 토큰 폐기 목록 저장소 설정 (redis를 사용하면 여러 게이트웨이 인스턴스가 폐기 목록을 공유)
+	var revocationStore auth.RevocationStore
+	if cfg.RevocationStore == config.RevocationStoreRedis {
+		revocationStore = auth.NewRedisRevocationStore(auth.RedisRevocationConfig{
+			Addr:     cfg.RevocationRedisAddr,
+			Password: cfg.RevocationRedisPassword,
+			DB:       cfg.RevocationRedisDB,
+			Timeout:  cfg.RevocationRedisTimeout,
+		})
+	} else {
+		revocationStore = auth.NewMemoryRevocationStore()
+	}
+	routeHandler.SetRevocationStore(revocationStore, ratelimiter.FailureMode(cfg.RevocationFailureMode))
+
+	// 라우터 구성 함수 (라우트 리로드 시마다 새 라우터를 생성)
+	buildRouter := func(routes *config.RoutesConfig) (*gin.Engine, error) {
+		router := gin.New()
+
+		// 기본 미들웨어 등록
+		router.Use(gin.Recovery())
+		router.Use(middleware.StructuredLogger())
+
+		// CORS 미들웨어 설정 (허용 오리진은 CSRF 검사에서도 허용, 교차 출처 클라이언트가 CSRF 토큰 헤더를 보낼 수 있도록 허용)
+		router.Use(middleware.CORS(cfg.AllowedOrigins, cfg.CSRFHeader))
+
+		// 레이트 리미터 설정
+		router.Use(middleware.RateLimit(rateLimiter))
+
+		// 요청 크기 제한 설정
+		router.Use(middleware.SizeLimitMiddleware(cfg))
+
+		// 메트릭 설정
+		if metricsCollector != nil {
+			router.Use(middleware.Metrics(metricsCollector))
+			router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+		}
+
+		// 라우트 설정
+		if err := routeHandler.RegisterRoutesFrom(router, routes); err != nil {
+			return nil, err
+		}
+
+		return router, nil
+	}
+
+	// 라우트 리로더 초기화
+	reloader, err := handler.NewRouteReloader(cfg.RoutesConfigPath, buildRouter)
+	if err != nil {
 		log.Fatalf("라우트 등록 실패: %v", err)
 	}
 
+	// 라우트 구성 파일 변경 감지
+	reloader.Watch(cfg.RoutesReloadInterval)
+
+	// SIGHUP 수신 시 라우트 리로드
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Println("SIGHUP 수신: 라우트 리로드 시작")
+			if err := reloader.Reload(); err != nil {
+				log.Printf("라우트 리로드 실패, 기존 라우트를 유지합니다: %v", err)
+			}
+		}
+	}()
+
 	// 서버 초기화
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.Port),
-		Handler:      router,
+		Handler:      reloader,
 		ReadTimeout:  cfg.ReadTimeout,
 		WriteTimeout: cfg.WriteTimeout,
 		IdleTimeout:  cfg.IdleTimeout,
@@ -130,7 +286,12 @@ func main() {
 	}
 
 	// 리소스 정리
+	signal.Stop(hup)
+	reloader.Stop()
+	routeHandler.Close()
 	rateLimiter.Stop()
+	rateLimitStore.Close()
+	revocationStore.Close()
 	cacheProvider.Close()
 
 	log.Println("서버가 정상적으로 종료되었습니다")
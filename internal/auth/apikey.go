@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// apiKeyPrefix는 GenerateAPIKey가 생성하는 키의 접두사입니다 (로그나 저장소에서 키를 식별하기 쉽도록).
+const apiKeyPrefix = "gw_"
+
+// ErrAPIKeyNotFound는 저장소에 등록되지 않은 API 키입니다.
+var ErrAPIKeyNotFound = errors.New("등록되지 않은 API 키입니다")
+
+// APIKey는 등록된 API 키의 정보입니다. 키 원문은 저장하지 않고 SHA-256 해시만 저장합니다.
+type APIKey struct {
+	ID        string           `json:"id"`        // 키 ID (로그, 속도 제한에 사용)
+	Hash      string           `json:"hash"`      // 키의 SHA-256 해시 (16진수)
+	Consumer  string           `json:"consumer"`  // 키를 사용하는 소비자 ID (sub 클레임)
+	Roles     []string         `json:"roles"`     // 소비자 역할
+	RateLimit *APIKeyRateLimit `json:"rateLimit"` // 키별 속도 제한 (생략 시 제한하지 않음)
+	ExpiresAt *time.Time       `json:"expiresAt"` // 만료 시각 (생략 시 만료되지 않음)
+	Disabled  bool             `json:"disabled"`  // 비활성화 여부
+}
+
+// APIKeyRateLimit은 API 키별 속도 제한입니다.
+type APIKeyRateLimit struct {
+	Limit  int `json:"limit"`  // 윈도우 당 최대 요청 수
+	Window int `json:"window"` // 윈도우 크기 (초)
+	Burst  int `json:"burst"`  // 순간 허용량 (생략 시 limit)
+}
+
+// APIKeyStore는 해시로 API 키를 찾는 저장소입니다.
+type APIKeyStore interface {
+	// Lookup은 해시에 해당하는 API 키를 반환합니다. 없으면 ErrAPIKeyNotFound를 반환합니다.
+	Lookup(hash string) (*APIKey, error)
+}
+
+// MemoryAPIKeyStore는 메모리에 API 키 목록을 보관하는 저장소입니다.
+type MemoryAPIKeyStore struct {
+	mu   sync.RWMutex
+	keys map[string]APIKey // 해시 -> 키
+}
+
+// NewMemoryAPIKeyStore는 API 키 목록으로 메모리 저장소를 생성합니다.
+func NewMemoryAPIKeyStore(keys []APIKey) (*MemoryAPIKeyStore, error) {
+	store := &MemoryAPIKeyStore{}
+	if err := store.Replace(keys); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// LoadAPIKeys는 JSON 키 파일({"keys": [...]})에서 API 키 목록을 읽어 메모리 저장소를 생성합니다.
+func LoadAPIKeys(path string) (*MemoryAPIKeyStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("API 키 파일을 읽을 수 없습니다: %w", err)
+	}
+
+	var file struct {
+		Keys []APIKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("API 키 파일 파싱 실패 (%s): %w", path, err)
+	}
+
+	store, err := NewMemoryAPIKeyStore(file.Keys)
+	if err != nil {
+		return nil, fmt.Errorf("API 키 파일 (%s): %w", path, err)
+	}
+	return store, nil
+}
+
+// Replace는 API 키 목록을 교체합니다. 목록이 올바르지 않으면 기존 목록을 유지합니다.
+func (s *MemoryAPIKeyStore) Replace(keys []APIKey) error {
+	index := make(map[string]APIKey, len(keys))
+	ids := make(map[string]bool, len(keys))
+	for i, key := range keys {
+		if key.ID == "" {
+			return fmt.Errorf("키 #%d: id가 필요합니다", i)
+		}
+		if ids[key.ID] {
+			return fmt.Errorf("키 %s: 중복된 id입니다", key.ID)
+		}
+		ids[key.ID] = true
+
+		hash := strings.ToLower(key.Hash)
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("키 %s: hash는 SHA-256 16진수여야 합니다", key.ID)
+		}
+		if _, exists := index[hash]; exists {
+			return fmt.Errorf("키 %s: 중복된 hash입니다", key.ID)
+		}
+		if key.Consumer == "" {
+			return fmt.Errorf("키 %s: consumer가 필요합니다", key.ID)
+		}
+		if limit := key.RateLimit; limit != nil && (limit.Limit <= 0 || limit.Window <= 0 || limit.Burst < 0) {
+			return fmt.Errorf("키 %s: rateLimit의 limit와 window는 0보다 커야 합니다", key.ID)
+		}
+
+		key.Hash = hash
+		index[hash] = key
+	}
+
+	s.mu.Lock()
+	s.keys = index
+	s.mu.Unlock()
+	return nil
+}
+
+// Lookup은 해시에 해당하는 API 키를 반환합니다.
+func (s *MemoryAPIKeyStore) Lookup(hash string) (*APIKey, error) {
+	s.mu.RLock()
+	key, ok := s.keys[hash]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrAPIKeyNotFound
+	}
+	return &key, nil
+}
+
+// HashAPIKey는 키 파일에 저장할 API 키의 SHA-256 해시(16진수)를 반환합니다.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateAPIKey는 새 API 키와 키 파일에 저장할 해시를 생성합니다.
+func GenerateAPIKey() (key, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("API 키 생성 실패: %v", err)
+	}
+	key = apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, HashAPIKey(key), nil
+}
+
+// APIKeyAuthenticator는 API 키 기반 인증을 구현하는 구조체입니다.
+// OAuth 흐름을 사용할 수 없는 서버 간 클라이언트(리포트 생성기, 배치 작업 등)를 위한 인증 방식입니다.
+type APIKeyAuthenticator struct {
+	store APIKeyStore
+}
+
+// NewAPIKeyAuthenticator는 저장소를 사용하는 API 키 인증기를 생성합니다.
+func NewAPIKeyAuthenticator(store APIKeyStore) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{store: store}
+}
+
+// GenerateToken은 지원하지 않습니다. API 키는 GenerateAPIKey로 생성하여 저장소에 해시를 등록해야 합니다.
+func (a *APIKeyAuthenticator) GenerateToken(userID string, roles []string) (string, error) {
+	return "", errors.New("API 키 인증기는 토큰을 발급하지 않습니다")
+}
+
+// VerifyToken은 API 키를 검증하고 소비자 정보를 클레임으로 반환합니다.
+func (a *APIKeyAuthenticator) VerifyToken(key string) (*Claims, error) {
+	apiKey, err := a.Lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	return apiKey.Claims(), nil
+}
+
+// Lookup은 API 키를 검증하고 등록된 키 정보를 반환합니다.
+func (a *APIKeyAuthenticator) Lookup(key string) (*APIKey, error) {
+	if key == "" {
+		return nil, errors.New("API 키가 필요합니다")
+	}
+
+	apiKey, err := a.store.Lookup(HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.Disabled {
+		return nil, fmt.Errorf("비활성화된 API 키입니다: %s", apiKey.ID)
+	}
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("만료된 API 키입니다: %s", apiKey.ID)
+	}
+	return apiKey, nil
+}
+
+// Claims는 API 키의 소비자 정보를 JWT와 같은 형식의 클레임으로 반환합니다.
+// 키 ID는 jti 클레임과 key_id 클레임에 담깁니다.
+func (k *APIKey) Claims() *Claims {
+	roles := make([]interface{}, len(k.Roles))
+	for i, role := range k.Roles {
+		roles[i] = role
+	}
+
+	return &Claims{
+		Roles: k.Roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: k.Consumer,
+			ID:      k.ID,
+		},
+		Raw: map[string]interface{}{
+			"sub":         k.Consumer,
+			"jti":         k.ID,
+			"roles":       roles,
+			"key_id":      k.ID,
+			"auth_method": "api_key",
+		},
+	}
+}
@@ -1,9 +1,12 @@
 package auth
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -20,6 +23,43 @@ type Claims struct {
 	Picture    string   `json:"picture"`
 	Roles      []string `json:"roles"`
 	jwt.RegisteredClaims
+
+	// Raw는 검증된 토큰의 모든 클레임입니다 (숫자는 json.Number).
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Lookup은 이름으로 클레임 값을 찾습니다. 점으로 구분하여 중첩된 객체의 필드를 지정할 수 있습니다 (예: org.id).
+func (c *Claims) Lookup(name string) (interface{}, bool) {
+	var value interface{} = c.Raw
+	for _, field := range strings.Split(name, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[field]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// Scopes는 OAuth 스코프 목록을 반환합니다.
+// scope 클레임(공백으로 구분된 문자열, RFC 8693)과 scp 클레임(문자열 또는 배열)을 모두 지원합니다.
+func (c *Claims) Scopes() []string {
+	var scopes []string
+	for _, name := range []string{"scope", "scp"} {
+		switch value := c.Raw[name].(type) {
+		case string:
+			scopes = append(scopes, strings.Fields(value)...)
+		case []interface{}:
+			for _, item := range value {
+				if scope, ok := item.(string); ok {
+					scopes = append(scopes, scope)
+				}
+			}
+		}
+	}
+	return scopes
 }
 
 // Authenticator는 인증 관련 기능을 제공하는 인터페이스입니다.
@@ -29,21 +69,80 @@ type Authenticator interface {
 }
 
 // JWTAuthenticator는 JWT 기반 인증을 구현하는 구조체입니다.
+// 게이트웨이가 발급하는 토큰은 HMAC(HS256)으로 서명하며, 검증 시에는 공개 키 소스가 있으면
+// RSA(RS*, PS*), ECDSA(ES*), EdDSA로 서명된 외부 발급자의 토큰도 받습니다.
 type JWTAuthenticator struct {
 	secretKey       string
 	issuer          string
+	issuers         map[string]bool // 허용하는 발급자 (issuer 포함)
 	expirationDelta time.Duration
+	keySources      []KeySource // 비대칭 서명 검증용 공개 키
+}
+
+// Config는 JWT 인증 설정입니다.
+type Config struct {
+	SecretKey       string        // HMAC 서명/검증 키 (비어 있으면 HMAC 토큰을 받지 않음)
+	Issuer          string        // 게이트웨이가 발급하는 토큰의 발급자
+	AcceptedIssuers []string      // Issuer 외에 허용하는 발급자 (외부 IdP)
+	ExpirationDelta time.Duration // 발급하는 토큰의 유효 기간
+
+	PublicKeyFiles         []string      // 공개 키 PEM 파일 (kid는 확장자를 제외한 파일 이름)
+	JWKSURL                string        // 공개 키 목록을 가져올 JWKS URL
+	JWKSRefreshInterval    time.Duration // JWKS를 다시 가져오는 주기
+	JWKSMinRefreshInterval time.Duration // 모르는 kid로 JWKS를 다시 가져올 때의 최소 간격
 }
 
-// New는 새로운 Authenticator를 생성합니다.
+// 알고리즘 종류별로 허용하는 서명 방식
+var (
+	hmacAlgorithms       = []string{"HS256", "HS384", "HS512"}
+	asymmetricAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+)
+
+// New는 HMAC 비밀 키만 사용하는 새로운 Authenticator를 생성합니다.
 func New(secretKey, issuer string, expirationDelta time.Duration) Authenticator {
 	return &JWTAuthenticator{
 		secretKey:       secretKey,
 		issuer:          issuer,
+		issuers:         map[string]bool{issuer: true},
 		expirationDelta: expirationDelta,
 	}
 }
 
+// NewWithConfig는 공개 키 파일과 JWKS를 포함한 설정으로 Authenticator를 생성합니다.
+// 공개 키 파일을 읽을 수 없으면 오류를 반환합니다.
+func NewWithConfig(config Config) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{
+		secretKey:       config.SecretKey,
+		issuer:          config.Issuer,
+		issuers:         map[string]bool{config.Issuer: true},
+		expirationDelta: config.ExpirationDelta,
+	}
+	for _, issuer := range config.AcceptedIssuers {
+		a.issuers[issuer] = true
+	}
+
+	if len(config.PublicKeyFiles) > 0 {
+		static, err := LoadPublicKeys(config.PublicKeyFiles)
+		if err != nil {
+			return nil, err
+		}
+		a.keySources = append(a.keySources, static)
+	}
+	if config.JWKSURL != "" {
+		a.keySources = append(a.keySources, NewJWKS(JWKSConfig{
+			URL:                config.JWKSURL,
+			RefreshInterval:    config.JWKSRefreshInterval,
+			MinRefreshInterval: config.JWKSMinRefreshInterval,
+		}))
+	}
+	return a, nil
+}
+
+// AddKeySource는 비대칭 서명 검증에 사용할 공개 키 소스를 추가합니다.
+func (a *JWTAuthenticator) AddKeySource(source KeySource) {
+	a.keySources = append(a.keySources, source)
+}
+
 // GenerateToken은 사용자 ID와 역할을 기반으로 JWT 토큰을 생성합니다.
 func (a *JWTAuthenticator) GenerateToken(userID string, roles []string) (string, error) {
 	log.Println("GenerateToken", userID, roles)
@@ -79,13 +178,7 @@ func (a *JWTAuthenticator) GenerateToken(userID string, roles []string) (string,
 func (a *JWTAuthenticator) VerifyToken(tokenString string) (*Claims, error) {
 	log.Println("VerifyToken", tokenString)
 	
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// 서명 방식 확인
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("서명 방식이 일치하지 않습니다: %v", token.Header["alg"])
-		}
-		return []byte(a.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, a.verificationKey, jwt.WithValidMethods(a.validMethods()))
 
 	if err != nil {
 		return nil, fmt.Errorf("토큰 파싱 실패: %v", err)
@@ -99,9 +192,12 @@ func (a *JWTAuthenticator) VerifyToken(tokenString string) (*Claims, error) {
 	if !ok {
 		return nil, errors.New("유효한 클레임을 추출할 수 없습니다")
 	}
+	if claims.Raw, err = decodeRawClaims(token.Raw); err != nil {
+		return nil, fmt.Errorf("클레임 파싱 실패: %v", err)
+	}
 
 	// 발급자 확인
-	if claims.Issuer != a.issuer {
+	if !a.issuers[claims.Issuer] {
 		return nil, fmt.Errorf("발급자가 일치하지 않습니다: %s", claims.Issuer)
 	}
 
@@ -112,3 +208,60 @@ func (a *JWTAuthenticator) VerifyToken(tokenString string) (*Claims, error) {
 
 	return claims, nil
 }
+
+// validMethods는 설정된 키로 검증할 수 있는 서명 방식 목록을 반환합니다.
+func (a *JWTAuthenticator) validMethods() []string {
+	var methods []string
+	if a.secretKey != "" {
+		methods = append(methods, hmacAlgorithms...)
+	}
+	if len(a.keySources) > 0 {
+		methods = append(methods, asymmetricAlgorithms...)
+	}
+	return methods
+}
+
+// verificationKey는 토큰의 서명 방식과 kid에 맞는 검증 키를 반환합니다.
+// HMAC 토큰에는 비밀 키만, 비대칭 서명 토큰에는 공개 키만 사용하여 알고리즘 혼동 공격을 막습니다.
+func (a *JWTAuthenticator) verificationKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if a.secretKey == "" {
+			return nil, errors.New("HMAC 서명 토큰을 받지 않습니다")
+		}
+		return []byte(a.secretKey), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	alg := token.Method.Alg()
+
+	var keys jwt.VerificationKeySet
+	for _, source := range a.keySources {
+		for _, key := range source.Keys(kid, alg) {
+			keys.Keys = append(keys.Keys, key)
+		}
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("검증 키를 찾을 수 없습니다: kid=%s, alg=%s", kid, alg)
+	}
+	return keys, nil
+}
+
+// decodeRawClaims는 토큰의 페이로드를 클레임 맵으로 디코딩합니다.
+func decodeRawClaims(tokenString string) (map[string]interface{}, error) {
+	parts := strings.Split(tokenString, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("토큰 형식이 올바르지 않습니다")
+	}
+	payload, err := jwt.NewParser().DecodeSegment(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var raw map[string]interface{}
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// InternalTokenIssuer는 게이트웨이가 검증한 사용자 정보로 업스트림에 전달할 짧은 수명의 내부 JWT를 발급합니다.
+// 업스트림은 외부 발급자의 키나 JWKS 없이 게이트웨이와 공유한 비밀 키(HS256)만으로 사용자를 확인할 수 있습니다.
+type InternalTokenIssuer struct {
+	secretKey []byte
+	issuer    string
+	audience  string
+	ttl       time.Duration
+}
+
+// NewInternalTokenIssuer는 내부 JWT 발급기를 생성합니다. audience가 비어 있으면 aud 클레임을 넣지 않습니다.
+func NewInternalTokenIssuer(secretKey, issuer, audience string, ttl time.Duration) (*InternalTokenIssuer, error) {
+	if secretKey == "" {
+		return nil, errors.New("내부 JWT 서명 키가 필요합니다")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("내부 JWT 유효 기간은 0보다 커야 합니다")
+	}
+	return &InternalTokenIssuer{
+		secretKey: []byte(secretKey),
+		issuer:    issuer,
+		audience:  audience,
+		ttl:       ttl,
+	}, nil
+}
+
+// Issue는 검증된 클레임의 사용자 정보를 담은 내부 JWT를 발급합니다.
+// 원래 토큰의 발급자는 orig_iss 클레임으로 전달합니다.
+func (i *InternalTokenIssuer) Issue(claims *Claims) (string, error) {
+	now := time.Now()
+	internal := jwt.MapClaims{
+		"sub": claims.Subject,
+		"iss": i.issuer,
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(i.ttl).Unix(),
+	}
+	if i.audience != "" {
+		internal["aud"] = i.audience
+	}
+	if claims.Issuer != "" {
+		internal["orig_iss"] = claims.Issuer
+	}
+
+	// 비어 있지 않은 사용자 정보만 포함
+	for name, value := range map[string]string{
+		"email":       claims.Email,
+		"name":        claims.Name,
+		"username":    claims.Username,
+		"provider":    claims.Provider,
+		"provider_id": claims.ProviderID,
+	} {
+		if value != "" {
+			internal[name] = value
+		}
+	}
+	if len(claims.Roles) > 0 {
+		internal["roles"] = claims.Roles
+	}
+	if scopes := claims.Scopes(); len(scopes) > 0 {
+		internal["scope"] = strings.Join(scopes, " ")
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, internal).SignedString(i.secretKey)
+	if err != nil {
+		return "", fmt.Errorf("내부 토큰 서명 실패: %v", err)
+	}
+	return token, nil
+}
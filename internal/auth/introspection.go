@@ -0,0 +1,279 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+)
+
+// introspection 기본 설정
+const (
+	DefaultIntrospectionCacheTTL         = time.Minute
+	DefaultIntrospectionNegativeCacheTTL = 10 * time.Second
+	DefaultIntrospectionCacheMaxEntries  = 10000
+	defaultIntrospectionTimeout          = 2 * time.Second
+	maxIntrospectionBytes                = 1 << 20
+)
+
+var (
+	// ErrInactiveToken은 introspection 엔드포인트가 active=false로 응답한 토큰입니다.
+	ErrInactiveToken = errors.New("비활성 토큰입니다")
+	// ErrIntrospectionUnavailable은 introspection 엔드포인트를 호출할 수 없어 토큰을 확인하지 못한 경우입니다.
+	ErrIntrospectionUnavailable = errors.New("토큰 확인 서비스를 사용할 수 없습니다")
+)
+
+// IntrospectionConfig는 OAuth2 토큰 introspection(RFC 7662) 설정입니다.
+type IntrospectionConfig struct {
+	URL              string        // introspection 엔드포인트 URL
+	ClientID         string        // 엔드포인트 인증용 클라이언트 ID (HTTP Basic, 비어 있으면 인증하지 않음)
+	ClientSecret     string        // 엔드포인트 인증용 클라이언트 비밀
+	Timeout          time.Duration // 호출 타임아웃 (기본 2초, Client를 지정하면 무시)
+	CacheTTL         time.Duration // 활성 토큰 결과의 최대 캐시 시간 (토큰의 exp를 넘지 않음, 0이면 캐시하지 않음)
+	NegativeCacheTTL time.Duration // 비활성 토큰 결과의 캐시 시간 (0이면 캐시하지 않음)
+	CacheMaxEntries  int           // 캐시 최대 항목 수 (기본 10000)
+
+	Client  *http.Client                   // nil이면 Timeout을 사용하는 기본 클라이언트
+	Breaker *circuitbreaker.CircuitBreaker // 엔드포인트 호출을 보호하는 서킷 브레이커 (nil이면 기본 설정)
+}
+
+// introspectionResult는 캐시된 introspection 결과입니다. 비활성 토큰은 claims가 nil입니다.
+type introspectionResult struct {
+	claims    *Claims
+	expiresAt time.Time
+}
+
+// IntrospectionAuthenticator는 불투명(reference) 토큰을 OAuth2 introspection 엔드포인트로 검증하는 인증기입니다.
+// 활성/비활성 결과를 제한된 시간 동안 캐시하여 모든 요청이 인증 서비스를 호출하지 않도록 하며,
+// 엔드포인트 호출은 서킷 브레이커로 보호합니다. 호출 실패는 캐시하지 않습니다.
+type IntrospectionAuthenticator struct {
+	config  IntrospectionConfig
+	breaker *circuitbreaker.CircuitBreaker
+
+	mu    sync.Mutex
+	cache map[string]introspectionResult // 토큰 해시 -> 결과
+}
+
+// NewIntrospectionAuthenticator는 introspection 인증기를 생성합니다.
+func NewIntrospectionAuthenticator(config IntrospectionConfig) *IntrospectionAuthenticator {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultIntrospectionTimeout
+	}
+	if config.CacheMaxEntries <= 0 {
+		config.CacheMaxEntries = DefaultIntrospectionCacheMaxEntries
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: config.Timeout}
+	}
+	breaker := config.Breaker
+	if breaker == nil {
+		breaker = circuitbreaker.New(circuitbreaker.Config{})
+	}
+
+	return &IntrospectionAuthenticator{
+		config:  config,
+		breaker: breaker,
+		cache:   make(map[string]introspectionResult),
+	}
+}
+
+// GenerateToken은 지원하지 않습니다. 불투명 토큰은 인증 서비스가 발급합니다.
+func (a *IntrospectionAuthenticator) GenerateToken(userID string, roles []string) (string, error) {
+	return "", errors.New("introspection 인증기는 토큰을 발급하지 않습니다")
+}
+
+// VerifyToken은 introspection 엔드포인트로 토큰을 확인하고 응답의 필드를 클레임으로 반환합니다.
+// 비활성 토큰은 ErrInactiveToken을, 엔드포인트를 호출할 수 없으면 ErrIntrospectionUnavailable을 감싼 오류를 반환합니다.
+func (a *IntrospectionAuthenticator) VerifyToken(token string) (*Claims, error) {
+	if token == "" {
+		return nil, errors.New("토큰이 필요합니다")
+	}
+
+	key := hashToken(token)
+	if result, ok := a.cached(key); ok {
+		return result.verify()
+	}
+
+	result, err := a.breaker.Execute(func() (interface{}, error) {
+		return a.introspect(token)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrIntrospectionUnavailable, err)
+	}
+
+	claims := result.(*Claims)
+	a.store(key, claims)
+	return introspectionResult{claims: claims}.verify()
+}
+
+// introspect는 introspection 엔드포인트를 호출합니다. 비활성 토큰은 nil 클레임을 반환합니다.
+func (a *IntrospectionAuthenticator) introspect(token string) (*Claims, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if a.config.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(a.config.ClientID), url.QueryEscape(a.config.ClientSecret))
+	}
+
+	resp, err := a.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspection 응답 상태 코드가 올바르지 않습니다: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxIntrospectionBytes))
+	if err != nil {
+		return nil, err
+	}
+	return parseIntrospection(body)
+}
+
+// parseIntrospection은 introspection 응답을 클레임으로 변환합니다.
+// sub, scope, exp 등 응답 필드는 JWT 클레임과 같은 이름이므로 JWT 토큰과 같이 권한 확인과 사용자 정보 전달에 사용됩니다.
+func parseIntrospection(body []byte) (*Claims, error) {
+	var response struct {
+		Active bool `json:"active"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("introspection 응답 파싱 실패: %w", err)
+	}
+	if !response.Active {
+		return nil, nil
+	}
+
+	claims := &Claims{}
+	if err := json.Unmarshal(body, claims); err != nil {
+		return nil, fmt.Errorf("introspection 응답 파싱 실패: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims.Raw); err != nil {
+		return nil, fmt.Errorf("introspection 응답 파싱 실패: %w", err)
+	}
+	delete(claims.Raw, "active")
+	return claims, nil
+}
+
+// verify는 캐시된 결과로 토큰을 확인합니다.
+func (r introspectionResult) verify() (*Claims, error) {
+	if r.claims == nil {
+		return nil, ErrInactiveToken
+	}
+	if r.claims.ExpiresAt != nil && r.claims.ExpiresAt.Time.Before(time.Now()) {
+		return nil, errors.New("만료된 토큰입니다")
+	}
+
+	// 호출자가 클레임을 수정해도 캐시에 영향이 없도록 복사
+	claims := *r.claims
+	return &claims, nil
+}
+
+// cached는 만료되지 않은 캐시 결과를 반환합니다.
+func (a *IntrospectionAuthenticator) cached(key string) (introspectionResult, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result, ok := a.cache[key]
+	if !ok {
+		return introspectionResult{}, false
+	}
+	if !result.expiresAt.After(time.Now()) {
+		delete(a.cache, key)
+		return introspectionResult{}, false
+	}
+	return result, true
+}
+
+// store는 introspection 결과를 캐시합니다.
+// 활성 토큰은 CacheTTL과 토큰 만료 시각 중 이른 시각까지, 비활성 토큰은 NegativeCacheTTL 동안 캐시합니다.
+func (a *IntrospectionAuthenticator) store(key string, claims *Claims) {
+	now := time.Now()
+	ttl := a.config.NegativeCacheTTL
+	if claims != nil {
+		ttl = a.config.CacheTTL
+	}
+	expiresAt := now.Add(ttl)
+	if claims != nil && claims.ExpiresAt != nil && claims.ExpiresAt.Time.Before(expiresAt) {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	if !expiresAt.After(now) {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.cache) >= a.config.CacheMaxEntries {
+		for k, result := range a.cache {
+			if !result.expiresAt.After(now) {
+				delete(a.cache, k)
+			}
+		}
+		// 만료된 항목이 없으면 임의의 항목을 제거하여 크기를 제한
+		for k := range a.cache {
+			if len(a.cache) < a.config.CacheMaxEntries {
+				break
+			}
+			delete(a.cache, k)
+		}
+	}
+	a.cache[key] = introspectionResult{claims: claims, expiresAt: expiresAt}
+}
+
+// hashToken은 캐시 키로 사용할 토큰의 SHA-256 해시를 반환합니다 (토큰 원문을 메모리에 보관하지 않음).
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// OpaqueTokenAuthenticator는 JWT 형식의 토큰은 JWT 인증기로, 그 외의 불투명 토큰은 introspection으로 검증합니다.
+type OpaqueTokenAuthenticator struct {
+	jwt           Authenticator
+	introspection *IntrospectionAuthenticator
+}
+
+// NewOpaqueTokenAuthenticator는 JWT 인증기와 introspection 인증기를 함께 사용하는 인증기를 생성합니다.
+func NewOpaqueTokenAuthenticator(jwt Authenticator, introspection *IntrospectionAuthenticator) *OpaqueTokenAuthenticator {
+	return &OpaqueTokenAuthenticator{jwt: jwt, introspection: introspection}
+}
+
+// GenerateToken은 JWT 인증기로 토큰을 발급합니다.
+func (a *OpaqueTokenAuthenticator) GenerateToken(userID string, roles []string) (string, error) {
+	return a.jwt.GenerateToken(userID, roles)
+}
+
+// VerifyToken은 토큰 형식에 맞는 인증기로 토큰을 검증합니다.
+func (a *OpaqueTokenAuthenticator) VerifyToken(token string) (*Claims, error) {
+	if isJWT(token) {
+		return a.jwt.VerifyToken(token)
+	}
+	return a.introspection.VerifyToken(token)
+}
+
+// isJWT는 토큰이 JWS 압축 직렬화 형식(header.payload.signature)인지 확인합니다.
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// JWKS 기본 설정
+const (
+	DefaultJWKSRefreshInterval    = time.Hour
+	DefaultJWKSMinRefreshInterval = time.Minute
+	defaultJWKSFetchTimeout       = 5 * time.Second
+	maxJWKSBytes                  = 1 << 20
+)
+
+// JWKSConfig는 JWKS 키 소스 설정입니다.
+type JWKSConfig struct {
+	URL                string        // JWKS 문서 URL
+	RefreshInterval    time.Duration // 키 목록을 다시 가져오는 주기 (기본 1시간)
+	MinRefreshInterval time.Duration // 모르는 kid로 다시 가져올 때의 최소 간격 (기본 1분)
+	Client             *http.Client  // nil이면 5초 타임아웃의 기본 클라이언트
+}
+
+// JWKS는 JWKS URL에서 가져온 공개 키 목록입니다 (RFC 7517).
+// 키 목록은 RefreshInterval마다, 그리고 모르는 kid의 토큰이 들어오면 (MinRefreshInterval 간격으로) 다시 가져오므로
+// 발급자가 키를 교체해도 게이트웨이를 재시작할 필요가 없습니다. 가져오기에 실패하면 이전 키 목록을 계속 사용합니다.
+type JWKS struct {
+	config JWKSConfig
+
+	fetchMu     sync.Mutex // 동시에 한 요청만 키 목록을 가져옴
+	lastAttempt time.Time
+
+	mu        sync.RWMutex
+	keys      keySet
+	fetchedAt time.Time
+}
+
+// NewJWKS는 JWKS 키 소스를 생성합니다. 키 목록은 첫 토큰 검증 시 가져옵니다.
+func NewJWKS(config JWKSConfig) *JWKS {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultJWKSRefreshInterval
+	}
+	if config.MinRefreshInterval <= 0 {
+		config.MinRefreshInterval = DefaultJWKSMinRefreshInterval
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: defaultJWKSFetchTimeout}
+	}
+	return &JWKS{config: config}
+}
+
+// Keys는 kid와 서명 알고리즘에 맞는 공개 키를 반환합니다.
+// 키 목록이 오래되었거나 kid를 찾을 수 없으면 키 목록을 다시 가져옵니다.
+func (j *JWKS) Keys(kid, alg string) []crypto.PublicKey {
+	keys, fetchedAt := j.snapshot()
+	if fetchedAt.IsZero() || time.Since(fetchedAt) > j.config.RefreshInterval {
+		j.refresh(fetchedAt)
+		keys, fetchedAt = j.snapshot()
+	}
+
+	found := keys.find(kid, alg)
+	if len(found) == 0 && kid != "" {
+		// 발급자가 새 키로 서명하기 시작했을 수 있음
+		j.refresh(fetchedAt)
+		keys, _ = j.snapshot()
+		found = keys.find(kid, alg)
+	}
+	return found
+}
+
+// Refresh는 키 목록을 즉시 다시 가져옵니다.
+func (j *JWKS) Refresh(ctx context.Context) error {
+	j.fetchMu.Lock()
+	defer j.fetchMu.Unlock()
+	return j.fetch(ctx)
+}
+
+// snapshot은 현재 키 목록과 가져온 시각을 반환합니다.
+func (j *JWKS) snapshot() (keySet, time.Time) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.keys, j.fetchedAt
+}
+
+// refresh는 seen 이후 다른 요청이 키 목록을 갱신하지 않았고 최소 간격이 지났으면 키 목록을 다시 가져옵니다.
+func (j *JWKS) refresh(seen time.Time) {
+	j.fetchMu.Lock()
+	defer j.fetchMu.Unlock()
+
+	if _, fetchedAt := j.snapshot(); fetchedAt.After(seen) {
+		return
+	}
+	if !j.lastAttempt.IsZero() && time.Since(j.lastAttempt) < j.config.MinRefreshInterval {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultJWKSFetchTimeout)
+	defer cancel()
+	if err := j.fetch(ctx); err != nil {
+		log.Printf("[AUTH] JWKS 가져오기 실패: %s - %v", j.config.URL, err)
+	}
+}
+
+// fetch는 JWKS 문서를 가져와 키 목록을 교체합니다. 호출자는 fetchMu를 보유해야 합니다.
+func (j *JWKS) fetch(ctx context.Context) error {
+	j.lastAttempt = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.config.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := j.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("JWKS 응답 상태 코드가 올바르지 않습니다: %d", resp.StatusCode)
+	}
+
+	var document struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSBytes)).Decode(&document); err != nil {
+		return fmt.Errorf("JWKS 문서 파싱 실패: %w", err)
+	}
+
+	var keys keySet
+	for _, raw := range document.Keys {
+		key, err := parseJWK(raw)
+		if err != nil {
+			log.Printf("[AUTH] JWKS 키 무시: %v", err)
+			continue
+		}
+		if key != nil {
+			keys = append(keys, *key)
+		}
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.fetchedAt = time.Now()
+	j.mu.Unlock()
+	return nil
+}
+
+// jsonWebKey는 JWK 문서의 공개 키 필드입니다 (RFC 7517, RFC 7518, RFC 8037).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWK는 JWK를 공개 키로 변환합니다. 서명용이 아닌 키는 nil을 반환합니다.
+func parseJWK(raw json.RawMessage) (*publicKey, error) {
+	var jwk jsonWebKey
+	if err := json.Unmarshal(raw, &jwk); err != nil {
+		return nil, err
+	}
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return nil, nil
+	}
+
+	var key crypto.PublicKey
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decodeJWKField(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("kid=%s: n: %w", jwk.Kid, err)
+		}
+		e, err := decodeJWKField(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("kid=%s: e: %w", jwk.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 || exponent.Int64() < 2 {
+			return nil, fmt.Errorf("kid=%s: 올바르지 않은 RSA 지수입니다", jwk.Kid)
+		}
+		key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("kid=%s: 지원하지 않는 곡선입니다: %s", jwk.Kid, jwk.Crv)
+		}
+		x, err := decodeJWKField(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("kid=%s: x: %w", jwk.Kid, err)
+		}
+		y, err := decodeJWKField(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("kid=%s: y: %w", jwk.Kid, err)
+		}
+		ecKey := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(ecKey.X, ecKey.Y) {
+			return nil, fmt.Errorf("kid=%s: 곡선 위의 점이 아닙니다", jwk.Kid)
+		}
+		key = ecKey
+
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("kid=%s: 지원하지 않는 곡선입니다: %s", jwk.Kid, jwk.Crv)
+		}
+		x, err := decodeJWKField(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("kid=%s: x: %w", jwk.Kid, err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("kid=%s: 올바르지 않은 Ed25519 키 길이입니다", jwk.Kid)
+		}
+		key = ed25519.PublicKey(x)
+
+	default:
+		return nil, fmt.Errorf("kid=%s: 지원하지 않는 키 종류입니다: %s", jwk.Kid, jwk.Kty)
+	}
+
+	return &publicKey{kid: jwk.Kid, alg: jwk.Alg, key: key}, nil
+}
+
+// decodeJWKField는 base64url로 인코딩된 JWK 필드를 디코딩합니다.
+func decodeJWKField(value string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("값이 없습니다")
+	}
+	return base64.RawURLEncoding.DecodeString(value)
+}
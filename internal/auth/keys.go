@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// KeySource는 토큰 서명을 검증할 공개 키를 제공합니다.
+type KeySource interface {
+	// Keys는 kid와 서명 알고리즘에 맞는 공개 키 후보를 반환합니다.
+	// kid가 비어 있으면 알고리즘에 맞는 모든 키를 반환합니다.
+	Keys(kid, alg string) []crypto.PublicKey
+}
+
+// publicKey는 키 ID와 허용 알고리즘을 가진 공개 키입니다.
+type publicKey struct {
+	kid string
+	alg string // 비어 있으면 키 종류에 맞는 모든 알고리즘 허용
+	key crypto.PublicKey
+}
+
+// keySet은 공개 키 목록입니다.
+type keySet []publicKey
+
+// find는 kid와 알고리즘에 맞는 키를 찾습니다.
+func (s keySet) find(kid, alg string) []crypto.PublicKey {
+	var keys []crypto.PublicKey
+	for _, k := range s {
+		if kid != "" && k.kid != kid {
+			continue
+		}
+		if k.alg != "" && k.alg != alg {
+			continue
+		}
+		if keyMatchesAlgorithm(k.key, alg) {
+			keys = append(keys, k.key)
+		}
+	}
+	return keys
+}
+
+// StaticKeys는 PEM 파일에서 읽은 고정 공개 키 목록입니다.
+// 키 ID(kid)는 확장자를 제외한 파일 이름입니다 (예: keys/issuer-2024.pem -> issuer-2024).
+type StaticKeys struct {
+	keys keySet
+}
+
+// LoadPublicKeys는 PEM 파일에서 RSA, ECDSA, Ed25519 공개 키를 읽습니다.
+// PKIX 공개 키(PUBLIC KEY), PKCS#1 RSA 공개 키(RSA PUBLIC KEY), X.509 인증서(CERTIFICATE)를 지원합니다.
+func LoadPublicKeys(paths []string) (*StaticKeys, error) {
+	static := &StaticKeys{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("공개 키 파일을 읽을 수 없습니다: %w", err)
+		}
+
+		keys, err := parsePublicKeysPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("공개 키 파일 파싱 실패 (%s): %w", path, err)
+		}
+
+		kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		for _, key := range keys {
+			static.keys = append(static.keys, publicKey{kid: kid, key: key})
+		}
+	}
+	return static, nil
+}
+
+// Keys는 kid와 서명 알고리즘에 맞는 공개 키를 반환합니다.
+func (s *StaticKeys) Keys(kid, alg string) []crypto.PublicKey {
+	return s.keys.find(kid, alg)
+}
+
+// parsePublicKeysPEM은 PEM 데이터의 모든 공개 키를 파싱합니다.
+func parsePublicKeysPEM(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		var key crypto.PublicKey
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch key.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+			keys = append(keys, key)
+		default:
+			return nil, fmt.Errorf("지원하지 않는 공개 키 형식입니다: %T", key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("PEM 공개 키가 없습니다")
+	}
+	return keys, nil
+}
+
+// keyMatchesAlgorithm은 공개 키 종류가 서명 알고리즘과 맞는지 확인합니다.
+func keyMatchesAlgorithm(key crypto.PublicKey, alg string) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		switch alg {
+		case "ES256":
+			return k.Curve == elliptic.P256()
+		case "ES384":
+			return k.Curve == elliptic.P384()
+		case "ES512":
+			return k.Curve == elliptic.P521()
+		}
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	}
+	return false
+}
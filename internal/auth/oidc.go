@@ -0,0 +1,310 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OIDC 기본 설정
+const (
+	defaultOIDCTimeout = 5 * time.Second
+	maxOIDCBytes       = 1 << 20
+)
+
+// DefaultOIDCScopes는 스코프를 지정하지 않았을 때 요청하는 스코프입니다 (offline_access는 갱신 토큰 발급용).
+var DefaultOIDCScopes = []string{"openid", "profile", "email", "offline_access"}
+
+// OIDCConfig는 OpenID Connect 인가 코드 흐름 설정입니다.
+type OIDCConfig struct {
+	Issuer       string   // IdP 발급자 URL (discovery 문서 위치)
+	ClientID     string   // 클라이언트 ID
+	ClientSecret string   // 클라이언트 비밀 (비어 있으면 PKCE만 사용하는 공개 클라이언트)
+	RedirectURL  string   // 인가 코드를 받을 콜백 URL (/auth/callback)
+	Scopes       []string // 요청할 스코프 (기본 DefaultOIDCScopes)
+
+	// 엔드포인트를 직접 지정하면 discovery 문서보다 우선합니다.
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	EndSessionEndpoint    string
+	RevocationEndpoint    string
+
+	Client *http.Client // nil이면 5초 타임아웃의 기본 클라이언트
+}
+
+// OIDCTokens는 토큰 엔드포인트 응답입니다.
+type OIDCTokens struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	ExpiresAt    time.Time // 액세스 토큰 만료 시각 (응답에 expires_in이 없으면 0)
+}
+
+// oidcEndpoints는 discovery 문서의 엔드포인트입니다.
+type oidcEndpoints struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+	RevocationEndpoint    string `json:"revocation_endpoint"`
+}
+
+// OIDCProvider는 IdP와 인가 코드 + PKCE 흐름(RFC 7636)을 수행하는 클라이언트입니다.
+// 엔드포인트는 첫 사용 시 discovery 문서에서 가져오며, 가져오기에 실패하면 다음 요청에서 다시 시도합니다.
+type OIDCProvider struct {
+	config OIDCConfig
+
+	mu        sync.Mutex
+	endpoints *oidcEndpoints
+}
+
+// NewOIDCProvider는 OIDC 클라이언트를 생성합니다.
+func NewOIDCProvider(config OIDCConfig) *OIDCProvider {
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultOIDCScopes
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: defaultOIDCTimeout}
+	}
+	return &OIDCProvider{config: config}
+}
+
+// AuthCodeURL은 로그인을 위해 브라우저를 보낼 IdP 인가 엔드포인트 URL을 반환합니다.
+// verifier는 PKCE 코드 검증자이며 IdP에는 S256 챌린지만 전달합니다.
+func (p *OIDCProvider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	endpoints, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {PKCEChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	return appendQuery(endpoints.AuthorizationEndpoint, query), nil
+}
+
+// Exchange는 인가 코드와 PKCE 코드 검증자로 토큰을 발급받습니다.
+func (p *OIDCProvider) Exchange(ctx context.Context, code, verifier string) (*OIDCTokens, error) {
+	return p.token(ctx, url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"code_verifier": {verifier},
+	})
+}
+
+// Refresh는 갱신 토큰으로 새 액세스 토큰을 발급받습니다.
+// 응답에 갱신 토큰이 없으면 기존 갱신 토큰을 그대로 반환합니다.
+func (p *OIDCProvider) Refresh(ctx context.Context, refreshToken string) (*OIDCTokens, error) {
+	tokens, err := p.token(ctx, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tokens.RefreshToken == "" {
+		tokens.RefreshToken = refreshToken
+	}
+	return tokens, nil
+}
+
+// Revoke는 IdP의 토큰 폐기 엔드포인트(RFC 7009)로 토큰을 폐기합니다. 엔드포인트가 없으면 아무것도 하지 않습니다.
+func (p *OIDCProvider) Revoke(ctx context.Context, token, tokenTypeHint string) error {
+	endpoints, err := p.discover(ctx)
+	if err != nil || endpoints.RevocationEndpoint == "" {
+		return err
+	}
+
+	resp, err := p.post(ctx, endpoints.RevocationEndpoint, url.Values{"token": {token}, "token_type_hint": {tokenTypeHint}})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("토큰 폐기 응답 상태 코드가 올바르지 않습니다: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// EndSessionURL은 IdP 세션을 종료할 URL(RP-Initiated Logout)을 반환합니다. 엔드포인트가 없으면 false를 반환합니다.
+func (p *OIDCProvider) EndSessionURL(ctx context.Context, postLogoutRedirect string) (string, bool) {
+	endpoints, err := p.discover(ctx)
+	if err != nil || endpoints.EndSessionEndpoint == "" {
+		return "", false
+	}
+
+	query := url.Values{"client_id": {p.config.ClientID}}
+	if postLogoutRedirect != "" {
+		query.Set("post_logout_redirect_uri", postLogoutRedirect)
+	}
+	return appendQuery(endpoints.EndSessionEndpoint, query), true
+}
+
+// token은 토큰 엔드포인트를 호출합니다.
+func (p *OIDCProvider) token(ctx context.Context, form url.Values) (*OIDCTokens, error) {
+	endpoints, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.post(ctx, endpoints.TokenEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		IDToken          string `json:"id_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCBytes)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("토큰 응답 파싱 실패 (상태 코드 %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return nil, fmt.Errorf("토큰 발급 실패 (상태 코드 %d): %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.AccessToken == "" {
+		return nil, errors.New("토큰 응답에 access_token이 없습니다")
+	}
+
+	tokens := &OIDCTokens{
+		AccessToken:  body.AccessToken,
+		RefreshToken: body.RefreshToken,
+		IDToken:      body.IDToken,
+	}
+	if body.ExpiresIn > 0 {
+		tokens.ExpiresAt = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return tokens, nil
+}
+
+// post는 클라이언트 인증 정보와 함께 폼을 전송합니다.
+// 클라이언트 비밀이 있으면 HTTP Basic으로, 없으면 폼의 client_id로 클라이언트를 식별합니다.
+func (p *OIDCProvider) post(ctx context.Context, endpoint string, form url.Values) (*http.Response, error) {
+	if p.config.ClientSecret == "" {
+		form.Set("client_id", p.config.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+	return p.config.Client.Do(req)
+}
+
+// discover는 IdP 엔드포인트를 반환합니다. 직접 지정하지 않은 엔드포인트는 discovery 문서에서 가져옵니다.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcEndpoints, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.endpoints != nil {
+		return p.endpoints, nil
+	}
+
+	endpoints := &oidcEndpoints{}
+	if p.config.AuthorizationEndpoint == "" || p.config.TokenEndpoint == "" {
+		fetched, err := p.fetchDiscovery(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("OIDC discovery 실패: %w", err)
+		}
+		endpoints = fetched
+	}
+
+	for _, override := range []struct {
+		value  string
+		target *string
+	}{
+		{p.config.AuthorizationEndpoint, &endpoints.AuthorizationEndpoint},
+		{p.config.TokenEndpoint, &endpoints.TokenEndpoint},
+		{p.config.EndSessionEndpoint, &endpoints.EndSessionEndpoint},
+		{p.config.RevocationEndpoint, &endpoints.RevocationEndpoint},
+	} {
+		if override.value != "" {
+			*override.target = override.value
+		}
+	}
+	if endpoints.AuthorizationEndpoint == "" || endpoints.TokenEndpoint == "" {
+		return nil, errors.New("인가 엔드포인트와 토큰 엔드포인트가 필요합니다")
+	}
+
+	p.endpoints = endpoints
+	return endpoints, nil
+}
+
+// fetchDiscovery는 발급자의 discovery 문서(/.well-known/openid-configuration)를 가져옵니다.
+func (p *OIDCProvider) fetchDiscovery(ctx context.Context) (*oidcEndpoints, error) {
+	issuer := strings.TrimSuffix(p.config.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery 응답 상태 코드가 올바르지 않습니다: %d", resp.StatusCode)
+	}
+
+	var endpoints oidcEndpoints
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOIDCBytes)).Decode(&endpoints); err != nil {
+		return nil, fmt.Errorf("discovery 문서 파싱 실패: %w", err)
+	}
+	if strings.TrimSuffix(endpoints.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("discovery 문서의 발급자가 일치하지 않습니다: %s", endpoints.Issuer)
+	}
+	return &endpoints, nil
+}
+
+// RandomToken은 state, nonce, PKCE 코드 검증자로 사용할 임의의 문자열(32바이트, base64url)을 생성합니다.
+func RandomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("임의 값 생성 실패: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// PKCEChallenge는 코드 검증자의 S256 챌린지를 반환합니다 (RFC 7636).
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// appendQuery는 기존 쿼리를 유지하며 URL에 쿼리 파라미터를 추가합니다.
+func appendQuery(endpoint string, query url.Values) string {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	return endpoint + separator + query.Encode()
+}
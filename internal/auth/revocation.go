@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/isinthesky/api-gateway/pkg/redis"
+)
+
+// RevocationStore는 폐기된 토큰과 사용자를 보관하는 저장소입니다.
+// 여러 게이트웨이 인스턴스가 같은 저장소를 공유하면 한 인스턴스에서 폐기한 토큰이 모든 인스턴스에서 거부됩니다.
+type RevocationStore interface {
+	// Revoke는 키를 expiresAt까지 폐기 목록에 추가합니다. 이미 지난 expiresAt은 무시합니다.
+	Revoke(ctx context.Context, key string, revokedAt, expiresAt time.Time) error
+	// RevokedAt은 키가 폐기된 시각을 반환합니다. 폐기되지 않았으면 0 시각을 반환합니다.
+	RevokedAt(ctx context.Context, key string) (time.Time, error)
+	// Close는 저장소가 사용하는 자원을 해제합니다.
+	Close() error
+}
+
+// Denylist는 토큰 ID(jti)와 사용자(sub) 단위의 토큰 폐기 목록입니다.
+// 폐기 항목은 토큰이 만료되는 시각(exp)에 자동으로 삭제됩니다.
+type Denylist struct {
+	store RevocationStore
+}
+
+// NewDenylist는 저장소를 사용하는 폐기 목록을 생성합니다.
+func NewDenylist(store RevocationStore) *Denylist {
+	return &Denylist{store: store}
+}
+
+// TokenID는 폐기 목록에서 토큰을 식별하는 ID를 반환합니다.
+// jti 클레임이 없는 토큰은 토큰 문자열의 SHA-256 해시를 사용합니다.
+func TokenID(claims *Claims, token string) string {
+	if claims != nil && claims.ID != "" {
+		return claims.ID
+	}
+	return "sha256:" + hashToken(token)
+}
+
+// RevokeToken은 토큰 하나를 expiresAt(토큰의 exp)까지 폐기합니다.
+func (d *Denylist) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if tokenID == "" {
+		return errors.New("토큰 ID가 필요합니다")
+	}
+	return d.store.Revoke(ctx, "jti:"+tokenID, time.Now(), expiresAt)
+}
+
+// RevokeSubject는 사용자에게 지금까지 발급된 모든 토큰을 폐기하고 폐기 시각을 반환합니다 (모든 기기에서 로그아웃).
+// 폐기 이후 발급된 토큰은 영향을 받지 않으며, expiresAt은 폐기 전에 발급된 토큰이 모두 만료되는 시각이어야 합니다.
+func (d *Denylist) RevokeSubject(ctx context.Context, subject string, expiresAt time.Time) (time.Time, error) {
+	if subject == "" {
+		return time.Time{}, errors.New("사용자 ID가 필요합니다")
+	}
+	revokedAt := time.Now()
+	return revokedAt, d.store.Revoke(ctx, "sub:"+subject, revokedAt, expiresAt)
+}
+
+// IsRevoked는 토큰이 폐기되었는지 확인합니다.
+// 사용자 단위로 폐기된 경우 폐기 시각 이전에 발급된(iat) 토큰과 iat가 없는 토큰을 폐기된 것으로 봅니다.
+// iat는 초 단위이므로 폐기 시각도 초 단위로 내림해 비교하며, 폐기와 같은 초에 발급된 토큰은 허용합니다.
+func (d *Denylist) IsRevoked(ctx context.Context, claims *Claims, token string) (bool, error) {
+	revokedAt, err := d.store.RevokedAt(ctx, "jti:"+TokenID(claims, token))
+	if err != nil {
+		return false, err
+	}
+	if !revokedAt.IsZero() {
+		return true, nil
+	}
+
+	if claims.Subject == "" {
+		return false, nil
+	}
+	revokedAt, err = d.store.RevokedAt(ctx, "sub:"+claims.Subject)
+	if err != nil || revokedAt.IsZero() {
+		return false, err
+	}
+	return claims.IssuedAt == nil || claims.IssuedAt.Time.Before(revokedAt.Truncate(time.Second)), nil
+}
+
+// Close는 저장소를 닫습니다.
+func (d *Denylist) Close() error {
+	return d.store.Close()
+}
+
+// revocation은 메모리 저장소의 폐기 항목입니다.
+type revocation struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+// MemoryRevocationStore는 프로세스 메모리에 폐기 목록을 보관하는 저장소입니다. 인스턴스 간에 공유되지 않습니다.
+type MemoryRevocationStore struct {
+	mu      sync.Mutex
+	entries map[string]revocation
+}
+
+// NewMemoryRevocationStore는 메모리 폐기 목록 저장소를 생성합니다.
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{entries: make(map[string]revocation)}
+}
+
+// Revoke는 키를 expiresAt까지 폐기 목록에 추가합니다. 같은 키를 다시 폐기하면 더 늦은 시각을 유지합니다.
+func (s *MemoryRevocationStore) Revoke(ctx context.Context, key string, revokedAt, expiresAt time.Time) error {
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 폐기는 드물게 일어나므로 추가할 때 만료된 항목을 정리
+	for k, entry := range s.entries {
+		if !entry.expiresAt.After(now) {
+			delete(s.entries, k)
+		}
+	}
+
+	if existing, ok := s.entries[key]; ok {
+		if existing.revokedAt.After(revokedAt) {
+			revokedAt = existing.revokedAt
+		}
+		if existing.expiresAt.After(expiresAt) {
+			expiresAt = existing.expiresAt
+		}
+	}
+	s.entries[key] = revocation{revokedAt: revokedAt, expiresAt: expiresAt}
+	return nil
+}
+
+// RevokedAt은 키가 폐기된 시각을 반환합니다.
+func (s *MemoryRevocationStore) RevokedAt(ctx context.Context, key string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok {
+		return time.Time{}, nil
+	}
+	if !entry.expiresAt.After(time.Now()) {
+		delete(s.entries, key)
+		return time.Time{}, nil
+	}
+	return entry.revokedAt, nil
+}
+
+// Close는 아무것도 하지 않습니다.
+func (s *MemoryRevocationStore) Close() error {
+	return nil
+}
+
+// RedisRevocationConfig는 Redis 폐기 목록 저장소 설정입니다.
+type RedisRevocationConfig struct {
+	Addr      string        // host:port
+	Password  string        // AUTH 비밀번호 (비어 있으면 인증하지 않음)
+	DB        int           // SELECT할 데이터베이스 번호
+	KeyPrefix string        // 모든 키 앞에 붙일 접두사 (기본 "revoked:")
+	Timeout   time.Duration // 명령별 읽기/쓰기 타임아웃 (기본 100ms)
+}
+
+// RevokeScript는 폐기 항목을 추가하면서 기존 항목의 더 늦은 폐기 시각과 만료 시각을 유지하는 Redis Lua 스크립트입니다.
+// KEYS[1]: 폐기 키, ARGV: 폐기 시각(유닉스 나노초), 남은 유효 시간(ms)
+// 나노초 값은 Lua 숫자(double)로 정확히 표현되지 않으므로 10진수 문자열의 길이와 사전 순서로 비교합니다.
+const RevokeScript = `
+local revokedAt = ARGV[1]
+local ttl = tonumber(ARGV[2])
+local current = redis.call('GET', KEYS[1])
+if current and (#current > #revokedAt or (#current == #revokedAt and current > revokedAt)) then
+  revokedAt = current
+end
+local remaining = redis.call('PTTL', KEYS[1])
+if remaining > ttl then
+  ttl = remaining
+end
+return redis.call('SET', KEYS[1], revokedAt, 'PX', ttl)
+`
+
+// revokeScriptSHA는 EVALSHA에 사용하는 RevokeScript의 SHA1 해시입니다.
+var revokeScriptSHA = func() string {
+	sum := sha1.Sum([]byte(RevokeScript))
+	return hex.EncodeToString(sum[:])
+}()
+
+// RedisRevocationStore는 Redis 프로토콜(RESP)을 사용하는 서버에 폐기 목록을 보관하는 저장소입니다.
+// 항목 만료는 서버(PX)가 담당합니다.
+type RedisRevocationStore struct {
+	keyPrefix string
+	client    *redis.Client
+}
+
+// NewRedisRevocationStore는 Redis 폐기 목록 저장소를 생성합니다. 연결은 첫 요청 시 수립됩니다.
+func NewRedisRevocationStore(config RedisRevocationConfig) *RedisRevocationStore {
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "revoked:"
+	}
+	return &RedisRevocationStore{
+		keyPrefix: config.KeyPrefix,
+		client: redis.NewClient(redis.Config{
+			Addr:     config.Addr,
+			Password: config.Password,
+			DB:       config.DB,
+			Timeout:  config.Timeout,
+		}),
+	}
+}
+
+// Revoke는 키를 expiresAt까지 폐기 목록에 추가합니다. 메모리 저장소와 같이 같은 키를 다시 폐기하면 더 늦은 시각을 유지합니다.
+// 스크립트는 EVALSHA로 실행하고, 서버에 스크립트가 없으면 EVAL로 다시 실행합니다.
+func (s *RedisRevocationStore) Revoke(ctx context.Context, key string, revokedAt, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt).Milliseconds()
+	if ttl <= 0 {
+		return nil
+	}
+
+	args := []string{revokeScriptSHA, "1", s.keyPrefix + key, strconv.FormatInt(revokedAt.UnixNano(), 10), strconv.FormatInt(ttl, 10)}
+	_, err := s.client.Do(ctx, append([]string{"EVALSHA"}, args...)...)
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "NOSCRIPT") {
+		args[0] = RevokeScript
+		_, err = s.client.Do(ctx, append([]string{"EVAL"}, args...)...)
+	}
+	return err
+}
+
+// RevokedAt은 키가 폐기된 시각을 반환합니다.
+func (s *RedisRevocationStore) RevokedAt(ctx context.Context, key string) (time.Time, error) {
+	reply, err := s.client.Do(ctx, "GET", s.keyPrefix+key)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	value, _ := reply.(string)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, nanos), nil
+}
+
+// Close는 Redis 연결을 닫습니다.
+func (s *RedisRevocationStore) Close() error {
+	return s.client.Close()
+}
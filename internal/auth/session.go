@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// minSessionSecretLength는 세션 암호화 비밀의 최소 길이입니다.
+const minSessionSecretLength = 32
+
+// Session은 브라우저 로그인 세션입니다. 암호화된 쿠키에 저장됩니다.
+type Session struct {
+	AccessToken  string    `json:"at"`
+	RefreshToken string    `json:"rt,omitempty"`
+	ExpiresAt    time.Time `json:"exp,omitempty"` // 액세스 토큰 만료 시각 (0이면 알 수 없음)
+	EndsAt       time.Time `json:"end"`           // 세션 만료 시각 (갱신해도 연장되지 않음)
+}
+
+// SessionCodec은 쿠키 값을 AES-256-GCM으로 암호화하고 인증합니다.
+// 브라우저는 값을 읽거나 수정할 수 없으며, 용도(name)가 다른 쿠키 값으로 바꿔치기할 수 없습니다.
+type SessionCodec struct {
+	aead cipher.AEAD
+}
+
+// NewSessionCodec은 비밀로부터 암호화 키를 만들어 코덱을 생성합니다. 비밀은 32자 이상이어야 합니다.
+func NewSessionCodec(secret string) (*SessionCodec, error) {
+	if len(secret) < minSessionSecretLength {
+		return nil, fmt.Errorf("세션 암호화 비밀은 %d자 이상이어야 합니다", minSessionSecretLength)
+	}
+
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionCodec{aead: aead}, nil
+}
+
+// Encode는 값을 JSON으로 직렬화하여 암호화한 쿠키 값을 반환합니다. name은 쿠키 용도이며 Decode에도 같은 값을 사용해야 합니다.
+func (c *SessionCodec) Encode(name string, value interface{}) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := c.aead.Seal(nonce, nonce, plaintext, []byte(name))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode는 쿠키 값을 복호화하여 value에 저장합니다. 값이 수정되었거나 용도가 다르면 오류를 반환합니다.
+func (c *SessionCodec) Decode(name, encoded string, value interface{}) error {
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return errors.New("올바르지 않은 세션 값입니다")
+	}
+	if len(sealed) < c.aead.NonceSize() {
+		return errors.New("올바르지 않은 세션 값입니다")
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plaintext, err := c.aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return errors.New("세션 값을 복호화할 수 없습니다")
+	}
+	return json.Unmarshal(plaintext, value)
+}
@@ -2,14 +2,21 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"github.com/isinthesky/api-gateway/pkg/cache"
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+	"github.com/isinthesky/api-gateway/pkg/concurrency"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
 )
 
 // Config는 애플리케이션 설정을 저장하는 구조체입니다.
@@ -20,7 +27,50 @@ type Config struct {
 	JWTSecret                   string        // JWT 토큰 검증용 비밀 키
 	JWTIssuer                   string        // JWT 토큰 발행자
 	JWTExpirationDelta          time.Duration // JWT 토큰 만료 시간 (초)
-	AllowedOrigins              []string      // CORS 허용 오리진 목록
+	JWTAcceptedIssuers          []string      // JWTIssuer 외에 허용하는 토큰 발행자 (외부 IdP)
+	JWTPublicKeyFiles           []string      // RS256/ES256/EdDSA 토큰 검증용 공개 키 PEM 파일 목록
+	JWTJWKSURL                  string        // 토큰 검증용 공개 키 목록(JWKS) URL
+	JWTJWKSRefreshInterval      time.Duration // JWKS를 다시 가져오는 주기
+	JWTJWKSMinRefreshInterval   time.Duration // 모르는 kid의 토큰으로 JWKS를 다시 가져올 때의 최소 간격
+	IntrospectionURL            string        // 불투명 토큰 검증용 OAuth2 introspection 엔드포인트 (비어 있으면 비활성화)
+	IntrospectionClientID       string        // introspection 엔드포인트 인증용 클라이언트 ID
+	IntrospectionClientSecret   string        // introspection 엔드포인트 인증용 클라이언트 비밀
+	IntrospectionTimeout        time.Duration // introspection 호출 타임아웃
+	IntrospectionCacheTTL       time.Duration // 활성 토큰 결과의 최대 캐시 시간 (토큰 만료 시각을 넘지 않음)
+	IntrospectionNegativeCacheTTL time.Duration // 비활성 토큰 결과의 캐시 시간
+	IntrospectionCacheMaxEntries  int         // introspection 결과 캐시 최대 항목 수
+	ForwardIdentity             bool          // 인증된 사용자 정보를 업스트림에 헤더로 전달할지 여부
+	IdentityHeaders             []IdentityHeader // 업스트림에 전달할 사용자 정보 헤더와 클레임
+	InternalJWTSecret           string        // 업스트림에 전달할 내부 JWT 서명 키 (비어 있으면 발급하지 않음)
+	InternalJWTHeader           string        // 내부 JWT를 전달할 헤더
+	InternalJWTIssuer           string        // 내부 JWT 발행자
+	InternalJWTAudience         string        // 내부 JWT 대상 (aud)
+	InternalJWTTTL              time.Duration // 내부 JWT 유효 기간
+	APIKeysFile                 string        // 해시된 API 키 목록 파일 (비어 있으면 API 키 인증 비활성화)
+	APIKeyHeader                string        // API 키를 전달하는 요청 헤더
+	APIKeyQueryParam            string        // API 키를 전달하는 쿼리 파라미터 (비어 있으면 사용하지 않음)
+	OIDCIssuer                  string        // 브라우저 로그인에 사용할 OIDC IdP 발급자 URL (비어 있으면 비활성화)
+	OIDCClientID                string        // OIDC 클라이언트 ID
+	OIDCClientSecret            string        // OIDC 클라이언트 비밀 (비어 있으면 PKCE만 사용하는 공개 클라이언트)
+	OIDCRedirectURL             string        // 인가 코드를 받을 콜백 URL (예: https://app.example.com/auth/callback)
+	OIDCScopes                  []string      // 요청할 스코프 (비어 있으면 openid, profile, email, offline_access)
+	OIDCSessionSecret           string        // 세션 쿠키 암호화 비밀 (32자 이상)
+	OIDCSessionCookie           string        // 세션 쿠키 이름
+	OIDCSessionTTL              time.Duration // 세션 최대 유지 시간 (토큰을 갱신해도 연장되지 않음)
+	OIDCCookieSecure            bool          // 세션 쿠키에 Secure 속성을 지정할지 여부
+	OIDCPostLogoutRedirectURL   string        // 로그아웃 후 이동할 URL
+	RevocationStore             string        // 토큰 폐기 목록 저장소 (memory, redis)
+	RevocationRedisAddr         string        // Redis 폐기 목록 저장소 주소 (host:port)
+	RevocationRedisPassword     string        // Redis 폐기 목록 저장소 비밀번호
+	RevocationRedisDB           int           // Redis 폐기 목록 저장소 데이터베이스 번호
+	RevocationRedisTimeout      time.Duration // Redis 폐기 목록 저장소 명령 타임아웃
+	RevocationFailureMode       string        // 폐기 목록 저장소 장애 시 처리 방식 (open: 허용, closed: 거부)
+	RevocationDefaultTTL        time.Duration // 만료 시각을 알 수 없는 폐기 항목의 유지 시간
+	CSRFMode                    string        // 쿠키로 인증한 상태 변경 요청의 CSRF 검사 방식 (origin, token, both, off)
+	CSRFTrustedOrigins          []string      // AllowedOrigins 외에 CSRF 검사에서 허용하는 오리진
+	CSRFCookie                  string        // CSRF 토큰 쿠키 이름 (token, both 방식)
+	CSRFHeader                  string        // CSRF 토큰을 전달하는 요청 헤더 (token, both 방식)
+	AllowedOrigins              []string      // CORS 허용 오리진 목록 (CSRF 검사에서도 허용)
 	EnableMetrics               bool          // Prometheus 메트릭 수집 활성화 여부
 	LogLevel                    string        // 로그 레벨 (debug, info, warn, error)
 	MaxContentSize              int64         // 최대 요청 본문 크기 (바이트)
@@ -29,14 +79,47 @@ type Config struct {
 	IdleTimeout                 time.Duration // 유휴 타임아웃 (초)
 	RateLimitWindow             time.Duration // 레이트 리밋 윈도우 크기 (초)
 	RateLimitMaxReqs            int           // 윈도우 당 최대 요청 수
+	RateLimitAlgorithm          string        // 레이트 리밋 알고리즘 (token-bucket, sliding-window)
+	RateLimitStore              string        // 레이트 리밋 상태 저장소 (memory, redis)
+	RateLimitRedisAddr          string        // Redis 저장소 주소 (host:port)
+	RateLimitRedisPassword      string        // Redis 저장소 비밀번호
+	RateLimitRedisDB            int           // Redis 저장소 데이터베이스 번호
+	RateLimitRedisTimeout       time.Duration // Redis 명령 타임아웃
+	RateLimitFailureMode        string        // 저장소 장애 시 처리 방식 (open: 허용, closed: 거부)
 	RoutesConfigPath            string        // 라우트 설정 파일 경로
+	RoutesReloadInterval        time.Duration // 라우트 설정 파일 변경 감지 주기 (0이면 비활성화)
 	EnableCaching               bool          // 캐싱 활성화 여부
 	CacheTTL                    time.Duration // 캐시 항목 기본 수명
+	CacheMaxEntries             int           // 메모리 캐시 최대 항목 수
+	CacheMaxBytes               int64         // 메모리 캐시 최대 크기 (바이트)
+	CacheMaxItemBytes           int64         // 캐시 항목 하나의 최대 크기 (바이트)
+	CacheStore                  string        // 캐시 저장소 (memory, redis, disk)
+	CacheRedisAddr              string        // Redis 캐시 주소 (host:port)
+	CacheRedisPassword          string        // Redis 캐시 비밀번호
+	CacheRedisDB                int           // Redis 캐시 데이터베이스 번호
+	CacheRedisTimeout           time.Duration // Redis 캐시 명령 타임아웃
+	CacheDiskDir                string        // 디스크 캐시 디렉터리
+	CacheCoalesceTimeout        time.Duration // 같은 캐시 미스의 업스트림 응답을 기다리는 최대 시간 (0이면 비활성화)
 	CircuitBreakerErrorThreshold float64       // 서킷 브레이커 오류 임계값
 	CircuitBreakerMinRequests    int           // 서킷 브레이커 최소 요청 수
 	CircuitBreakerTimeout        time.Duration // 서킷 브레이커 타임아웃
 	CircuitBreakerHalfOpenReqs   int           // 서킷 브레이커 반열림 상태 최대 요청 수
 	CircuitBreakerSuccessThreshold int          // 서킷 브레이커 성공 임계값
+	CircuitBreakerFailureStatusCodes []int      // 서킷 브레이커가 실패로 기록할 응답 상태 코드
+	CircuitBreakerSlowCallThreshold  time.Duration // 서킷 브레이커가 실패로 기록할 응답 지연 시간 (0이면 비활성화)
+	CircuitBreakerFailureErrors      []string   // 서킷 브레이커가 실패로 기록할 오류 유형 (비어 있으면 모든 오류)
+	CircuitBreakerSlowCallRateThreshold float64 // 서킷 브레이커 지연 호출 비율 임계값 (0이면 지연 호출을 실패로 기록)
+	CircuitBreakerWindowType         string     // 서킷 브레이커 슬라이딩 윈도우 유형 (count, time)
+	CircuitBreakerWindowSize         int        // 서킷 브레이커 윈도우 크기 (count: 호출 수, time: 초)
+	UpstreamDialTimeout           time.Duration // 업스트림 연결 수립 타임아웃
+	UpstreamTLSHandshakeTimeout   time.Duration // 업스트림 TLS 핸드셰이크 타임아웃
+	UpstreamResponseHeaderTimeout time.Duration // 업스트림 응답 헤더 수신 타임아웃 (0이면 비활성화)
+	UpstreamIdleConnTimeout       time.Duration // 업스트림 유휴 연결 유지 시간
+	UpstreamMaxIdleConns          int           // 업스트림별 전체 최대 유휴 연결 수
+	UpstreamMaxIdleConnsPerHost   int           // 업스트림 대상 서버별 최대 유휴 연결 수
+	UpstreamMaxConnsPerHost       int           // 업스트림 대상 서버별 최대 연결 수 (0이면 제한 없음)
+	UpstreamHTTP2                 bool          // TLS 업스트림과 HTTP/2 사용 여부
+	UpstreamProxyFromEnvironment  bool          // 업스트림 요청에 HTTP_PROXY 등 환경 변수 프록시 사용 여부
 }
 
 // Load는 환경 변수와 구성 파일에서 설정을 로드합니다.
@@ -52,6 +135,48 @@ func Load() (*Config, error) {
 		JWTSecret:                 getEnv("JWT_SECRET_KEY", "your_jwt_secret_key_here"),
 		JWTIssuer:                 getEnv("JWT_ISSUER", "receiptally-auth-service"),
 		JWTExpirationDelta:        time.Duration(getEnvInt("JWT_EXPIRATION", 3600)) * time.Second,
+		JWTAcceptedIssuers:        getEnvArray("JWT_ACCEPTED_ISSUERS", nil),
+		JWTPublicKeyFiles:         getEnvArray("JWT_PUBLIC_KEY_FILES", nil),
+		JWTJWKSURL:                getEnv("JWT_JWKS_URL", ""),
+		JWTJWKSRefreshInterval:    time.Duration(getEnvInt("JWT_JWKS_REFRESH_INTERVAL", 3600)) * time.Second,
+		JWTJWKSMinRefreshInterval: time.Duration(getEnvInt("JWT_JWKS_MIN_REFRESH_INTERVAL", 60)) * time.Second,
+		IntrospectionURL:          getEnv("INTROSPECTION_URL", ""),
+		IntrospectionClientID:     getEnv("INTROSPECTION_CLIENT_ID", ""),
+		IntrospectionClientSecret: getEnv("INTROSPECTION_CLIENT_SECRET", ""),
+		IntrospectionTimeout:      time.Duration(getEnvInt("INTROSPECTION_TIMEOUT", 2000)) * time.Millisecond,
+		IntrospectionCacheTTL:     time.Duration(getEnvInt("INTROSPECTION_CACHE_TTL", 60)) * time.Second,
+		IntrospectionNegativeCacheTTL: time.Duration(getEnvInt("INTROSPECTION_NEGATIVE_CACHE_TTL", 10)) * time.Second,
+		IntrospectionCacheMaxEntries:  getEnvInt("INTROSPECTION_CACHE_MAX_ENTRIES", 10000),
+		ForwardIdentity:           getEnvBool("FORWARD_IDENTITY", true),
+		InternalJWTSecret:         getEnv("INTERNAL_JWT_SECRET", ""),
+		InternalJWTHeader:         getEnv("INTERNAL_JWT_HEADER", DefaultInternalJWTHeader),
+		InternalJWTIssuer:         getEnv("INTERNAL_JWT_ISSUER", "api-gateway"),
+		InternalJWTAudience:       getEnv("INTERNAL_JWT_AUDIENCE", ""),
+		InternalJWTTTL:            time.Duration(getEnvInt("INTERNAL_JWT_TTL", 60)) * time.Second,
+		APIKeysFile:               getEnv("API_KEYS_FILE", ""),
+		APIKeyHeader:              getEnv("API_KEY_HEADER", DefaultAPIKeyHeader),
+		APIKeyQueryParam:          getEnv("API_KEY_QUERY_PARAM", ""),
+		OIDCIssuer:                getEnv("OIDC_ISSUER", ""),
+		OIDCClientID:              getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret:          getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:           getEnv("OIDC_REDIRECT_URL", ""),
+		OIDCScopes:                getEnvArray("OIDC_SCOPES", nil),
+		OIDCSessionSecret:         getEnv("OIDC_SESSION_SECRET", ""),
+		OIDCSessionCookie:         getEnv("OIDC_SESSION_COOKIE", DefaultSessionCookie),
+		OIDCSessionTTL:            time.Duration(getEnvInt("OIDC_SESSION_TTL", 28800)) * time.Second, // 기본 8시간
+		OIDCCookieSecure:          getEnvBool("OIDC_COOKIE_SECURE", true),
+		OIDCPostLogoutRedirectURL: getEnv("OIDC_POST_LOGOUT_REDIRECT_URL", "/"),
+		RevocationStore:           getEnv("REVOCATION_STORE", RevocationStoreMemory),
+		RevocationRedisAddr:       getEnv("REVOCATION_REDIS_ADDR", "localhost:6379"),
+		RevocationRedisPassword:   getEnv("REVOCATION_REDIS_PASSWORD", ""),
+		RevocationRedisDB:         getEnvInt("REVOCATION_REDIS_DB", 0),
+		RevocationRedisTimeout:    time.Duration(getEnvInt("REVOCATION_REDIS_TIMEOUT", 100)) * time.Millisecond,
+		RevocationFailureMode:     getEnv("REVOCATION_FAILURE_MODE", string(ratelimiter.FailOpen)),
+		RevocationDefaultTTL:      time.Duration(getEnvInt("REVOCATION_DEFAULT_TTL", 86400)) * time.Second, // 기본 1일
+		CSRFMode:                  getEnv("CSRF_MODE", CSRFModeOrigin),
+		CSRFTrustedOrigins:        getEnvArray("CSRF_TRUSTED_ORIGINS", nil),
+		CSRFCookie:                getEnv("CSRF_COOKIE", DefaultCSRFCookie),
+		CSRFHeader:                getEnv("CSRF_HEADER", DefaultCSRFHeader),
 		AllowedOrigins:            getEnvArray("ALLOWED_ORIGINS", []string{"*"}),
 		EnableMetrics:             getEnvBool("ENABLE_METRICS", true),
 		LogLevel:                  getEnv("LOG_LEVEL", "info"),
@@ -61,14 +186,47 @@ func Load() (*Config, error) {
 		IdleTimeout:               time.Duration(getEnvInt("IDLE_TIMEOUT", 120)) * time.Second,
 		RateLimitWindow:           time.Duration(getEnvInt("RATE_LIMIT_WINDOW", 60)) * time.Second,
 		RateLimitMaxReqs:          getEnvInt("RATE_LIMIT_MAX_REQUESTS", 200),
+		RateLimitAlgorithm:        getEnv("RATE_LIMIT_ALGORITHM", RateLimitAlgorithmTokenBucket),
+		RateLimitStore:            getEnv("RATE_LIMIT_STORE", RateLimitStoreMemory),
+		RateLimitRedisAddr:        getEnv("RATE_LIMIT_REDIS_ADDR", "localhost:6379"),
+		RateLimitRedisPassword:    getEnv("RATE_LIMIT_REDIS_PASSWORD", ""),
+		RateLimitRedisDB:          getEnvInt("RATE_LIMIT_REDIS_DB", 0),
+		RateLimitRedisTimeout:     time.Duration(getEnvInt("RATE_LIMIT_REDIS_TIMEOUT", 100)) * time.Millisecond,
+		RateLimitFailureMode:      getEnv("RATE_LIMIT_FAILURE_MODE", string(ratelimiter.FailOpen)),
 		RoutesConfigPath:          getEnv("ROUTES_CONFIG_PATH", "configs/routes.json"),
+		RoutesReloadInterval:      time.Duration(getEnvInt("ROUTES_RELOAD_INTERVAL", 5)) * time.Second,
 		EnableCaching:             getEnvBool("ENABLE_CACHING", true),
 		CacheTTL:                  time.Duration(getEnvInt("CACHE_TTL", 300)) * time.Second, // 기본 5분
+		CacheMaxEntries:           getEnvInt("CACHE_MAX_ENTRIES", cache.DefaultMaxEntries),
+		CacheMaxBytes:             int64(getEnvInt("CACHE_MAX_BYTES", cache.DefaultMaxBytes)),
+		CacheMaxItemBytes:         int64(getEnvInt("CACHE_MAX_ITEM_BYTES", cache.DefaultMaxItemBytes)),
+		CacheStore:                getEnv("CACHE_STORE", CacheStoreMemory),
+		CacheRedisAddr:            getEnv("CACHE_REDIS_ADDR", "localhost:6379"),
+		CacheRedisPassword:        getEnv("CACHE_REDIS_PASSWORD", ""),
+		CacheRedisDB:              getEnvInt("CACHE_REDIS_DB", 0),
+		CacheRedisTimeout:         time.Duration(getEnvInt("CACHE_REDIS_TIMEOUT", 100)) * time.Millisecond,
+		CacheDiskDir:              getEnv("CACHE_DISK_DIR", "data/cache"),
+		CacheCoalesceTimeout:      time.Duration(getEnvInt("CACHE_COALESCE_TIMEOUT", 5000)) * time.Millisecond,
 		CircuitBreakerErrorThreshold: getEnvFloat("CIRCUIT_BREAKER_ERROR_THRESHOLD", 0.5),
 		CircuitBreakerMinRequests:   getEnvInt("CIRCUIT_BREAKER_MIN_REQUESTS", 10),
 		CircuitBreakerTimeout:       time.Duration(getEnvInt("CIRCUIT_BREAKER_TIMEOUT", 60)) * time.Second,
 		CircuitBreakerHalfOpenReqs:  getEnvInt("CIRCUIT_BREAKER_HALF_OPEN_REQS", 5),
 		CircuitBreakerSuccessThreshold: getEnvInt("CIRCUIT_BREAKER_SUCCESS_THRESHOLD", 3),
+		CircuitBreakerFailureStatusCodes: getEnvIntArray("CIRCUIT_BREAKER_FAILURE_STATUS_CODES", []int{500, 502, 503, 504}),
+		CircuitBreakerSlowCallThreshold:  time.Duration(getEnvInt("CIRCUIT_BREAKER_SLOW_CALL_THRESHOLD", 0)) * time.Millisecond,
+		CircuitBreakerFailureErrors:      getEnvArray("CIRCUIT_BREAKER_FAILURE_ERRORS", nil),
+		CircuitBreakerSlowCallRateThreshold: getEnvFloat("CIRCUIT_BREAKER_SLOW_CALL_RATE_THRESHOLD", 0),
+		CircuitBreakerWindowType:         getEnv("CIRCUIT_BREAKER_WINDOW_TYPE", "count"),
+		CircuitBreakerWindowSize:         getEnvInt("CIRCUIT_BREAKER_WINDOW_SIZE", 100),
+		UpstreamDialTimeout:           time.Duration(getEnvInt("UPSTREAM_DIAL_TIMEOUT", 5000)) * time.Millisecond,
+		UpstreamTLSHandshakeTimeout:   time.Duration(getEnvInt("UPSTREAM_TLS_HANDSHAKE_TIMEOUT", 10000)) * time.Millisecond,
+		UpstreamResponseHeaderTimeout: time.Duration(getEnvInt("UPSTREAM_RESPONSE_HEADER_TIMEOUT", 0)) * time.Millisecond,
+		UpstreamIdleConnTimeout:       time.Duration(getEnvInt("UPSTREAM_IDLE_CONN_TIMEOUT", 90000)) * time.Millisecond,
+		UpstreamMaxIdleConns:          getEnvInt("UPSTREAM_MAX_IDLE_CONNS", 100),
+		UpstreamMaxIdleConnsPerHost:   getEnvInt("UPSTREAM_MAX_IDLE_CONNS_PER_HOST", 32),
+		UpstreamMaxConnsPerHost:       getEnvInt("UPSTREAM_MAX_CONNS_PER_HOST", 0),
+		UpstreamHTTP2:                 getEnvBool("UPSTREAM_HTTP2", true),
+		UpstreamProxyFromEnvironment:  getEnvBool("UPSTREAM_PROXY_FROM_ENVIRONMENT", true),
 	}
 
 	// 백엔드 URL 목록
@@ -83,6 +241,53 @@ func Load() (*Config, error) {
 		cfg.Backends = []string{cfg.DefaultBackend}
 	}
 
+	// 사용자 정보 헤더 설정 확인
+	identityHeaders, err := ParseIdentityHeaders(getEnvArray("IDENTITY_HEADERS", DefaultIdentityHeaders))
+	if err != nil {
+		return nil, err
+	}
+	cfg.IdentityHeaders = identityHeaders
+	if cfg.InternalJWTSecret != "" && cfg.InternalJWTTTL <= 0 {
+		return nil, fmt.Errorf("내부 JWT 유효 기간은 0보다 커야 합니다: %v", cfg.InternalJWTTTL)
+	}
+
+	// 토큰 introspection 설정 확인
+	if cfg.IntrospectionURL != "" && (cfg.IntrospectionCacheTTL < 0 || cfg.IntrospectionNegativeCacheTTL < 0) {
+		return nil, fmt.Errorf("introspection 캐시 시간은 0 이상이어야 합니다")
+	}
+
+	// 레이트 리밋 설정 확인
+	if err := validateRateLimit(cfg); err != nil {
+		return nil, err
+	}
+
+	// 브라우저 로그인 설정 확인
+	if cfg.OIDCIssuer != "" {
+		if cfg.OIDCClientID == "" || cfg.OIDCRedirectURL == "" || cfg.OIDCSessionSecret == "" {
+			return nil, fmt.Errorf("OIDC 로그인에는 OIDC_CLIENT_ID, OIDC_REDIRECT_URL, OIDC_SESSION_SECRET이 필요합니다")
+		}
+		if cfg.OIDCSessionTTL <= 0 {
+			return nil, fmt.Errorf("세션 최대 유지 시간은 0보다 커야 합니다: %v", cfg.OIDCSessionTTL)
+		}
+	}
+
+	// 토큰 폐기 목록 설정 확인
+	if err := validateRevocation(cfg); err != nil {
+		return nil, err
+	}
+
+	// CSRF 설정 확인
+	if err := validateCSRFMode(cfg.CSRFMode); err != nil {
+		return nil, err
+	}
+
+	// 캐시 저장소 설정 확인
+	switch cfg.CacheStore {
+	case CacheStoreMemory, CacheStoreRedis, CacheStoreDisk:
+	default:
+		return nil, fmt.Errorf("지원하지 않는 캐시 저장소입니다: %s", cfg.CacheStore)
+	}
+
 	// 라우트 구성 파일 존재 여부 확인
 	if _, err := os.Stat(cfg.RoutesConfigPath); os.IsNotExist(err) {
 		return nil, fmt.Errorf("라우트 구성 파일이 존재하지 않습니다: %s", cfg.RoutesConfigPath)
@@ -91,8 +296,149 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// 레이트 리밋 알고리즘과 저장소 (RATE_LIMIT_ALGORITHM, RATE_LIMIT_STORE에 사용)
+const (
+	RateLimitAlgorithmTokenBucket   = "token-bucket"
+	RateLimitAlgorithmSlidingWindow = "sliding-window"
+
+	RateLimitStoreMemory = "memory"
+	RateLimitStoreRedis  = "redis"
+)
+
+// 토큰 폐기 목록 저장소 (REVOCATION_STORE에 사용)
+const (
+	RevocationStoreMemory = "memory"
+	RevocationStoreRedis  = "redis"
+)
+
+// 응답 캐시 저장소 (CACHE_STORE에 사용)
+const (
+	CacheStoreMemory = "memory"
+	CacheStoreRedis  = "redis"
+	CacheStoreDisk   = "disk"
+)
+
+// DefaultIdentityHeaders는 IDENTITY_HEADERS가 없을 때 업스트림에 전달하는 사용자 정보 헤더입니다 (헤더=클레임).
+var DefaultIdentityHeaders = []string{
+	"X-User-Id=sub",
+	"X-User-Email=email",
+	"X-User-Name=name",
+	"X-User-Roles=roles",
+	"X-User-Scopes=scope",
+}
+
+// DefaultInternalJWTHeader는 INTERNAL_JWT_HEADER가 없을 때 내부 JWT를 전달하는 헤더입니다.
+const DefaultInternalJWTHeader = "X-Internal-Token"
+
+// DefaultAPIKeyHeader는 API_KEY_HEADER가 없을 때 API 키를 읽는 요청 헤더입니다.
+const DefaultAPIKeyHeader = "X-API-Key"
+
+// DefaultSessionCookie는 OIDC_SESSION_COOKIE가 없을 때 브라우저 로그인 세션을 저장하는 쿠키입니다.
+const DefaultSessionCookie = "gw_session"
+
+// CSRF 검사 방식 (CSRF_MODE, CSRFConfig.Mode에 사용)
+const (
+	CSRFModeOrigin = "origin" // Sec-Fetch-Site, Origin(없으면 Referer) 헤더로 요청 출처 확인 (기본)
+	CSRFModeToken  = "token"  // 이중 제출 토큰 (쿠키와 헤더의 토큰이 같아야 함)
+	CSRFModeBoth   = "both"   // 출처와 토큰 모두 확인
+	CSRFModeOff    = "off"    // 검사하지 않음
+)
+
+// DefaultCSRFCookie는 CSRF_COOKIE가 없을 때 CSRF 토큰을 저장하는 쿠키입니다. 스크립트가 읽을 수 있어야 하므로 HttpOnly가 아닙니다.
+const DefaultCSRFCookie = "gw_csrf"
+
+// DefaultCSRFHeader는 CSRF_HEADER가 없을 때 CSRF 토큰을 읽는 요청 헤더입니다.
+const DefaultCSRFHeader = "X-CSRF-Token"
+
+// IdentityHeader는 업스트림에 전달할 사용자 정보 헤더와 값을 가져올 클레임입니다.
+type IdentityHeader struct {
+	Header string // 헤더 이름 (예: X-User-Id)
+	Claim  string // 클레임 이름 (점으로 중첩 필드 지정, 예: org.id)
+}
+
+// ParseIdentityHeaders는 "헤더=클레임" 형식의 목록을 파싱합니다.
+func ParseIdentityHeaders(entries []string) ([]IdentityHeader, error) {
+	var headers []IdentityHeader
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, claim, ok := strings.Cut(entry, "=")
+		name, claim = strings.TrimSpace(name), strings.TrimSpace(claim)
+		if !ok || name == "" || claim == "" {
+			return nil, fmt.Errorf("사용자 정보 헤더는 헤더=클레임 형식이어야 합니다: %s", entry)
+		}
+		headers = append(headers, IdentityHeader{Header: name, Claim: claim})
+	}
+	return headers, nil
+}
+
+// validateRateLimit은 레이트 리밋 알고리즘, 저장소, 장애 처리 방식 설정을 검사합니다.
+func validateRateLimit(cfg *Config) error {
+	switch cfg.RateLimitAlgorithm {
+	case RateLimitAlgorithmTokenBucket, RateLimitAlgorithmSlidingWindow:
+	default:
+		return fmt.Errorf("지원하지 않는 레이트 리밋 알고리즘입니다: %s", cfg.RateLimitAlgorithm)
+	}
+
+	switch cfg.RateLimitStore {
+	case RateLimitStoreMemory, RateLimitStoreRedis:
+	default:
+		return fmt.Errorf("지원하지 않는 레이트 리밋 저장소입니다: %s", cfg.RateLimitStore)
+	}
+
+	switch ratelimiter.FailureMode(cfg.RateLimitFailureMode) {
+	case ratelimiter.FailOpen, ratelimiter.FailClosed:
+	default:
+		return fmt.Errorf("지원하지 않는 레이트 리밋 장애 처리 방식입니다: %s", cfg.RateLimitFailureMode)
+	}
+
+	return nil
+}
+
+// validateRevocation은 토큰 폐기 목록 저장소와 장애 처리 방식 설정을 검사합니다.
+// 장애 처리 방식은 레이트 리밋과 같은 값(open, closed)을 사용합니다.
+func validateRevocation(cfg *Config) error {
+	switch cfg.RevocationStore {
+	case RevocationStoreMemory, RevocationStoreRedis:
+	default:
+		return fmt.Errorf("지원하지 않는 토큰 폐기 목록 저장소입니다: %s", cfg.RevocationStore)
+	}
+
+	switch ratelimiter.FailureMode(cfg.RevocationFailureMode) {
+	case ratelimiter.FailOpen, ratelimiter.FailClosed:
+	default:
+		return fmt.Errorf("지원하지 않는 토큰 폐기 목록 장애 처리 방식입니다: %s", cfg.RevocationFailureMode)
+	}
+
+	if cfg.RevocationDefaultTTL <= 0 {
+		return fmt.Errorf("폐기 항목 기본 유지 시간은 0보다 커야 합니다: %v", cfg.RevocationDefaultTTL)
+	}
+	return nil
+}
+
+// validateCSRFMode는 CSRF 검사 방식을 검사합니다.
+func validateCSRFMode(mode string) error {
+	switch mode {
+	case CSRFModeOrigin, CSRFModeToken, CSRFModeBoth, CSRFModeOff:
+		return nil
+	}
+	return fmt.Errorf("지원하지 않는 CSRF 검사 방식입니다: %s", mode)
+}
+
 // LoadRoutes는 라우트 구성 파일을 로드합니다.
 func (c *Config) LoadRoutes() ([]Route, error) {
+	routesConfig, err := c.LoadRoutesConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return routesConfig.Routes, nil
+}
+
+// LoadRoutesConfig는 업스트림 정의를 포함한 전체 라우트 구성 파일을 로드합니다.
+func (c *Config) LoadRoutesConfig() (*RoutesConfig, error) {
 	data, err := os.ReadFile(c.RoutesConfigPath)
 
 	log.Println("LoadRoutes", c.RoutesConfigPath)
@@ -101,17 +447,398 @@ func (c *Config) LoadRoutes() ([]Route, error) {
 		return nil, fmt.Errorf("라우트 구성 파일 읽기 실패: %v", err)
 	}
 
+	return ParseRoutesConfig(data)
+}
+
+// ParseRoutesConfig는 라우트 구성 파일 내용을 파싱하고 검증합니다.
+func ParseRoutesConfig(data []byte) (*RoutesConfig, error) {
 	var routesConfig RoutesConfig
 	if err := json.Unmarshal(data, &routesConfig); err != nil {
 		return nil, fmt.Errorf("라우트 구성 파싱 실패: %v", err)
 	}
 
-	return routesConfig.Routes, nil
+	// 라우트 구성 검증
+	if err := ValidateRoutesConfig(&routesConfig); err != nil {
+		return nil, fmt.Errorf("라우트 구성 검증 실패: %v", err)
+	}
+
+	return &routesConfig, nil
+}
+
+// ValidateRoutesConfig는 업스트림 정의와 라우트의 업스트림 참조를 포함하여 구성을 검사합니다.
+func ValidateRoutesConfig(rc *RoutesConfig) error {
+	upstreams := make(map[string]bool)
+	for i, upstream := range rc.Upstreams {
+		if upstream.Name == "" {
+			return fmt.Errorf("업스트림 #%d: name이 필요합니다", i)
+		}
+		if upstreams[upstream.Name] {
+			return fmt.Errorf("업스트림 %s: 중복된 이름입니다", upstream.Name)
+		}
+		upstreams[upstream.Name] = true
+
+		if !validStrategies[upstream.Strategy] {
+			return fmt.Errorf("업스트림 %s: 지원하지 않는 부하 분산 전략입니다: %s", upstream.Name, upstream.Strategy)
+		}
+		if len(upstream.Targets) == 0 {
+			return fmt.Errorf("업스트림 %s: 최소 하나의 대상이 필요합니다", upstream.Name)
+		}
+		for _, target := range upstream.Targets {
+			u, err := url.Parse(target.URL)
+			if err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("업스트림 %s: 유효하지 않은 대상 URL입니다: %q", upstream.Name, target.URL)
+			}
+			if target.Weight < 0 {
+				return fmt.Errorf("업스트림 %s: 가중치는 0 이상이어야 합니다: %s", upstream.Name, target.URL)
+			}
+		}
+
+		if hc := upstream.HealthCheck; hc != nil {
+			if hc.Interval < 0 || hc.Timeout < 0 || hc.HealthyThreshold < 0 || hc.UnhealthyThreshold < 0 {
+				return fmt.Errorf("업스트림 %s: 헬스 체크 설정은 0 이상이어야 합니다", upstream.Name)
+			}
+			if hc.ExpectedStatus != 0 && (hc.ExpectedStatus < 100 || hc.ExpectedStatus > 599) {
+				return fmt.Errorf("업스트림 %s: 유효하지 않은 기대 상태 코드입니다: %d", upstream.Name, hc.ExpectedStatus)
+			}
+		}
+		if ph := upstream.PassiveHealth; ph != nil && (ph.MaxFailures < 0 || ph.Cooldown < 0) {
+			return fmt.Errorf("업스트림 %s: 수동 헬스 체크 설정은 0 이상이어야 합니다", upstream.Name)
+		}
+		if tc := upstream.Transport; tc != nil {
+			if tc.DialTimeout < 0 || tc.TLSHandshakeTimeout < 0 || tc.ResponseHeaderTimeout < 0 || tc.IdleConnTimeout < 0 ||
+				tc.MaxIdleConns < 0 || tc.MaxIdleConnsPerHost < 0 || tc.MaxConnsPerHost < 0 {
+				return fmt.Errorf("업스트림 %s: 연결 풀 설정은 0 이상이어야 합니다", upstream.Name)
+			}
+		}
+		if err := validateConcurrency(upstream.Concurrency); err != nil {
+			return fmt.Errorf("업스트림 %s: %v", upstream.Name, err)
+		}
+	}
+
+	for _, route := range rc.Routes {
+		if route.Upstream != "" && !upstreams[route.Upstream] {
+			return fmt.Errorf("라우트 %s: 정의되지 않은 업스트림입니다: %s", route.Path, route.Upstream)
+		}
+	}
+
+	return ValidateRoutes(rc.Routes)
+}
+
+// Upstream은 이름으로 참조하는 업스트림 그룹을 반환합니다.
+func (rc *RoutesConfig) Upstream(name string) (Upstream, bool) {
+	for _, upstream := range rc.Upstreams {
+		if upstream.Name == name {
+			return upstream, true
+		}
+	}
+	return Upstream{}, false
+}
+
+// ValidateRoutes는 라우트 구성의 유효성을 검사합니다.
+func ValidateRoutes(routes []Route) error {
+	if len(routes) == 0 {
+		return errors.New("등록할 라우트가 없습니다")
+	}
+
+	seen := make(map[string]bool)
+	for i, route := range routes {
+		if route.Path == "" || !strings.HasPrefix(route.Path, "/") {
+			return fmt.Errorf("라우트 #%d: 경로는 '/'로 시작해야 합니다: %q", i, route.Path)
+		}
+
+		// 업스트림을 사용하는 라우트는 targetURL을 경로로만 사용하므로 생략 가능
+		if route.TargetURL == "" && route.Upstream == "" {
+			return fmt.Errorf("라우트 %s: targetURL 또는 upstream이 필요합니다", route.Path)
+		}
+		if _, err := url.Parse(route.TargetURL); err != nil {
+			return fmt.Errorf("라우트 %s: targetURL 파싱 실패: %v", route.Path, err)
+		}
+
+		if len(route.Methods) == 0 {
+			return fmt.Errorf("라우트 %s: 최소 하나의 메서드가 필요합니다", route.Path)
+		}
+		for _, method := range route.Methods {
+			if !validMethods[method] {
+				return fmt.Errorf("라우트 %s: 지원하지 않는 메서드입니다: %s", route.Path, method)
+			}
+
+			// 동일 경로/메서드 중복 등록 방지
+			key := method + " " + route.Path
+			if seen[key] {
+				return fmt.Errorf("라우트 %s: 중복된 라우트입니다 (%s)", route.Path, method)
+			}
+			seen[key] = true
+		}
+
+		if route.Timeout < 0 {
+			return fmt.Errorf("라우트 %s: timeout은 0 이상이어야 합니다", route.Path)
+		}
+
+		if err := validateCircuitBreaker(route.CircuitBreaker); err != nil {
+			return fmt.Errorf("라우트 %s: %v", route.Path, err)
+		}
+		if err := validateRetry(route.Retry); err != nil {
+			return fmt.Errorf("라우트 %s: %v", route.Path, err)
+		}
+		for _, policy := range route.RateLimits {
+			if err := validateRateLimitPolicy(policy); err != nil {
+				return fmt.Errorf("라우트 %s: %v", route.Path, err)
+			}
+		}
+		if err := validateConcurrency(route.Concurrency); err != nil {
+			return fmt.Errorf("라우트 %s: %v", route.Path, err)
+		}
+		if route.Authorization != nil && !route.RequireAuth {
+			return fmt.Errorf("라우트 %s: authorization을 사용하려면 requireAuth가 필요합니다", route.Path)
+		}
+		if err := validateAuthorization(route.Authorization); err != nil {
+			return fmt.Errorf("라우트 %s: %v", route.Path, err)
+		}
+		switch route.AuthMode {
+		case "", AuthModeJWT, AuthModeAPIKey, AuthModeAny:
+		default:
+			return fmt.Errorf("라우트 %s: 지원하지 않는 인증 방식입니다: %s", route.Path, route.AuthMode)
+		}
+		if route.AuthMode != "" && !route.RequireAuth {
+			return fmt.Errorf("라우트 %s: authMode를 사용하려면 requireAuth가 필요합니다", route.Path)
+		}
+		if route.CSRF != nil && route.CSRF.Mode != "" {
+			if err := validateCSRFMode(route.CSRF.Mode); err != nil {
+				return fmt.Errorf("라우트 %s: %v", route.Path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateCircuitBreaker는 라우트별 서킷 브레이커 설정을 검사합니다.
+func validateCircuitBreaker(cb *CircuitBreakerConfig) error {
+	if cb == nil {
+		return nil
+	}
+
+	switch cb.Scope {
+	case "", CircuitBreakerScopeRoute, CircuitBreakerScopeTarget:
+	default:
+		return fmt.Errorf("지원하지 않는 서킷 브레이커 범위입니다: %s", cb.Scope)
+	}
+	if cb.ErrorThreshold < 0 || cb.ErrorThreshold > 1 {
+		return fmt.Errorf("서킷 브레이커 errorThreshold는 0.0-1.0 사이여야 합니다: %v", cb.ErrorThreshold)
+	}
+	if cb.SlowCallRateThreshold < 0 || cb.SlowCallRateThreshold > 1 {
+		return fmt.Errorf("서킷 브레이커 slowCallRateThreshold는 0.0-1.0 사이여야 합니다: %v", cb.SlowCallRateThreshold)
+	}
+	switch cb.WindowType {
+	case "", circuitbreaker.WindowTypeCount, circuitbreaker.WindowTypeTime:
+	default:
+		return fmt.Errorf("지원하지 않는 서킷 브레이커 윈도우 유형입니다: %s", cb.WindowType)
+	}
+	if cb.MinRequests < 0 || cb.Timeout < 0 || cb.HalfOpenMaxReqs < 0 || cb.SuccessThreshold < 0 || cb.SlowCallThreshold < 0 || cb.WindowSize < 0 {
+		return errors.New("서킷 브레이커 설정은 0 이상이어야 합니다")
+	}
+	for _, code := range cb.FailureStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("유효하지 않은 서킷 브레이커 실패 상태 코드입니다: %d", code)
+		}
+	}
+	for _, errorType := range cb.FailureErrors {
+		if !circuitbreaker.IsValidErrorType(errorType) {
+			return fmt.Errorf("지원하지 않는 서킷 브레이커 오류 유형입니다: %s", errorType)
+		}
+	}
+
+	return nil
+}
+
+// validateRetry는 라우트별 재시도 정책을 검사합니다.
+func validateRetry(retry *RetryConfig) error {
+	if retry == nil {
+		return nil
+	}
+
+	if retry.Attempts < 0 || retry.BackoffBase < 0 || retry.BackoffMax < 0 || retry.PerTryTimeout < 0 || retry.MaxBodySize < 0 {
+		return errors.New("재시도 설정은 0 이상이어야 합니다")
+	}
+	for _, code := range retry.StatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("유효하지 않은 재시도 상태 코드입니다: %d", code)
+		}
+	}
+	for _, errorType := range retry.Errors {
+		if !circuitbreaker.IsValidErrorType(errorType) {
+			return fmt.Errorf("지원하지 않는 재시도 오류 유형입니다: %s", errorType)
+		}
+	}
+
+	return nil
+}
+
+// validateRateLimitPolicy는 라우트별 속도 제한 정책을 검사합니다.
+func validateRateLimitPolicy(policy RateLimitPolicy) error {
+	switch policy.Key {
+	case RateLimitKeyIP, RateLimitKeyUser, RateLimitKeyAPIKey:
+	case RateLimitKeyHeader:
+		if policy.Header == "" {
+			return errors.New("header 키를 사용하는 속도 제한 정책에는 header가 필요합니다")
+		}
+	default:
+		return fmt.Errorf("지원하지 않는 속도 제한 키입니다: %q", policy.Key)
+	}
+
+	switch policy.Algorithm {
+	case "", RateLimitAlgorithmTokenBucket, RateLimitAlgorithmSlidingWindow:
+	default:
+		return fmt.Errorf("지원하지 않는 속도 제한 알고리즘입니다: %s", policy.Algorithm)
+	}
+
+	if policy.Window <= 0 || policy.Limit <= 0 {
+		return errors.New("속도 제한 정책의 window와 limit는 0보다 커야 합니다")
+	}
+	if policy.Burst < 0 {
+		return errors.New("속도 제한 정책의 burst는 0 이상이어야 합니다")
+	}
+
+	for _, tier := range policy.Tiers {
+		if tier.Role == "" {
+			return errors.New("속도 제한 등급에는 role이 필요합니다")
+		}
+		if tier.Multiplier < 0 || tier.Limit < 0 || tier.Burst < 0 {
+			return fmt.Errorf("속도 제한 등급 %s: 설정은 0 이상이어야 합니다", tier.Role)
+		}
+		if tier.Multiplier == 0 && tier.Limit == 0 {
+			return fmt.Errorf("속도 제한 등급 %s: multiplier 또는 limit가 필요합니다", tier.Role)
+		}
+	}
+
+	return nil
+}
+
+// validateConcurrency는 동시 요청 제한 설정을 검사합니다.
+func validateConcurrency(cc *ConcurrencyConfig) error {
+	if cc == nil {
+		return nil
+	}
+
+	if cc.Mode != "" && !concurrency.IsValidMode(cc.Mode) {
+		return fmt.Errorf("지원하지 않는 동시 요청 제한 방식입니다: %s", cc.Mode)
+	}
+	if cc.Limit < 0 || cc.MinLimit < 0 || cc.MaxLimit < 0 || cc.QueueSize < 0 || cc.QueueTimeout < 0 ||
+		cc.LatencyThreshold < 0 || cc.Tolerance < 0 || cc.RetryAfter < 0 {
+		return errors.New("동시 요청 제한 설정은 0 이상이어야 합니다")
+	}
+	if cc.BackoffRatio < 0 || cc.BackoffRatio >= 1 {
+		return errors.New("동시 요청 제한의 backoffRatio는 0과 1 사이여야 합니다")
+	}
+	if cc.MaxLimit > 0 && cc.MinLimit > cc.MaxLimit {
+		return errors.New("동시 요청 제한의 minLimit는 maxLimit보다 클 수 없습니다")
+	}
+
+	return nil
+}
+
+// validateAuthorization은 라우트의 접근 조건을 검사합니다.
+func validateAuthorization(az *AuthorizationConfig) error {
+	if az == nil {
+		return nil
+	}
+
+	for _, mode := range []string{az.RolesMode, az.ScopesMode} {
+		switch mode {
+		case "", AuthorizationModeAny, AuthorizationModeAll:
+		default:
+			return fmt.Errorf("지원하지 않는 권한 조건 방식입니다: %s", mode)
+		}
+	}
+
+	for _, matcher := range az.Claims {
+		if matcher.Claim == "" {
+			return errors.New("클레임 조건에는 claim이 필요합니다")
+		}
+		switch matcher.Op {
+		case "", ClaimOpEquals, ClaimOpNotEquals, ClaimOpExists:
+		case ClaimOpIn:
+			if len(matcher.Values) == 0 {
+				return fmt.Errorf("클레임 %s: in 조건에는 values가 필요합니다", matcher.Claim)
+			}
+		default:
+			return fmt.Errorf("클레임 %s: 지원하지 않는 연산자입니다: %s", matcher.Claim, matcher.Op)
+		}
+	}
+
+	return nil
+}
+
+// validMethods는 라우트에 지정할 수 있는 HTTP 메서드 목록입니다.
+var validMethods = map[string]bool{
+	"GET":     true,
+	"POST":    true,
+	"PUT":     true,
+	"DELETE":  true,
+	"PATCH":   true,
+	"HEAD":    true,
+	"OPTIONS": true,
+}
+
+// validStrategies는 업스트림에 지정할 수 있는 부하 분산 전략 목록입니다.
+var validStrategies = map[string]bool{
+	"":                 true, // 기본값: round-robin
+	"round-robin":      true,
+	"weighted":         true,
+	"least-connection": true,
 }
 
 // RoutesConfig는 routes.json 파일의 구조입니다.
 type RoutesConfig struct {
-	Routes []Route `json:"routes"`
+	Upstreams []Upstream `json:"upstreams"`
+	Routes    []Route    `json:"routes"`
+}
+
+// Upstream은 여러 대상 서버로 구성된 업스트림 그룹입니다.
+type Upstream struct {
+	Name     string           `json:"name"`
+	Strategy string           `json:"strategy"` // round-robin, weighted, least-connection
+	Targets  []UpstreamTarget `json:"targets"`
+
+	HealthCheck   *HealthCheckConfig   `json:"healthCheck"`   // 능동 헬스 체크 (생략 시 비활성화)
+	PassiveHealth *PassiveHealthConfig `json:"passiveHealth"` // 수동 헬스 체크 (생략 시 비활성화)
+	Transport     *TransportConfig     `json:"transport"`     // 연결 풀 설정 (생략한 값은 UPSTREAM_* 환경 변수 기본값)
+	Concurrency   *ConcurrencyConfig   `json:"concurrency"`   // 업스트림 전체의 동시 요청 제한 (생략 시 제한하지 않음)
+}
+
+// TransportConfig는 업스트림 연결 풀 설정입니다. 0이거나 생략한 값은 환경 변수 기본값을 사용합니다.
+type TransportConfig struct {
+	DialTimeout           int   `json:"dialTimeout"`           // 연결 수립 타임아웃 (밀리초)
+	TLSHandshakeTimeout   int   `json:"tlsHandshakeTimeout"`   // TLS 핸드셰이크 타임아웃 (밀리초)
+	ResponseHeaderTimeout int   `json:"responseHeaderTimeout"` // 응답 헤더 수신 타임아웃 (밀리초)
+	IdleConnTimeout       int   `json:"idleConnTimeout"`       // 유휴 연결 유지 시간 (밀리초)
+	MaxIdleConns          int   `json:"maxIdleConns"`          // 전체 최대 유휴 연결 수
+	MaxIdleConnsPerHost   int   `json:"maxIdleConnsPerHost"`   // 대상 서버별 최대 유휴 연결 수
+	MaxConnsPerHost       int   `json:"maxConnsPerHost"`       // 대상 서버별 최대 연결 수
+	HTTP2                 *bool `json:"http2"`                 // TLS 대상 서버와 HTTP/2 사용
+	ProxyFromEnvironment  *bool `json:"proxyFromEnvironment"`  // 환경 변수 프록시 사용
+}
+
+// HealthCheckConfig는 업스트림 대상 서버의 능동 헬스 체크 설정입니다.
+type HealthCheckConfig struct {
+	Path               string `json:"path"`               // 헬스 체크 경로 (기본 /health)
+	Interval           int    `json:"interval"`           // 검사 주기 (초, 기본 10)
+	Timeout            int    `json:"timeout"`            // 검사 타임아웃 (초, 기본 2)
+	ExpectedStatus     int    `json:"expectedStatus"`     // 기대 상태 코드 (생략 시 2xx)
+	HealthyThreshold   int    `json:"healthyThreshold"`   // 정상 전환 연속 성공 횟수 (기본 2)
+	UnhealthyThreshold int    `json:"unhealthyThreshold"` // 비정상 전환 연속 실패 횟수 (기본 3)
+}
+
+// PassiveHealthConfig는 프록시 응답 결과 기반 수동 헬스 체크 설정입니다.
+type PassiveHealthConfig struct {
+	MaxFailures int `json:"maxFailures"` // 대상 제외 기준 연속 5xx/연결 오류 횟수 (기본 5)
+	Cooldown    int `json:"cooldown"`    // 제외 기간 (초, 기본 30)
+}
+
+// UpstreamTarget은 업스트림 그룹의 단일 대상 서버입니다.
+type UpstreamTarget struct {
+	URL    string `json:"url"`
+	Weight int    `json:"weight"` // weighted 전략에서 사용 (기본 1)
 }
 
 // Route는 단일 라우트 구성입니다.
@@ -123,6 +850,140 @@ type Route struct {
 	RequireAuth bool     `json:"requireAuth"`
 	Cacheable   bool     `json:"cacheable"`
 	Timeout     int      `json:"timeout"` // 초 단위
+	Upstream    string   `json:"upstream"` // 업스트림 그룹 이름 (지정 시 targetURL은 경로로 사용)
+
+	CircuitBreaker *CircuitBreakerConfig `json:"circuitBreaker"` // 라우트별 서킷 브레이커 설정 (생략 시 환경 변수 기본값)
+	Retry          *RetryConfig          `json:"retry"`          // 라우트별 재시도 정책 (생략 시 재시도하지 않음)
+	RateLimits     []RateLimitPolicy     `json:"rateLimits"`     // 라우트별 속도 제한 정책 (모든 정책을 통과해야 허용)
+	Concurrency    *ConcurrencyConfig    `json:"concurrency"`    // 라우트별 동시 요청 제한 (생략 시 제한하지 않음)
+	Authorization  *AuthorizationConfig  `json:"authorization"`  // 인증된 사용자의 접근 조건 (requireAuth 필요)
+	AuthMode       string                `json:"authMode"`       // 인증 방식 (jwt(기본), apiKey, any, requireAuth 필요)
+	CSRF           *CSRFConfig           `json:"csrf"`           // 라우트별 CSRF 검사 설정 (생략 시 CSRF_MODE)
+}
+
+// CSRFConfig는 쿠키로 인증한 상태 변경 요청(GET, HEAD, OPTIONS, TRACE 외)의 CSRF 검사 설정입니다.
+type CSRFConfig struct {
+	Mode           string   `json:"mode"`           // origin, token, both, off (생략 시 CSRF_MODE)
+	TrustedOrigins []string `json:"trustedOrigins"` // ALLOWED_ORIGINS, CSRF_TRUSTED_ORIGINS 외에 허용하는 오리진
+}
+
+// 라우트 인증 방식 (Route.AuthMode에 사용)
+const (
+	AuthModeJWT    = "jwt"    // JWT 토큰 (기본)
+	AuthModeAPIKey = "apiKey" // API 키
+	AuthModeAny    = "any"    // API 키가 있으면 API 키, 없으면 JWT 토큰
+)
+
+// 역할/스코프 조건 방식 (AuthorizationConfig.RolesMode, ScopesMode에 사용)
+const (
+	AuthorizationModeAny = "any" // 하나 이상 보유 (기본)
+	AuthorizationModeAll = "all" // 모두 보유
+)
+
+// 클레임 비교 연산자 (ClaimMatcher.Op에 사용)
+const (
+	ClaimOpEquals    = "eq"     // 값과 같음 (기본)
+	ClaimOpNotEquals = "ne"     // 값과 다르거나 클레임이 없음
+	ClaimOpIn        = "in"     // values 중 하나와 같음
+	ClaimOpExists    = "exists" // 클레임이 있음
+)
+
+// AuthorizationConfig는 라우트의 접근 조건입니다. 지정한 조건을 모두 만족해야 합니다.
+type AuthorizationConfig struct {
+	Roles      []string       `json:"roles"`      // 필요한 역할 (roles 클레임)
+	RolesMode  string         `json:"rolesMode"`  // any(기본), all
+	Scopes     []string       `json:"scopes"`     // 필요한 OAuth 스코프 (scope 또는 scp 클레임)
+	ScopesMode string         `json:"scopesMode"` // any(기본), all
+	Audience   []string       `json:"audience"`   // 허용하는 aud (하나 이상 일치해야 함)
+	Claims     []ClaimMatcher `json:"claims"`     // 클레임 조건 (모두 만족해야 함)
+}
+
+// ClaimMatcher는 토큰 클레임 조건입니다. 클레임이 배열이면 요소 중 하나가 일치하면 같은 것으로 봅니다.
+type ClaimMatcher struct {
+	Claim  string   `json:"claim"`  // 클레임 이름 (점으로 중첩 필드 지정, 예: org.id)
+	Op     string   `json:"op"`     // eq(기본), ne, in, exists
+	Value  string   `json:"value"`  // eq, ne의 비교 값
+	Values []string `json:"values"` // in의 비교 값 목록
+}
+
+// ConcurrencyConfig는 라우트 또는 업스트림의 동시 요청 제한 설정입니다.
+type ConcurrencyConfig struct {
+	Mode             string  `json:"mode"`             // fixed(기본), aimd, gradient
+	Limit            int     `json:"limit"`            // 고정 한도 또는 적응형 모드의 초기 한도
+	MinLimit         int     `json:"minLimit"`         // 적응형 모드의 최소 한도
+	MaxLimit         int     `json:"maxLimit"`         // 적응형 모드의 최대 한도
+	QueueSize        int     `json:"queueSize"`        // 한도 초과 시 대기할 수 있는 요청 수 (0이면 바로 거부)
+	QueueTimeout     int     `json:"queueTimeout"`     // 대기열에서 기다릴 최대 시간 (밀리초)
+	LatencyThreshold int     `json:"latencyThreshold"` // aimd: 한도를 줄일 응답 지연 시간 (밀리초)
+	BackoffRatio     float64 `json:"backoffRatio"`     // 한도를 줄일 때 곱할 비율 (0-1, 기본 0.9)
+	Tolerance        float64 `json:"tolerance"`        // gradient: 평균 대비 허용할 지연 시간 배수 (기본 1.5)
+	RetryAfter       int     `json:"retryAfter"`       // 거부 응답의 Retry-After (초, 기본 1)
+}
+
+// 속도 제한 정책 키 유형 (RateLimitPolicy.Key에 사용)
+const (
+	RateLimitKeyIP     = "ip"     // 클라이언트 IP
+	RateLimitKeyUser   = "user"   // JWT 사용자 ID (authMiddleware가 설정한 userId)
+	RateLimitKeyHeader = "header" // 지정한 요청 헤더 값
+	RateLimitKeyAPIKey = "apiKey" // 인증된 API 키 ID
+)
+
+// RateLimitPolicy는 라우트별 속도 제한 정책입니다.
+type RateLimitPolicy struct {
+	Name      string          `json:"name"`      // 정책 이름 (같은 이름의 정책은 라우트 간에 한도를 공유, 생략 시 라우트별)
+	Key       string          `json:"key"`       // 요청 구분 키 (ip, user, header, apiKey)
+	Header    string          `json:"header"`    // key가 header일 때 사용할 헤더 이름
+	Algorithm string          `json:"algorithm"` // token-bucket(기본), sliding-window
+	Window    int             `json:"window"`    // 윈도우 크기 (초)
+	Limit     int             `json:"limit"`     // 윈도우 당 최대 요청 수
+	Burst     int             `json:"burst"`     // 순간 허용량 (토큰 버킷 용량, 기본 limit)
+	Tiers     []RateLimitTier `json:"tiers"`     // 역할별 한도 (먼저 일치한 항목 사용)
+}
+
+// RateLimitTier는 JWT 역할에 따라 다른 한도를 적용하는 속도 제한 등급입니다.
+type RateLimitTier struct {
+	Role       string  `json:"role"`       // JWT 역할
+	Multiplier float64 `json:"multiplier"` // 정책 limit/burst에 곱할 배수
+	Limit      int     `json:"limit"`      // 직접 지정한 한도 (multiplier보다 우선)
+	Burst      int     `json:"burst"`      // 직접 지정한 순간 허용량 (multiplier보다 우선)
+}
+
+// RetryConfig는 라우트별 재시도 정책입니다. 0인 값은 기본값을 사용합니다.
+type RetryConfig struct {
+	Attempts      int      `json:"attempts"`      // 최대 시도 횟수 (첫 요청 포함, 기본 3)
+	StatusCodes   []int    `json:"statusCodes"`   // 재시도할 응답 상태 코드 (생략 시 502, 503, 504)
+	Errors        []string `json:"errors"`        // 재시도할 오류 유형 (생략 시 connection, timeout)
+	BackoffBase   int      `json:"backoffBase"`   // 첫 재시도 전 대기 시간 (밀리초, 기본 50)
+	BackoffMax    int      `json:"backoffMax"`    // 최대 대기 시간 (밀리초, 기본 1000)
+	PerTryTimeout int      `json:"perTryTimeout"` // 시도별 타임아웃 (밀리초, 0이면 라우트 timeout만 적용)
+	NonIdempotent bool     `json:"nonIdempotent"` // POST, PATCH 등 멱등하지 않은 메서드도 재시도
+	MaxBodySize   int64    `json:"maxBodySize"`   // 재전송을 위해 버퍼링할 최대 요청 본문 크기 (바이트, 기본 64KB)
+}
+
+// 서킷 브레이커 적용 범위
+const (
+	CircuitBreakerScopeRoute  = "route"  // 라우트마다 하나의 서킷 브레이커
+	CircuitBreakerScopeTarget = "target" // 대상 서버마다 하나의 서킷 브레이커 (라우트 간 공유)
+)
+
+// CircuitBreakerConfig는 라우트별 서킷 브레이커 설정입니다. 0인 값은 기본값을 사용합니다.
+type CircuitBreakerConfig struct {
+	Scope            string  `json:"scope"`            // route(기본) 또는 target
+	ErrorThreshold   float64 `json:"errorThreshold"`   // 오류 비율 임계값 (0.0-1.0)
+	MinRequests      int     `json:"minRequests"`      // 상태 결정을 위한 최소 요청 수
+	Timeout          int     `json:"timeout"`          // 열림 상태 유지 시간 (초)
+	HalfOpenMaxReqs  int     `json:"halfOpenMaxReqs"`  // 반열림 상태 최대 요청 수
+	SuccessThreshold int     `json:"successThreshold"` // 닫힘 전환 연속 성공 횟수
+
+	// 실패 분류 (생략 시 기본값, 빈 배열이면 해당 분류 비활성화)
+	FailureStatusCodes []int    `json:"failureStatusCodes"` // 실패로 기록할 응답 상태 코드
+	SlowCallThreshold  int      `json:"slowCallThreshold"`  // 실패로 기록할 응답 지연 시간 (밀리초)
+	FailureErrors      []string `json:"failureErrors"`      // 실패로 기록할 오류 유형 (timeout, canceled, connection, other)
+
+	// 슬라이딩 윈도우 (생략 시 기본값)
+	SlowCallRateThreshold float64 `json:"slowCallRateThreshold"` // 지연 호출 비율 임계값 (0.0-1.0)
+	WindowType            string  `json:"windowType"`            // count 또는 time
+	WindowSize            int     `json:"windowSize"`            // count: 최근 호출 수, time: 최근 시간 (초)
 }
 
 // 환경 변수 유틸리티 함수
@@ -142,6 +1003,23 @@ func getEnvArray(key string, defaultValue []string) []string {
 	return strings.Split(value, ",")
 }
 
+func getEnvIntArray(key string, defaultValue []int) []int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var values []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(item))
+		if err != nil {
+			return defaultValue
+		}
+		values = append(values, n)
+	}
+	return values
+}
+
 func getEnvInt(key string, defaultValue int) int {
 	valueStr := os.Getenv(key)
 	if valueStr == "" {
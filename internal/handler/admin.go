@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+)
+
+// adminRole은 관리자 엔드포인트 접근에 필요한 역할입니다.
+const adminRole = "admin"
+
+// registerAdminRoutes는 관리자 전용 엔드포인트를 등록합니다.
+func (h *RouteHandler) registerAdminRoutes(router *gin.Engine) {
+	handlers := []gin.HandlerFunc{h.cookieToHeaderMiddleware()}
+	if policy := h.newCSRFPolicy(nil); policy != nil {
+		handlers = append(handlers, h.csrfMiddleware(policy))
+	}
+	handlers = append(handlers, h.authMiddleware(), h.requireRole(adminRole))
+	admin := router.Group("/admin", handlers...)
+
+	admin.GET("/circuit-breakers", h.CircuitBreakersHandler)
+	admin.GET("/concurrency", h.ConcurrencyHandler)
+	admin.GET("/cache", h.CacheEntriesHandler)
+	admin.GET("/cache/entry", h.CacheEntryHandler)
+	admin.DELETE("/cache", h.PurgeCacheHandler)
+	admin.POST("/revocations", h.RevokeHandler)
+}
+
+// requireRole은 인증된 사용자가 지정된 역할을 가지고 있는지 확인하는 핸들러를 반환합니다.
+func (h *RouteHandler) requireRole(role string) gin.HandlerFunc {
+	return h.authorizeMiddleware(&config.AuthorizationConfig{Roles: []string{role}})
+}
+
+// CircuitBreakersHandler는 모든 서킷 브레이커의 상태와 메트릭을 반환합니다.
+func (h *RouteHandler) CircuitBreakersHandler(c *gin.Context) {
+	breakers := make([]gin.H, 0)
+	for _, key := range h.breakers.Keys() {
+		cb, ok := h.breakers.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		breakers = append(breakers, gin.H{
+			"key":     key,
+			"state":   cb.FormatState(),
+			"metrics": cb.GetMetrics(),
+			"config":  cb.Config(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"circuit_breakers": breakers,
+		"time":             time.Now().Format(time.RFC3339),
+	})
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/middleware"
+)
+
+// SetAPIKeyAuthenticator는 authMode가 apiKey 또는 any인 라우트가 사용할 API 키 인증기를 설정합니다.
+// RegisterRoutes 전에 호출해야 합니다.
+func (h *RouteHandler) SetAPIKeyAuthenticator(authenticator *auth.APIKeyAuthenticator) {
+	h.apiKeys = authenticator
+}
+
+// checkAuthMode는 라우트의 인증 방식에 필요한 인증기가 설정되어 있는지 확인합니다.
+func (h *RouteHandler) checkAuthMode(route config.Route) error {
+	if !route.RequireAuth || route.AuthMode == "" || route.AuthMode == config.AuthModeJWT {
+		return nil
+	}
+	if h.apiKeys == nil {
+		return fmt.Errorf("라우트 %s: authMode %s에는 API 키 설정(API_KEYS_FILE)이 필요합니다", route.Path, route.AuthMode)
+	}
+	return nil
+}
+
+// authMiddlewareFor는 라우트 인증 방식에 맞는 인증 핸들러를 반환합니다.
+func (h *RouteHandler) authMiddlewareFor(mode string) gin.HandlerFunc {
+	switch mode {
+	case config.AuthModeAPIKey:
+		return h.apiKeyMiddleware(nil)
+	case config.AuthModeAny:
+		// API 키가 없는 요청은 JWT 토큰으로 인증
+		return h.apiKeyMiddleware(h.authMiddleware())
+	default:
+		return h.authMiddleware()
+	}
+}
+
+// apiKeyMiddleware는 API 키 인증을 수행하는 핸들러를 반환합니다.
+// fallback이 있으면 API 키가 없는 요청을 fallback으로 인증합니다.
+func (h *RouteHandler) apiKeyMiddleware(fallback gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := h.apiKeyFrom(c)
+		if key == "" && fallback != nil {
+			fallback(c)
+			return
+		}
+		if key == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "API 키가 필요합니다"})
+			c.Abort()
+			return
+		}
+
+		if h.apiKeys == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "API 키 인증을 사용할 수 없습니다"})
+			c.Abort()
+			return
+		}
+		apiKey, err := h.apiKeys.Lookup(key)
+		if err != nil {
+			log.Printf("[AUTH] API 키 인증 실패: %v", err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("인증 실패: %v", err)})
+			c.Abort()
+			return
+		}
+
+		// 업스트림에 키 원문을 전달하지 않음
+		h.removeAPIKey(c)
+
+		// 인증 정보를 컨텍스트에 저장 (JWT 인증과 같은 키 사용)
+		claims := apiKey.Claims()
+		c.Set("userId", claims.Subject)
+		c.Set("roles", claims.Roles)
+		c.Set(claimsContextKey, claims)
+		c.Set(middleware.APIKeyIDContextKey, apiKey.ID)
+
+		// 키별 속도 제한
+		if policy := h.apiKeyRateLimit(apiKey); policy != nil && !policy.Enforce(c) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// apiKeyFrom은 요청 헤더 또는 쿼리 파라미터에서 API 키를 읽습니다.
+func (h *RouteHandler) apiKeyFrom(c *gin.Context) string {
+	if key := c.GetHeader(h.apiKeyHeader()); key != "" {
+		return key
+	}
+	if param := h.config.APIKeyQueryParam; param != "" {
+		return c.Query(param)
+	}
+	return ""
+}
+
+// removeAPIKey는 업스트림에 전달할 요청에서 API 키 헤더와 쿼리 파라미터를 제거합니다.
+func (h *RouteHandler) removeAPIKey(c *gin.Context) {
+	c.Request.Header.Del(h.apiKeyHeader())
+
+	param := h.config.APIKeyQueryParam
+	if param == "" {
+		return
+	}
+	query := c.Request.URL.Query()
+	if query.Has(param) {
+		query.Del(param)
+		c.Request.URL.RawQuery = query.Encode()
+	}
+}
+
+// apiKeyHeader는 API 키를 읽을 요청 헤더 이름을 반환합니다.
+func (h *RouteHandler) apiKeyHeader() string {
+	if h.config.APIKeyHeader != "" {
+		return h.config.APIKeyHeader
+	}
+	return config.DefaultAPIKeyHeader
+}
+
+// apiKeyRateLimit은 API 키의 속도 제한 정책을 반환합니다. 속도 제한이 없는 키는 nil을 반환합니다.
+// 정책은 키 ID와 한도별로 한 번만 생성하며, 키 파일에서 한도가 바뀌면 새 정책을 사용합니다.
+func (h *RouteHandler) apiKeyRateLimit(apiKey *auth.APIKey) *middleware.RateLimitPolicy {
+	limit := apiKey.RateLimit
+	if limit == nil {
+		return nil
+	}
+
+	cacheKey := fmt.Sprintf("%s:%d:%d:%d", apiKey.ID, limit.Limit, limit.Window, limit.Burst)
+	if policy, ok := h.apiKeyLimits.Load(cacheKey); ok {
+		return policy.(*middleware.RateLimitPolicy)
+	}
+
+	burst := limit.Burst
+	if burst == 0 {
+		burst = limit.Limit
+	}
+	limiter := h.newPolicyLimiter(config.RateLimitPolicy{Window: limit.Window}, limit.Limit, burst)
+
+	id := apiKey.ID
+	policy, _ := h.apiKeyLimits.LoadOrStore(cacheKey, &middleware.RateLimitPolicy{
+		Name:    "apiKey=" + id,
+		Key:     func(*gin.Context) string { return id },
+		Limiter: limiter,
+	})
+	return policy.(*middleware.RateLimitPolicy)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+)
+
+// claimsContextKey는 authMiddleware가 검증한 토큰 클레임을 저장하는 gin 컨텍스트 키입니다.
+const claimsContextKey = "claims"
+
+// 권한 거부 사유 (403 응답의 reason)
+const (
+	reasonInsufficientRole  = "insufficient_role"
+	reasonInsufficientScope = "insufficient_scope"
+	reasonInvalidAudience   = "invalid_audience"
+	reasonClaimMismatch     = "claim_mismatch"
+)
+
+// authorizationError는 접근 조건을 만족하지 못한 이유입니다.
+type authorizationError struct {
+	Reason   string   `json:"reason"`
+	Required []string `json:"required,omitempty"` // 필요한 역할, 스코프 또는 aud
+	Mode     string   `json:"mode,omitempty"`     // 역할/스코프 조건 방식
+	Claim    string   `json:"claim,omitempty"`    // 일치하지 않은 클레임
+}
+
+// authorizeMiddleware는 인증된 사용자가 라우트의 접근 조건을 만족하는지 확인하는 핸들러를 반환합니다.
+// authMiddleware 이후에 사용해야 하며, 조건을 만족하지 못하면 403으로 응답합니다.
+func (h *RouteHandler) authorizeMiddleware(policy *config.AuthorizationConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get(claimsContextKey)
+		claims, _ := value.(*auth.Claims)
+		if claims == nil {
+			claims = &auth.Claims{}
+		}
+
+		if denied := authorize(claims, policy); denied != nil {
+			if denied.Reason == reasonInsufficientScope {
+				// RFC 6750 3.1
+				c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(denied.Required, " ")))
+			}
+			c.JSON(http.StatusForbidden, struct {
+				Error string `json:"error"`
+				*authorizationError
+			}{"접근 권한이 없습니다", denied})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// authorize는 클레임이 접근 조건을 만족하는지 확인하고, 만족하지 못하면 그 이유를 반환합니다.
+func authorize(claims *auth.Claims, policy *config.AuthorizationConfig) *authorizationError {
+	if len(policy.Audience) > 0 && !containsAny(claims.Audience, policy.Audience) {
+		return &authorizationError{Reason: reasonInvalidAudience, Required: policy.Audience}
+	}
+
+	if len(policy.Roles) > 0 && !satisfies(claims.Roles, policy.Roles, policy.RolesMode) {
+		return &authorizationError{Reason: reasonInsufficientRole, Required: policy.Roles, Mode: modeOrDefault(policy.RolesMode)}
+	}
+
+	if len(policy.Scopes) > 0 && !satisfies(claims.Scopes(), policy.Scopes, policy.ScopesMode) {
+		return &authorizationError{Reason: reasonInsufficientScope, Required: policy.Scopes, Mode: modeOrDefault(policy.ScopesMode)}
+	}
+
+	for _, matcher := range policy.Claims {
+		if !matchClaim(claims, matcher) {
+			return &authorizationError{Reason: reasonClaimMismatch, Claim: matcher.Claim}
+		}
+	}
+
+	return nil
+}
+
+// satisfies는 보유한 값이 필요한 값을 mode(any, all)에 따라 만족하는지 확인합니다.
+func satisfies(granted, required []string, mode string) bool {
+	if mode == config.AuthorizationModeAll {
+		for _, value := range required {
+			if !containsAny(granted, []string{value}) {
+				return false
+			}
+		}
+		return true
+	}
+	return containsAny(granted, required)
+}
+
+// containsAny는 values에 candidates 중 하나가 있는지 확인합니다.
+func containsAny(values, candidates []string) bool {
+	for _, value := range values {
+		for _, candidate := range candidates {
+			if value == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// modeOrDefault는 조건 방식이 비어 있으면 기본값(any)을 반환합니다.
+func modeOrDefault(mode string) string {
+	if mode == "" {
+		return config.AuthorizationModeAny
+	}
+	return mode
+}
+
+// matchClaim은 클레임이 조건을 만족하는지 확인합니다.
+func matchClaim(claims *auth.Claims, matcher config.ClaimMatcher) bool {
+	value, found := claims.Lookup(matcher.Claim)
+
+	switch matcher.Op {
+	case config.ClaimOpExists:
+		return found
+	case config.ClaimOpNotEquals:
+		return !found || !containsAny(claimStrings(value), []string{matcher.Value})
+	case config.ClaimOpIn:
+		return found && containsAny(claimStrings(value), matcher.Values)
+	default:
+		return found && containsAny(claimStrings(value), []string{matcher.Value})
+	}
+}
+
+// claimStrings는 클레임 값을 비교할 문자열 목록으로 변환합니다. 배열은 각 요소를 변환합니다.
+func claimStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, claimStrings(item)...)
+		}
+		return values
+	case string:
+		return []string{v}
+	case json.Number:
+		return []string{v.String()}
+	case map[string]interface{}:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"log"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+)
+
+// configureBreaker는 라우트에 적용할 서킷 브레이커 범위, 키, 설정을 구성합니다.
+func (t *routeTable) configureBreaker(h *RouteHandler, rt *routeRuntime) {
+	rt.breakerConfig = routeBreakerConfig(h.breakers.Defaults(), rt.route.CircuitBreaker)
+	rt.targetBreakers = t.targetBreakers
+
+	if rt.route.CircuitBreaker == nil || rt.route.CircuitBreaker.Scope != config.CircuitBreakerScopeTarget {
+		rt.breakerKey = routeBreakerKey(rt.route)
+		t.breakerKeys[rt.breakerKey] = true
+		return
+	}
+
+	// 대상 서버별 서킷 브레이커는 같은 대상을 사용하는 라우트 간에 공유되며,
+	// 설정이 다른 경우 먼저 등록된 라우트의 설정을 사용
+	rt.targetScoped = true
+	for _, origin := range rt.origins() {
+		key := targetBreakerKey(origin)
+		if existing, ok := t.targetBreakers[key]; ok {
+			if !reflect.DeepEqual(existing, rt.breakerConfig) {
+				log.Printf("[CIRCUIT] 라우트 %s: 대상 %s의 서킷 브레이커 설정이 다른 라우트와 달라 먼저 등록된 설정을 사용합니다", rt.route.Path, origin)
+			}
+			continue
+		}
+		t.targetBreakers[key] = rt.breakerConfig
+		t.breakerKeys[key] = true
+	}
+}
+
+// breakerFor는 요청에 적용할 서킷 브레이커를 반환합니다.
+func (h *RouteHandler) breakerFor(rt *routeRuntime, backend string) *circuitbreaker.CircuitBreaker {
+	if !rt.targetScoped {
+		return h.breakers.Get(rt.breakerKey, rt.breakerConfig)
+	}
+
+	origin := backend
+	if origin == "" {
+		origin = urlOrigin(rt.route.TargetURL)
+	}
+
+	key := targetBreakerKey(origin)
+	if cfg, ok := rt.targetBreakers[key]; ok {
+		return h.breakers.Get(key, cfg)
+	}
+	return h.breakers.Get(key, rt.breakerConfig)
+}
+
+// origins는 라우트가 요청을 전달할 수 있는 대상 서버 목록을 반환합니다.
+func (rt *routeRuntime) origins() []string {
+	if rt.balancer == nil {
+		return []string{urlOrigin(rt.route.TargetURL)}
+	}
+
+	targets := rt.balancer.GetTargets()
+	origins := make([]string, 0, len(targets))
+	for _, target := range targets {
+		origins = append(origins, target.URL)
+	}
+	return origins
+}
+
+// routeBreakerConfig는 기본 설정에 라우트별 설정을 덮어쓴 서킷 브레이커 설정을 반환합니다.
+func routeBreakerConfig(defaults circuitbreaker.Config, override *config.CircuitBreakerConfig) circuitbreaker.Config {
+	cfg := defaults
+	if override == nil {
+		return cfg
+	}
+
+	if override.ErrorThreshold > 0 {
+		cfg.ErrorThreshold = override.ErrorThreshold
+	}
+	if override.MinRequests > 0 {
+		cfg.MinRequests = override.MinRequests
+	}
+	if override.Timeout > 0 {
+		cfg.TimeoutDuration = time.Duration(override.Timeout) * time.Second
+	}
+	if override.HalfOpenMaxReqs > 0 {
+		cfg.HalfOpenMaxReqs = override.HalfOpenMaxReqs
+	}
+	if override.SuccessThreshold > 0 {
+		cfg.SuccessThreshold = override.SuccessThreshold
+	}
+	if override.FailureStatusCodes != nil {
+		cfg.FailureStatusCodes = override.FailureStatusCodes
+	}
+	if override.SlowCallThreshold > 0 {
+		cfg.SlowCallDuration = time.Duration(override.SlowCallThreshold) * time.Millisecond
+	}
+	if override.FailureErrors != nil {
+		cfg.FailureErrorTypes = override.FailureErrors
+	}
+	if override.SlowCallRateThreshold > 0 {
+		cfg.SlowCallRateThreshold = override.SlowCallRateThreshold
+	}
+	if override.WindowType != "" || override.WindowSize > 0 {
+		windowType := cfg.WindowType
+		if override.WindowType != "" {
+			windowType = override.WindowType
+		}
+		cfg.SetWindow(windowType, override.WindowSize)
+	}
+
+	return cfg
+}
+
+// routeBreakerKey는 라우트 범위 서킷 브레이커의 키를 반환합니다.
+func routeBreakerKey(route config.Route) string {
+	return "route:" + strings.Join(route.Methods, ",") + " " + route.Path
+}
+
+// targetBreakerKey는 대상 서버 범위 서킷 브레이커의 키를 반환합니다.
+func targetBreakerKey(origin string) string {
+	return "target:" + origin
+}
+
+// urlOrigin은 URL에서 스킴과 호스트만 추출합니다.
+func urlOrigin(target string) string {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return target
+	}
+	return u.Scheme + "://" + u.Host
+}
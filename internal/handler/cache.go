@@ -0,0 +1,619 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/middleware"
+	"github.com/isinthesky/api-gateway/internal/proxy"
+	"github.com/isinthesky/api-gateway/pkg/cache"
+)
+
+// cacheStatusHeader는 응답이 캐시에서 처리된 방식을 알리는 응답 헤더입니다.
+const cacheStatusHeader = "X-Cache"
+
+// 캐시 처리 방식
+const (
+	cacheHit         = "HIT"         // 신선한 캐시 응답
+	cacheMiss        = "MISS"        // 업스트림 응답
+	cacheStale       = "STALE"       // 만료된 캐시 응답 (stale-while-revalidate, stale-if-error)
+	cacheRevalidated = "REVALIDATED" // 업스트림 재검증(304) 후 캐시 응답
+)
+
+// defaultRevalidateTimeout은 타임아웃이 없는 라우트의 백그라운드 재검증 타임아웃입니다.
+const defaultRevalidateTimeout = 30 * time.Second
+
+// heuristicallyCacheable은 명시적인 만료 정보 없이도 저장할 수 있는 상태 코드입니다 (RFC 9110 15.1).
+var heuristicallyCacheable = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusMultipleChoices:      true,
+	http.StatusMovedPermanently:     true,
+	http.StatusPermanentRedirect:    true,
+	http.StatusNotFound:             true,
+	http.StatusMethodNotAllowed:     true,
+	http.StatusGone:                 true,
+	http.StatusRequestURITooLong:    true,
+	http.StatusNotImplemented:       true,
+}
+
+// uncachedHeaders는 캐시에 저장하지 않는 응답 헤더입니다 (홉 단위 헤더와 응답마다 계산하는 헤더).
+var uncachedHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Connection", "Proxy-Authenticate", "Te", "Trailer", "Transfer-Encoding", "Upgrade",
+	"Age", cacheStatusHeader, retryCountHeader,
+}
+
+// cacheMiddleware는 RFC 9111 공유 캐시 규칙에 따라 응답 캐싱을 처리하는 핸들러를 반환합니다.
+//   - Vary 헤더의 요청 헤더 값으로 보조 키를 만들어 변형 응답을 구분
+//   - s-maxage, max-age, Expires 순으로 신선도를 계산하고 no-cache, must-revalidate를 준수
+//   - 클라이언트의 If-None-Match/If-Modified-Since는 게이트웨이에서 평가하여 304로 응답
+//   - 만료된 응답은 ETag/Last-Modified로 재검증하며, stale-while-revalidate 기간에는 만료된 응답으로
+//     응답한 뒤 백그라운드에서 재검증하고, stale-if-error 기간에는 업스트림 오류 시 만료된 응답으로 응답
+//   - 같은 키로 동시에 발생한 캐시 미스는 업스트림 요청 하나로 합쳐 응답을 함께 사용
+func (h *RouteHandler) cacheMiddleware(rt *routeRuntime) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := c.Request
+		primaryKey := generateCacheKey(req)
+		authenticated := isAuthenticated(c)
+
+		if req.Method != http.MethodGet {
+			c.Next()
+
+			// 안전하지 않은 메서드가 성공하면 같은 URL의 캐시 무효화 (RFC 9111 4.4)
+			if !isSafeMethod(req.Method) && c.Writer.Status() < http.StatusBadRequest {
+				h.cache.Delete(cacheKeyFor(http.MethodGet, req))
+			}
+			return
+		}
+
+		reqCC := cache.ParseCacheControl(req.Header.Values("Cache-Control"))
+		noStore := reqCC.Has("no-store")
+		forceRevalidate := reqCC.Has("no-cache") || (len(reqCC) == 0 && req.Header.Get("Pragma") == "no-cache")
+
+		now := time.Now()
+		entry, key := h.lookupCache(primaryKey, req)
+		if entry != nil && !forceRevalidate {
+			if entry.IsFresh(now) && withinRequestMaxAge(entry, reqCC, now) {
+				h.writeCachedResponse(c, entry, cacheHit, now)
+				return
+			}
+			if entry.CanServeWhileRevalidating(now) && !reqCC.Has("max-age") {
+				h.writeCachedResponse(c, entry, cacheStale, now)
+				h.revalidateInBackground(rt, req, primaryKey, key, entry, authenticated)
+				return
+			}
+		}
+
+		// 같은 키의 캐시 미스는 업스트림 요청 하나로 합치고 그 응답을 함께 사용
+		var shared *cache.CachedResponse // 대기 중인 요청과 공유할 응답
+		var sharedStatus string
+		if h.config.CacheCoalesceTimeout > 0 {
+			flight, leader := h.joinCacheFlight(key)
+			if !leader {
+				if response, status, ok := h.waitCacheFlight(flight, req, primaryKey, authenticated); ok {
+					h.writeCachedResponse(c, response, status, time.Now())
+					return
+				}
+			} else {
+				// 업스트림 요청이 실패하거나 패닉이 발생해도 대기 중인 요청이 풀려나도록 defer로 전달
+				defer func() {
+					h.finishCacheFlight(key, flight, shared, sharedStatus, primaryKey, req)
+				}()
+			}
+		}
+
+		// 클라이언트의 조건부 요청은 게이트웨이에서 평가하고, 업스트림에는 캐시된 응답의 검증자로 요청
+		conditionals := takeConditionalHeaders(req.Header)
+		if entry != nil {
+			setValidators(req.Header, entry)
+		}
+
+		// 업스트림 응답은 클라이언트에 쓰면서 캡처하고, 캐시된 응답으로 대신할 수 있는 응답만 상태 코드가 정해질 때 보류
+		// (재검증 성공 304, stale-if-error 기간의 오류 응답)
+		clientReq := &http.Request{Header: conditionals}
+		writer := newCacheCaptureWriter(c.Writer, h.cacheMaxItemBytes(),
+			func(status int) bool {
+				return entry != nil && (status == http.StatusNotModified ||
+					(status >= http.StatusInternalServerError && entry.CanServeOnError(time.Now())))
+			},
+			func(status int, header http.Header) bool {
+				return status == http.StatusOK && (&cache.CachedResponse{Headers: header}).NotModified(clientReq)
+			})
+		c.Writer = writer
+		c.Next()
+		writer.WriteHeaderNow()
+		c.Writer = writer.ResponseWriter
+
+		restoreConditionalHeaders(req.Header, conditionals)
+		now = time.Now()
+
+		switch {
+		case writer.held && writer.status == http.StatusNotModified:
+			// 재검증 성공: 304 응답 헤더로 캐시된 응답 갱신
+			refreshed := h.refreshCachedResponse(entry, writer.header, now)
+			if !noStore {
+				h.storeCachedResponse(primaryKey, req, refreshed, now)
+			}
+			shared, sharedStatus = refreshed, cacheRevalidated
+			h.writeCachedResponse(c, refreshed, cacheRevalidated, now)
+
+		case writer.held:
+			log.Printf("[CACHE] 업스트림 오류(%d)로 만료된 캐시 응답 사용: %s", writer.status, primaryKey)
+			shared, sharedStatus = entry, cacheStale
+			h.writeCachedResponse(c, entry, cacheStale, now)
+
+		case writer.overflow:
+			log.Printf("[CACHE] 응답 본문이 항목 크기 제한(%d바이트)을 넘어 저장하지 않음: %s", writer.maxBytes, primaryKey)
+
+		default:
+			response := h.newCachedResponse(writer.status, writer.header, writer.body.Bytes(), now)
+			if isStorable(response, authenticated) {
+				if !noStore {
+					h.storeCachedResponse(primaryKey, req, response, now)
+				}
+				shared, sharedStatus = response, cacheMiss
+			}
+		}
+	}
+}
+
+// cacheMaxItemBytes는 캐시에 저장할 응답 본문의 최대 크기를 반환합니다 (CACHE_MAX_ITEM_BYTES).
+func (h *RouteHandler) cacheMaxItemBytes() int64 {
+	if h.config.CacheMaxItemBytes > 0 {
+		return h.config.CacheMaxItemBytes
+	}
+	return cache.DefaultMaxItemBytes
+}
+
+// lookupCache는 요청에 맞는 캐시된 응답과 그 키를 찾습니다.
+// 주 키에 Vary 목록이 저장되어 있으면 요청 헤더 값으로 만든 보조 키에서 찾습니다.
+// 저장소는 Vary 목록 항목의 조회를 세지 않으므로 조회 통계에는 보조 키 조회 결과만 기록됩니다.
+func (h *RouteHandler) lookupCache(primaryKey string, req *http.Request) (*cache.CachedResponse, string) {
+	entry, found := h.cache.Get(primaryKey)
+	if !found {
+		return nil, primaryKey
+	}
+	if len(entry.Vary) == 0 {
+		return entry, primaryKey
+	}
+
+	key := varyCacheKey(primaryKey, entry.Vary, req.Header)
+	variant, found := h.cache.Get(key)
+	if !found || len(variant.Vary) > 0 {
+		return nil, key
+	}
+	return variant, key
+}
+
+// storeCachedResponse는 응답을 저장소에 저장합니다.
+// 응답에 Vary 헤더가 있으면 주 키에는 Vary 목록을, 보조 키에는 응답을 저장합니다.
+func (h *RouteHandler) storeCachedResponse(primaryKey string, req *http.Request, response *cache.CachedResponse, now time.Time) {
+	ttl := response.StorageTTL(now, h.config.CacheTTL)
+	if ttl <= 0 {
+		return
+	}
+
+	vary := varyHeaderNames(response.Headers)
+	if len(vary) == 0 {
+		h.cache.Set(primaryKey, response, ttl)
+		return
+	}
+
+	h.cache.Set(primaryKey, &cache.CachedResponse{Vary: vary}, ttl)
+	h.cache.Set(varyCacheKey(primaryKey, vary, req.Header), response, ttl)
+}
+
+// newCachedResponse는 업스트림 응답으로 신선도 정보를 가진 캐시 응답을 만듭니다.
+func (h *RouteHandler) newCachedResponse(status int, header http.Header, body []byte, now time.Time) *cache.CachedResponse {
+	response := &cache.CachedResponse{
+		StatusCode: status,
+		Headers:    header.Clone(),
+		Body:       body,
+	}
+	response.ApplyFreshness(cache.ComputeFreshness(header, now, h.config.CacheTTL), now)
+	for _, name := range uncachedHeaders {
+		response.Headers.Del(name)
+	}
+	return response
+}
+
+// refreshCachedResponse는 재검증 응답(304)의 헤더로 캐시된 응답을 갱신합니다 (RFC 9111 4.3.4).
+func (h *RouteHandler) refreshCachedResponse(entry *cache.CachedResponse, header http.Header, now time.Time) *cache.CachedResponse {
+	refreshed := entry.Clone()
+	for name, values := range header {
+		if name == "Content-Length" || name == "Content-Type" {
+			continue
+		}
+		refreshed.Headers[name] = append([]string(nil), values...)
+	}
+	for _, name := range uncachedHeaders {
+		refreshed.Headers.Del(name)
+	}
+
+	// 저장된 헤더에는 Age가 없으므로 304 응답의 Age로 나이를 다시 계산
+	freshness := cache.ComputeFreshness(refreshed.Headers, now, h.config.CacheTTL)
+	if age, err := strconv.ParseInt(header.Get("Age"), 10, 64); err == nil && age > 0 {
+		freshness.InitialAge = time.Duration(age) * time.Second
+	}
+	refreshed.ApplyFreshness(freshness, now)
+	return refreshed
+}
+
+// writeCachedResponse는 캐시 응답을 클라이언트에 씁니다.
+// 클라이언트의 조건부 요청과 일치하면 본문 없이 304로 응답합니다.
+func (h *RouteHandler) writeCachedResponse(c *gin.Context, response *cache.CachedResponse, status string, now time.Time) {
+	header := c.Writer.Header()
+	for name, values := range response.Headers {
+		for _, value := range values {
+			header.Add(name, value)
+		}
+	}
+	header.Set(cacheStatusHeader, status)
+	header.Set("Age", strconv.Itoa(int(response.Age(now)/time.Second)))
+
+	if response.StatusCode == http.StatusOK && response.NotModified(c.Request) {
+		header.Del("Content-Length")
+		c.Writer.WriteHeader(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		c.Abort()
+		return
+	}
+
+	c.Writer.WriteHeader(response.StatusCode)
+	c.Writer.Write(response.Body)
+	c.Abort()
+}
+
+// revalidateInBackground는 만료된 응답을 백그라운드에서 재검증합니다 (stale-while-revalidate).
+// 같은 키의 재검증이 이미 진행 중이면 아무것도 하지 않습니다.
+func (h *RouteHandler) revalidateInBackground(rt *routeRuntime, req *http.Request, primaryKey, key string, entry *cache.CachedResponse, authenticated bool) {
+	if _, running := h.revalidating.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	timeout := defaultRevalidateTimeout
+	if rt.route.Timeout > 0 {
+		timeout = time.Duration(rt.route.Timeout) * time.Second
+	}
+
+	// 클라이언트 요청이 끝나도 재검증이 계속되도록 요청을 복제 (업스트림에 전달할 사용자 정보는 유지)
+	outReq := req.Clone(proxy.WithIdentity(context.Background(), proxy.IdentityFrom(req.Context())))
+	outReq.Body = http.NoBody
+	takeConditionalHeaders(outReq.Header)
+	setValidators(outReq.Header, entry)
+
+	go func() {
+		defer h.revalidating.Delete(key)
+
+		ctx, cancel := context.WithTimeout(outReq.Context(), timeout)
+		defer cancel()
+
+		resp, body, err := h.fetchUpstream(ctx, rt, outReq.WithContext(ctx))
+		if err != nil {
+			log.Printf("[CACHE] 백그라운드 재검증 실패: %s - %v", key, err)
+			return
+		}
+
+		now := time.Now()
+		switch {
+		case resp.StatusCode == http.StatusNotModified:
+			h.storeCachedResponse(primaryKey, outReq, h.refreshCachedResponse(entry, resp.Header, now), now)
+		case resp.StatusCode >= http.StatusInternalServerError:
+			log.Printf("[CACHE] 백그라운드 재검증 업스트림 오류(%d): %s", resp.StatusCode, key)
+		default:
+			response := h.newCachedResponse(resp.StatusCode, resp.Header, body, now)
+			if isStorable(response, authenticated) {
+				h.storeCachedResponse(primaryKey, outReq, response, now)
+			} else {
+				h.cache.Delete(key)
+			}
+		}
+	}()
+}
+
+// fetchUpstream은 라우트의 업스트림에 요청을 한 번 전달하고 응답 본문까지 읽어 반환합니다.
+func (h *RouteHandler) fetchUpstream(ctx context.Context, rt *routeRuntime, req *http.Request) (*http.Response, []byte, error) {
+	targetPath, backend, err := rt.resolveTarget()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rt.release(backend)
+
+	result, err := h.breakerFor(rt, backend).Execute(func() (interface{}, error) {
+		return h.transportFor(rt).ForwardRequest(ctx, req, targetPath, rt.route.StripPrefix != "", rt.route.StripPrefix)
+	})
+	h.reportUpstreamResult(rt, backend, result, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, ok := result.(*http.Response)
+	if !ok {
+		return nil, nil, errors.New("unexpected upstream response type")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// isAuthenticated는 요청이 인증 정보를 가지고 있는지 확인합니다.
+// API 키 인증은 업스트림에 전달하기 전에 키 헤더와 쿼리 파라미터를 제거하므로 인증 미들웨어가 저장한 컨텍스트로도 확인합니다.
+func isAuthenticated(c *gin.Context) bool {
+	if c.Request.Header.Get("Authorization") != "" || c.GetString(middleware.APIKeyIDContextKey) != "" {
+		return true
+	}
+	_, exists := c.Get(claimsContextKey)
+	return exists
+}
+
+// isStorable은 응답을 공유 캐시에 저장할 수 있는지 확인합니다 (RFC 9111 3).
+// authenticated는 응답을 받은 요청이 인증된 요청인지 여부입니다 (isAuthenticated 참조).
+func isStorable(response *cache.CachedResponse, authenticated bool) bool {
+	if !heuristicallyCacheable[response.StatusCode] {
+		return false
+	}
+
+	cc := cache.ParseCacheControl(response.Headers.Values("Cache-Control"))
+	if cc.Has("no-store") || cc.Has("private") {
+		return false
+	}
+
+	// 사용자별 응답이 다른 사용자에게 전달되지 않도록 쿠키를 설정하는 응답은 저장하지 않음
+	if response.Headers.Get("Set-Cookie") != "" {
+		return false
+	}
+	for _, name := range varyHeaderNames(response.Headers) {
+		if name == "*" {
+			return false
+		}
+	}
+
+	// 인증된 요청의 응답은 공유 캐시 사용을 명시한 경우에만 저장 (RFC 9111 3.5)
+	if authenticated && !cc.Has("public") && !cc.Has("s-maxage") {
+		return false
+	}
+
+	return true
+}
+
+// withinRequestMaxAge는 클라이언트가 요청한 최대 나이(Cache-Control: max-age) 이내인지 확인합니다.
+func withinRequestMaxAge(entry *cache.CachedResponse, reqCC cache.CacheControl, now time.Time) bool {
+	maxAge, ok := reqCC.Seconds("max-age")
+	return !ok || entry.Age(now) <= maxAge
+}
+
+// isSafeMethod는 안전한 HTTP 메서드인지 확인합니다.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// takeConditionalHeaders는 요청에서 조건부 헤더를 제거하고 반환합니다.
+func takeConditionalHeaders(header http.Header) http.Header {
+	conditionals := make(http.Header)
+	for _, name := range []string{"If-None-Match", "If-Modified-Since"} {
+		if values := header.Values(name); len(values) > 0 {
+			conditionals[name] = values
+			header.Del(name)
+		}
+	}
+	return conditionals
+}
+
+// restoreConditionalHeaders는 제거했던 클라이언트의 조건부 헤더로 되돌립니다.
+func restoreConditionalHeaders(header, conditionals http.Header) {
+	header.Del("If-None-Match")
+	header.Del("If-Modified-Since")
+	for name, values := range conditionals {
+		header[name] = values
+	}
+}
+
+// setValidators는 캐시된 응답의 검증자로 업스트림 조건부 요청 헤더를 설정합니다.
+func setValidators(header http.Header, entry *cache.CachedResponse) {
+	if etag := entry.Headers.Get("ETag"); etag != "" {
+		header.Set("If-None-Match", etag)
+	}
+	if lastModified := entry.Headers.Get("Last-Modified"); lastModified != "" {
+		header.Set("If-Modified-Since", lastModified)
+	}
+}
+
+// varyHeaderNames는 응답의 Vary 헤더에 나열된 요청 헤더 이름을 정규화하여 정렬된 목록으로 반환합니다.
+func varyHeaderNames(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// generateCacheKey는 요청에 대한 고유한 캐시 키를 생성합니다.
+func generateCacheKey(req *http.Request) string {
+	return cacheKeyFor(req.Method, req)
+}
+
+// cacheKeyFor는 지정한 메서드로 요청 URL의 캐시 키를 생성합니다 (예: "GET:/items?page=1").
+// 경로는 이스케이프된 형태를 사용하므로 키에서 경로를 구분할 수 있습니다 (cacheKeyPath 참고).
+func cacheKeyFor(method string, req *http.Request) string {
+	key := method + ":" + req.URL.EscapedPath()
+	if req.URL.RawQuery != "" {
+		key += "?" + req.URL.RawQuery
+	}
+	return key
+}
+
+// cacheKeyPath는 캐시 키에서 요청 경로를 추출합니다.
+// 이스케이프된 경로에는 '?'와 '|'가 없으므로 쿼리와 보조 키 앞에서 끝납니다.
+func cacheKeyPath(key string) string {
+	_, rest, found := strings.Cut(key, ":")
+	if !found {
+		return ""
+	}
+	if end := strings.IndexAny(rest, "?|"); end >= 0 {
+		rest = rest[:end]
+	}
+	return rest
+}
+
+// varyCacheKey는 Vary에 나열된 요청 헤더 값으로 보조 캐시 키를 생성합니다.
+func varyCacheKey(primaryKey string, vary []string, header http.Header) string {
+	parts := make([]string, len(vary))
+	for i, name := range vary {
+		values := header.Values(name)
+		for j := range values {
+			values[j] = strings.TrimSpace(values[j])
+		}
+		parts[i] = name + "=" + strings.Join(values, ",")
+	}
+	return primaryKey + "|vary:" + strings.Join(parts, "&")
+}
+
+// cacheCaptureWriter는 업스트림 응답을 클라이언트에 쓰면서 캐시에 저장할 본문을 함께 캡처하는 gin.ResponseWriter 래퍼입니다.
+// 상태 코드가 정해질 때 hold가 true를 반환하면(재검증 304, 만료된 응답으로 대신할 오류 응답) 클라이언트에 쓰지 않고 보류하며,
+// notModified가 true를 반환하면 클라이언트에 본문 없이 304로 응답합니다.
+// 본문이 maxBytes를 넘으면 캡처를 중단하고 클라이언트에는 계속 씁니다.
+type cacheCaptureWriter struct {
+	gin.ResponseWriter
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	size        int
+	maxBytes    int64
+	decided     bool // 상태 코드와 헤더가 정해짐
+	held        bool // 클라이언트에 쓰지 않고 보류
+	discard     bool // 클라이언트에 본문을 쓰지 않음 (304 응답)
+	overflow    bool // 본문이 maxBytes를 넘어 캡처 중단
+	hold        func(status int) bool
+	notModified func(status int, header http.Header) bool
+}
+
+// newCacheCaptureWriter는 새로운 cacheCaptureWriter를 생성합니다.
+func newCacheCaptureWriter(writer gin.ResponseWriter, maxBytes int64, hold func(int) bool, notModified func(int, http.Header) bool) *cacheCaptureWriter {
+	return &cacheCaptureWriter{
+		ResponseWriter: writer,
+		header:         make(http.Header),
+		status:         http.StatusOK,
+		size:           -1,
+		maxBytes:       maxBytes,
+		hold:           hold,
+		notModified:    notModified,
+	}
+}
+
+// Header는 업스트림 응답 헤더를 반환합니다. 상태 코드가 정해질 때 클라이언트 응답에 복사합니다.
+func (w *cacheCaptureWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader는 상태 코드를 기록합니다.
+func (w *cacheCaptureWriter) WriteHeader(code int) {
+	if code > 0 && !w.decided {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow는 상태 코드를 확정하고 보류하지 않는 응답이면 헤더를 클라이언트에 씁니다.
+func (w *cacheCaptureWriter) WriteHeaderNow() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	w.size = 0
+	if w.hold(w.status) {
+		w.held = true
+		return
+	}
+
+	header := w.ResponseWriter.Header()
+	for name, values := range w.header {
+		for _, value := range values {
+			header.Add(name, value)
+		}
+	}
+	header.Set(cacheStatusHeader, cacheMiss)
+
+	if w.notModified(w.status, w.header) {
+		w.discard = true
+		header.Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+	} else {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Write는 응답 본문을 클라이언트에 쓰고 캡처합니다.
+func (w *cacheCaptureWriter) Write(b []byte) (int, error) {
+	w.WriteHeaderNow()
+	w.capture(b)
+	w.size += len(b)
+	if w.held || w.discard {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// WriteString은 응답 본문을 클라이언트에 쓰고 캡처합니다.
+func (w *cacheCaptureWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// capture는 본문이 maxBytes를 넘지 않는 동안 본문을 캡처합니다.
+func (w *cacheCaptureWriter) capture(b []byte) {
+	if w.overflow || w.held {
+		return
+	}
+	if int64(w.body.Len()+len(b)) > w.maxBytes {
+		w.overflow = true
+		w.body = bytes.Buffer{}
+		return
+	}
+	w.body.Write(b)
+}
+
+// Status는 업스트림 응답 상태 코드를 반환합니다.
+func (w *cacheCaptureWriter) Status() int {
+	return w.status
+}
+
+// Size는 업스트림 응답 본문 크기를 반환합니다.
+func (w *cacheCaptureWriter) Size() int {
+	return w.size
+}
+
+// Written은 상태 코드가 확정되었는지 확인합니다.
+func (w *cacheCaptureWriter) Written() bool {
+	return w.decided
+}
+
+// Flush는 보류하지 않는 응답을 클라이언트에 즉시 전송합니다.
+func (w *cacheCaptureWriter) Flush() {
+	w.WriteHeaderNow()
+	if !w.held && !w.discard {
+		w.ResponseWriter.Flush()
+	}
+}
@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/pkg/cache"
+)
+
+// surrogateKeyHeader는 업스트림이 응답에 태그를 붙이는 헤더입니다 (공백으로 구분된 태그 목록).
+// 태그가 같은 캐시 항목은 관리자 API에서 한 번에 삭제할 수 있습니다.
+const surrogateKeyHeader = "Surrogate-Key"
+
+// 캐시 항목 목록 조회 개수
+const (
+	defaultCacheListLimit = 100
+	maxCacheListLimit     = 1000
+)
+
+// cacheEntryFilter는 관리자 API에서 캐시 항목을 고르는 조건입니다. 지정한 조건을 모두 만족하는 항목을 고릅니다.
+type cacheEntryFilter struct {
+	key     string // 정확한 캐시 키 (Vary 보조 키 포함)
+	prefix  string // 요청 경로 접두사
+	pattern string // 요청 경로 glob 패턴 (path.Match, '*'는 '/'와 일치하지 않음)
+	tag     string // Surrogate-Key 태그
+}
+
+// parseCacheEntryFilter는 쿼리 파라미터(key, prefix, pattern, tag)로 조건을 만듭니다.
+func parseCacheEntryFilter(c *gin.Context) (cacheEntryFilter, error) {
+	filter := cacheEntryFilter{
+		key:     c.Query("key"),
+		prefix:  c.Query("prefix"),
+		pattern: c.Query("pattern"),
+		tag:     c.Query("tag"),
+	}
+	if filter.pattern != "" {
+		if _, err := path.Match(filter.pattern, ""); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// empty는 조건이 하나도 없는지 확인합니다.
+func (f cacheEntryFilter) empty() bool {
+	return f.key == "" && f.prefix == "" && f.pattern == "" && f.tag == ""
+}
+
+// matches는 항목이 조건을 모두 만족하는지 확인합니다.
+func (f cacheEntryFilter) matches(entry cache.Entry) bool {
+	if f.key != "" && entry.Key != f.key && !strings.HasPrefix(entry.Key, f.key+"|vary:") {
+		return false
+	}
+
+	entryPath := cacheKeyPath(entry.Key)
+	if f.prefix != "" && !strings.HasPrefix(entryPath, f.prefix) {
+		return false
+	}
+	if f.pattern != "" {
+		if matched, _ := path.Match(f.pattern, entryPath); !matched {
+			return false
+		}
+	}
+
+	if f.tag != "" {
+		for _, tag := range surrogateKeys(entry.Response.Headers) {
+			if tag == f.tag {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// CacheEntriesHandler는 조건과 일치하는 캐시 항목 목록과 캐시 통계를 반환합니다.
+// 항목은 키 순서로 정렬하며 limit 파라미터로 개수를 제한합니다 (기본 100, 최대 1000).
+func (h *RouteHandler) CacheEntriesHandler(c *gin.Context) {
+	filter, err := parseCacheEntryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 경로 패턴입니다: " + err.Error()})
+		return
+	}
+
+	limit := defaultCacheListLimit
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit은 양의 정수여야 합니다"})
+			return
+		}
+		if limit > maxCacheListLimit {
+			limit = maxCacheListLimit
+		}
+	}
+
+	var matched []cache.Entry
+	h.cache.Range(func(entry cache.Entry) bool {
+		if filter.matches(entry) {
+			matched = append(matched, entry)
+		}
+		return true
+	})
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Key < matched[j].Key })
+
+	now := time.Now()
+	entries := make([]gin.H, 0, limit)
+	for i := 0; i < len(matched) && i < limit; i++ {
+		entries = append(entries, cacheEntrySummary(matched[i], now))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"total":   len(matched),
+		"stats":   h.cache.Stats(),
+	})
+}
+
+// CacheEntryHandler는 key 파라미터로 지정한 캐시 항목의 헤더와 신선도 정보를 반환합니다.
+func (h *RouteHandler) CacheEntryHandler(c *gin.Context) {
+	key := c.Query("key")
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "key 파라미터가 필요합니다"})
+		return
+	}
+
+	var found *cache.Entry
+	h.cache.Range(func(entry cache.Entry) bool {
+		if entry.Key == key {
+			found = &entry
+			return false
+		}
+		return true
+	})
+	if found == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "캐시 항목이 없습니다"})
+		return
+	}
+
+	now := time.Now()
+	detail := cacheEntrySummary(*found, now)
+	if response := found.Response; len(response.Vary) == 0 {
+		detail["headers"] = response.Headers
+		detail["bodySize"] = len(response.Body)
+		detail["storedAt"] = response.StoredAt.Format(time.RFC3339)
+		detail["lifetime"] = int(response.Lifetime / time.Second)
+		detail["staleWhileRevalidate"] = int(response.StaleWhileRevalidate / time.Second)
+		detail["staleIfError"] = int(response.StaleIfError / time.Second)
+		detail["mustRevalidate"] = response.MustRevalidate
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// PurgeCacheHandler는 조건(key, prefix, pattern, tag)과 일치하는 캐시 항목을 삭제합니다.
+// all=true이면 모든 항목을 삭제합니다.
+func (h *RouteHandler) PurgeCacheHandler(c *gin.Context) {
+	if c.Query("all") == "true" {
+		h.cache.Clear()
+		c.JSON(http.StatusOK, gin.H{"cleared": true})
+		return
+	}
+
+	filter, err := parseCacheEntryFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 경로 패턴입니다: " + err.Error()})
+		return
+	}
+	if filter.empty() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "삭제할 항목 조건(key, prefix, pattern, tag 또는 all=true)이 필요합니다"})
+		return
+	}
+
+	keys := cache.Purge(h.cache, filter.matches)
+	if keys == nil {
+		keys = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{"purged": len(keys), "keys": keys})
+}
+
+// cacheEntrySummary는 캐시 항목의 요약 정보를 만듭니다.
+// ttl은 저장소에서 제거될 때까지 남은 시간(초)이며, Vary 목록 항목은 응답 정보 대신 vary를 포함합니다.
+func cacheEntrySummary(entry cache.Entry, now time.Time) gin.H {
+	response := entry.Response
+	summary := gin.H{
+		"key":       entry.Key,
+		"path":      cacheKeyPath(entry.Key),
+		"size":      entry.Size,
+		"ttl":       int(response.Expiry.Sub(now) / time.Second),
+		"expiresAt": response.Expiry.Format(time.RFC3339),
+	}
+	if len(response.Vary) > 0 {
+		summary["vary"] = response.Vary
+		return summary
+	}
+
+	summary["status"] = response.StatusCode
+	summary["age"] = int(response.Age(now) / time.Second)
+	summary["fresh"] = response.IsFresh(now)
+	if tags := surrogateKeys(response.Headers); len(tags) > 0 {
+		summary["tags"] = tags
+	}
+	return summary
+}
+
+// surrogateKeys는 응답의 Surrogate-Key 헤더에 나열된 태그를 반환합니다.
+func surrogateKeys(header http.Header) []string {
+	var tags []string
+	for _, value := range header.Values(surrogateKeyHeader) {
+		tags = append(tags, strings.Fields(value)...)
+	}
+	return tags
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/isinthesky/api-gateway/pkg/cache"
+)
+
+// cacheFlight는 같은 캐시 키로 진행 중인 업스트림 요청입니다.
+// 먼저 도착한 요청(리더)이 업스트림에 요청하고, 같은 키의 캐시 미스는 그 결과를 기다려 함께 사용합니다.
+type cacheFlight struct {
+	done     chan struct{}
+	response *cache.CachedResponse // 대기 중인 요청과 공유할 응답 (nil이면 각자 업스트림에 요청)
+	status   string                // 공유한 응답의 X-Cache 값
+	varyKey  string                // 리더 요청 헤더로 만든 보조 키 (응답에 Vary가 있을 때 비교)
+}
+
+// joinCacheFlight는 키로 진행 중인 업스트림 요청에 참여합니다.
+// 진행 중인 요청이 없으면 새 요청을 등록하고 leader로 true를 반환합니다.
+func (h *RouteHandler) joinCacheFlight(key string) (flight *cacheFlight, leader bool) {
+	flight = &cacheFlight{done: make(chan struct{})}
+	existing, loaded := h.cacheFlights.LoadOrStore(key, flight)
+	if loaded {
+		return existing.(*cacheFlight), false
+	}
+	return flight, true
+}
+
+// finishCacheFlight는 리더의 응답을 대기 중인 요청에 전달합니다.
+// response가 nil이면 대기 중인 요청은 각자 업스트림에 요청합니다.
+func (h *RouteHandler) finishCacheFlight(key string, flight *cacheFlight, response *cache.CachedResponse, status, primaryKey string, req *http.Request) {
+	if response != nil {
+		flight.response = response
+		flight.status = status
+		if vary := varyHeaderNames(response.Headers); len(vary) > 0 {
+			flight.varyKey = varyCacheKey(primaryKey, vary, req.Header)
+		}
+	}
+	h.cacheFlights.CompareAndDelete(key, flight)
+	close(flight.done)
+}
+
+// waitCacheFlight는 리더의 응답을 기다립니다.
+// 대기 시간이 CacheCoalesceTimeout을 넘거나, 리더의 응답을 이 요청에 사용할 수 없으면 false를 반환합니다.
+// authenticated는 대기 중인 요청이 인증된 요청인지 여부입니다.
+func (h *RouteHandler) waitCacheFlight(flight *cacheFlight, req *http.Request, primaryKey string, authenticated bool) (*cache.CachedResponse, string, bool) {
+	timer := time.NewTimer(h.config.CacheCoalesceTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-flight.done:
+	case <-timer.C:
+		return nil, "", false
+	case <-req.Context().Done():
+		return nil, "", false
+	}
+
+	response := flight.response
+	if response == nil || !isStorable(response, authenticated) {
+		return nil, "", false
+	}
+	// 리더와 Vary 헤더 값이 다른 요청은 다른 변형 응답을 받아야 함
+	if vary := varyHeaderNames(response.Headers); len(vary) > 0 && varyCacheKey(primaryKey, vary, req.Header) != flight.varyKey {
+		return nil, "", false
+	}
+	return response, flight.status, true
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/middleware"
+	"github.com/isinthesky/api-gateway/pkg/concurrency"
+)
+
+// configureConcurrency는 라우트에 적용할 동시성 제한기를 구성합니다.
+// 라우트 제한기를 먼저, 업스트림 제한기(같은 업스트림을 사용하는 라우트 간에 공유)를 나중에 적용합니다.
+func (t *routeTable) configureConcurrency(h *RouteHandler, rt *routeRuntime) {
+	rt.concurrency = nil
+
+	if cc := rt.route.Concurrency; cc != nil {
+		key := routeBreakerKey(rt.route)
+		rt.concurrency = append(rt.concurrency, t.concurrencyScope(h, key, cc))
+	}
+	if rt.upstream != nil && rt.upstream.Concurrency != nil {
+		key := "upstream:" + rt.upstream.Name
+		rt.concurrency = append(rt.concurrency, t.concurrencyScope(h, key, rt.upstream.Concurrency))
+	}
+}
+
+// concurrencyScope는 레지스트리에서 키의 동시성 제한기를 가져와 범위로 구성합니다.
+// 설정이 바뀌지 않았으면 리로드 후에도 같은 제한기(처리 중인 요청 수와 조정된 한도)를 사용합니다.
+func (t *routeTable) concurrencyScope(h *RouteHandler, key string, cc *config.ConcurrencyConfig) middleware.ConcurrencyScope {
+	t.concurrencyKeys[key] = true
+
+	retryAfter := time.Second
+	if cc.RetryAfter > 0 {
+		retryAfter = time.Duration(cc.RetryAfter) * time.Second
+	}
+
+	return middleware.ConcurrencyScope{
+		Name:       key,
+		Limiter:    h.concurrency.Get(key, concurrencyConfig(cc)),
+		RetryAfter: retryAfter,
+	}
+}
+
+// concurrencyConfig는 라우트 설정을 동시성 제한기 설정으로 변환합니다.
+func concurrencyConfig(cc *config.ConcurrencyConfig) concurrency.Config {
+	return concurrency.Config{
+		Mode:             concurrency.Mode(cc.Mode),
+		Limit:            cc.Limit,
+		MinLimit:         cc.MinLimit,
+		MaxLimit:         cc.MaxLimit,
+		QueueSize:        cc.QueueSize,
+		QueueTimeout:     time.Duration(cc.QueueTimeout) * time.Millisecond,
+		LatencyThreshold: time.Duration(cc.LatencyThreshold) * time.Millisecond,
+		BackoffRatio:     cc.BackoffRatio,
+		Tolerance:        cc.Tolerance,
+	}
+}
+
+// ConcurrencyHandler는 모든 동시성 제한기의 현재 한도와 처리/대기 중인 요청 수를 반환합니다.
+func (h *RouteHandler) ConcurrencyHandler(c *gin.Context) {
+	limiters := make([]gin.H, 0)
+	for _, key := range h.concurrency.Keys() {
+		limiter, ok := h.concurrency.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		stats := limiter.Stats()
+		limiters = append(limiters, gin.H{
+			"key":      key,
+			"mode":     limiter.Config().Mode,
+			"limit":    stats.Limit,
+			"inFlight": stats.InFlight,
+			"queued":   stats.Queued,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"concurrency": limiters})
+}
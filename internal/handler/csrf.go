@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/middleware"
+)
+
+// cookieAuthContextKey는 요청의 인증 정보가 쿠키(access_token 쿠키, 브라우저 로그인 세션)에서 왔음을 표시하는 컨텍스트 키입니다.
+const cookieAuthContextKey = "cookieAuth"
+
+// csrfPolicy는 라우트에 적용할 CSRF 검사 정책입니다.
+type csrfPolicy struct {
+	checkOrigin    bool     // 요청 출처 확인
+	checkToken     bool     // 이중 제출 토큰 확인
+	trustedOrigins []string // 요청한 오리진 외에 허용하는 오리진
+}
+
+// newCSRFPolicy는 라우트 설정과 CSRF_MODE로 CSRF 검사 정책을 생성합니다. 검사하지 않으면 nil을 반환합니다.
+// 허용 오리진은 CORS 허용 목록(ALLOWED_ORIGINS)과 CSRF_TRUSTED_ORIGINS, 라우트의 trustedOrigins를 합친 목록입니다.
+func (h *RouteHandler) newCSRFPolicy(cfg *config.CSRFConfig) *csrfPolicy {
+	mode := h.config.CSRFMode
+	if mode == "" {
+		mode = config.CSRFModeOrigin
+	}
+	if cfg != nil && cfg.Mode != "" {
+		mode = cfg.Mode
+	}
+	if mode == config.CSRFModeOff {
+		return nil
+	}
+
+	p := &csrfPolicy{
+		checkOrigin: mode == config.CSRFModeOrigin || mode == config.CSRFModeBoth,
+		checkToken:  mode == config.CSRFModeToken || mode == config.CSRFModeBoth,
+	}
+	p.trustedOrigins = append(p.trustedOrigins, h.config.AllowedOrigins...)
+	p.trustedOrigins = append(p.trustedOrigins, h.config.CSRFTrustedOrigins...)
+	if cfg != nil {
+		p.trustedOrigins = append(p.trustedOrigins, cfg.TrustedOrigins...)
+	}
+	return p
+}
+
+// csrfMiddleware는 쿠키로 인증한 상태 변경 요청의 CSRF 검사를 수행하는 핸들러를 반환합니다.
+// 쿠키는 다른 사이트에서 보낸 요청에도 자동으로 포함되므로, Authorization 헤더나 API 키로 인증한 요청과 안전한 메서드는 검사하지 않습니다.
+// cookieToHeaderMiddleware 뒤에 사용해야 합니다.
+func (h *RouteHandler) csrfMiddleware(p *csrfPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool(cookieAuthContextKey) {
+			c.Next()
+			return
+		}
+
+		// 토큰 방식은 쿠키로 인증한 요청에 토큰 쿠키가 없으면 발급 (스크립트가 읽어 헤더로 다시 보냄)
+		token, _ := c.Cookie(h.csrfCookie())
+		if p.checkToken && token == "" {
+			h.issueCSRFToken(c)
+		}
+		if isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if p.checkOrigin && !csrfOriginAllowed(c.Request, p.trustedOrigins) {
+			log.Printf("[CSRF] 허용되지 않은 출처 - %s %s, Origin: %q, Referer: %q, Sec-Fetch-Site: %q",
+				c.Request.Method, c.Request.URL.Path, c.GetHeader("Origin"), c.GetHeader("Referer"), c.GetHeader("Sec-Fetch-Site"))
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "CSRF 검증 실패: 허용되지 않은 출처의 요청입니다"})
+			return
+		}
+		if p.checkToken && (token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(c.GetHeader(h.csrfHeader()))) != 1) {
+			log.Printf("[CSRF] 토큰 불일치 - %s %s", c.Request.Method, c.Request.URL.Path)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "CSRF 검증 실패: CSRF 토큰이 없거나 일치하지 않습니다"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// csrfOriginAllowed는 요청이 같은 출처 또는 허용된 오리진에서 왔는지 확인합니다.
+// Sec-Fetch-Site가 same-origin이나 none(사용자가 직접 이동)이면 허용하고, 그 외에는 Origin(없으면 Referer)의 오리진을 확인합니다.
+// 출처 헤더가 모두 없는 요청은 브라우저가 보낸 교차 사이트 요청이 아니므로 허용합니다.
+func csrfOriginAllowed(req *http.Request, trustedOrigins []string) bool {
+	fetchSite := req.Header.Get("Sec-Fetch-Site")
+	if fetchSite == "same-origin" || fetchSite == "none" {
+		return true
+	}
+
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		referer, err := url.Parse(req.Header.Get("Referer"))
+		if err != nil || referer.Host == "" {
+			return fetchSite == "" && req.Header.Get("Referer") == ""
+		}
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	return strings.EqualFold(origin, requestOrigin(req)) || middleware.IsTrustedOrigin(trustedOrigins, origin)
+}
+
+// requestOrigin은 요청을 받은 게이트웨이의 오리진을 반환합니다. TLS 종료 프록시 뒤에서는 X-Forwarded-Proto로 스킴을 판단합니다.
+func requestOrigin(req *http.Request) string {
+	return requestScheme(req) + "://" + req.Host
+}
+
+// requestScheme은 요청의 스킴을 반환합니다.
+func requestScheme(req *http.Request) string {
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		proto, _, _ = strings.Cut(proto, ",")
+		return strings.ToLower(strings.TrimSpace(proto))
+	}
+	if req.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// issueCSRFToken은 새 CSRF 토큰 쿠키를 설정합니다. 스크립트가 읽을 수 있도록 HttpOnly를 지정하지 않습니다.
+func (h *RouteHandler) issueCSRFToken(c *gin.Context) {
+	token, err := auth.RandomToken()
+	if err != nil {
+		log.Printf("[CSRF] 토큰 생성 실패: %v", err)
+		return
+	}
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     h.csrfCookie(),
+		Value:    token,
+		Path:     "/",
+		Secure:   requestScheme(c.Request) == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// csrfCookie는 CSRF 토큰 쿠키 이름을 반환합니다.
+func (h *RouteHandler) csrfCookie() string {
+	if h.config.CSRFCookie != "" {
+		return h.config.CSRFCookie
+	}
+	return config.DefaultCSRFCookie
+}
+
+// csrfHeader는 CSRF 토큰 요청 헤더 이름을 반환합니다.
+func (h *RouteHandler) csrfHeader() string {
+	if h.config.CSRFHeader != "" {
+		return h.config.CSRFHeader
+	}
+	return config.DefaultCSRFHeader
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/proxy"
+)
+
+// identityPropagator는 인증된 사용자 정보를 업스트림에 전달할 헤더를 구성합니다.
+type identityPropagator struct {
+	forward        bool                    // 사용자 정보 헤더 전달 여부
+	headers        []config.IdentityHeader // 헤더와 값을 가져올 클레임
+	internalTokens *auth.InternalTokenIssuer
+	internalHeader string
+	strip          []string // 클라이언트가 보낸 값을 항상 제거할 헤더
+}
+
+// newIdentityPropagator는 설정으로 사용자 정보 전달기를 생성합니다.
+// 내부 JWT 발급기를 만들 수 없으면 내부 JWT 없이 사용자 정보 헤더만 전달합니다.
+func newIdentityPropagator(cfg *config.Config) *identityPropagator {
+	p := &identityPropagator{
+		forward:        cfg.ForwardIdentity,
+		headers:        cfg.IdentityHeaders,
+		internalHeader: cfg.InternalJWTHeader,
+	}
+	if p.internalHeader == "" {
+		p.internalHeader = config.DefaultInternalJWTHeader
+	}
+
+	if cfg.InternalJWTSecret != "" {
+		issuer, err := auth.NewInternalTokenIssuer(cfg.InternalJWTSecret, cfg.InternalJWTIssuer, cfg.InternalJWTAudience, cfg.InternalJWTTTL)
+		if err != nil {
+			log.Printf("[IDENTITY] 내부 JWT를 발급하지 않습니다: %v", err)
+		} else {
+			p.internalTokens = issuer
+		}
+	}
+
+	// 설정한 헤더뿐 아니라 기본 헤더와 내부 JWT 헤더도 항상 제거 (설정 변경 후에도 위조 방지)
+	p.strip = append(p.strip, proxy.DefaultIdentityHeaders...)
+	p.strip = append(p.strip, p.internalHeader)
+	for _, header := range p.headers {
+		p.strip = append(p.strip, header.Header)
+	}
+	return p
+}
+
+// identityMiddleware는 업스트림에 전달할 사용자 정보를 요청 컨텍스트에 저장하는 핸들러를 반환합니다.
+// 인증하지 않는 라우트도 클라이언트가 보낸 사용자 정보 헤더를 제거하도록 모든 라우트에 사용합니다.
+func (h *RouteHandler) identityMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get(claimsContextKey)
+		claims, _ := value.(*auth.Claims)
+
+		identity, err := h.identity.identityFor(claims)
+		if err != nil {
+			log.Printf("[IDENTITY] 내부 JWT 발급 실패: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "사용자 정보를 전달할 수 없습니다"})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(proxy.WithIdentity(c.Request.Context(), identity))
+		c.Next()
+	}
+}
+
+// identityFor는 클레임으로 업스트림에 전달할 사용자 정보를 구성합니다. claims가 nil이면 제거할 헤더만 포함합니다.
+func (p *identityPropagator) identityFor(claims *auth.Claims) (*proxy.Identity, error) {
+	identity := &proxy.Identity{Strip: p.strip, Header: http.Header{}}
+	if claims == nil {
+		return identity, nil
+	}
+
+	if p.forward {
+		for _, header := range p.headers {
+			value := identityValue(claims, header.Claim)
+			if value == "" {
+				continue
+			}
+			if strings.ContainsAny(value, "\r\n\x00") {
+				log.Printf("[IDENTITY] 헤더에 사용할 수 없는 클레임 값입니다: %s", header.Claim)
+				continue
+			}
+			identity.Header.Set(header.Header, value)
+		}
+	}
+
+	if p.internalTokens != nil {
+		token, err := p.internalTokens.Issue(claims)
+		if err != nil {
+			return nil, err
+		}
+		identity.Header.Set(p.internalHeader, token)
+	}
+	return identity, nil
+}
+
+// identityValue는 헤더로 전달할 클레임 값을 반환합니다.
+// 스코프는 공백으로, 그 외 배열 클레임은 쉼표로 구분합니다.
+func identityValue(claims *auth.Claims, claim string) string {
+	switch claim {
+	case "scope", "scp":
+		return strings.Join(claims.Scopes(), " ")
+	case "sub":
+		if claims.Raw == nil {
+			return claims.Subject
+		}
+	case "roles":
+		if claims.Raw == nil {
+			return strings.Join(claims.Roles, ",")
+		}
+	}
+
+	value, found := claims.Lookup(claim)
+	if !found {
+		return ""
+	}
+	return strings.Join(claimStrings(value), ",")
+}
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+)
+
+// 브라우저 로그인 설정
+const (
+	loginStateCookie   = "gw_oidc_state"  // 로그인 요청의 state, nonce, PKCE 코드 검증자를 저장하는 쿠키
+	loginStateTTL      = 10 * time.Minute // 로그인을 완료해야 하는 시간
+	sessionRefreshSkew = 30 * time.Second // 액세스 토큰 만료 전에 미리 갱신하는 시간
+	refreshResultGrace = 30 * time.Second // 갱신 결과를 재사용하는 시간 (새 쿠키를 받기 전의 동시 요청용)
+	defaultSessionTTL  = 8 * time.Hour    // OIDC_SESSION_TTL이 없을 때 세션 최대 유지 시간
+	maxCookieBytes     = 4096
+)
+
+// loginState는 로그인 시작부터 콜백까지 유지하는 로그인 요청 정보입니다.
+type loginState struct {
+	State     string    `json:"state"`
+	Nonce     string    `json:"nonce"`
+	Verifier  string    `json:"verifier"`
+	Redirect  string    `json:"redirect"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// sessionRefresh는 진행 중이거나 완료된 세션 갱신입니다.
+type sessionRefresh struct {
+	done    chan struct{}
+	session *auth.Session
+	err     error
+}
+
+// SetOIDCLogin은 브라우저 로그인(/auth/login, /auth/callback, /auth/logout)에 사용할 IdP와 세션 쿠키 코덱을 설정합니다.
+// 로그인한 브라우저의 요청은 세션의 액세스 토큰으로 인증되므로 requireAuth 라우트를 그대로 사용할 수 있습니다.
+// RegisterRoutes 전에 호출해야 합니다.
+func (h *RouteHandler) SetOIDCLogin(provider *auth.OIDCProvider, sessions *auth.SessionCodec) {
+	h.oidc = provider
+	h.sessions = sessions
+}
+
+// registerLoginRoutes는 브라우저 로그인 엔드포인트를 등록합니다.
+func (h *RouteHandler) registerLoginRoutes(router *gin.Engine) {
+	if h.oidc == nil {
+		return
+	}
+
+	router.GET("/auth/login", h.LoginHandler)
+	router.GET("/auth/callback", h.CallbackHandler)
+	router.GET("/auth/logout", h.LogoutHandler)
+	router.POST("/auth/logout", h.LogoutHandler)
+}
+
+// LoginHandler는 인가 코드 + PKCE 흐름으로 로그인하도록 브라우저를 IdP로 보냅니다.
+// redirect 쿼리 파라미터로 로그인 후 돌아올 경로를 지정합니다.
+func (h *RouteHandler) LoginHandler(c *gin.Context) {
+	state := loginState{
+		Redirect:  safeRedirect(c.Query("redirect")),
+		ExpiresAt: time.Now().Add(loginStateTTL),
+	}
+	for _, value := range []*string{&state.State, &state.Nonce, &state.Verifier} {
+		token, err := auth.RandomToken()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		*value = token
+	}
+
+	authURL, err := h.oidc.AuthCodeURL(c.Request.Context(), state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		log.Printf("[AUTH] 로그인 시작 실패: %v", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "로그인을 시작할 수 없습니다"})
+		return
+	}
+
+	encoded, err := h.sessions.Encode(loginStateCookie, state)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.setCookie(c, loginStateCookie, encoded, "/auth", int(loginStateTTL/time.Second))
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// CallbackHandler는 IdP가 돌려준 인가 코드를 PKCE 코드 검증자와 함께 토큰으로 교환하고 세션 쿠키를 설정합니다.
+func (h *RouteHandler) CallbackHandler(c *gin.Context) {
+	if idpError := c.Query("error"); idpError != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("로그인 실패: %s %s", idpError, c.Query("error_description"))})
+		return
+	}
+
+	// state 확인 (로그인을 시작한 브라우저인지 확인하여 로그인 CSRF 방지)
+	var state loginState
+	encoded, err := c.Cookie(loginStateCookie)
+	if err == nil {
+		err = h.sessions.Decode(loginStateCookie, encoded, &state)
+	}
+	h.setCookie(c, loginStateCookie, "", "/auth", -1)
+	if err != nil || state.State == "" || time.Now().After(state.ExpiresAt) ||
+		subtle.ConstantTimeCompare([]byte(state.State), []byte(c.Query("state"))) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "로그인 요청이 유효하지 않습니다. 다시 로그인하세요"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tokens, err := h.oidc.Exchange(ctx, c.Query("code"), state.Verifier)
+	if err != nil {
+		log.Printf("[AUTH] 인가 코드 교환 실패: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인 실패: 토큰을 발급받을 수 없습니다"})
+		return
+	}
+
+	// ID 토큰의 nonce 확인 (다른 로그인 요청의 토큰 재사용 방지)
+	if tokens.IDToken != "" {
+		claims, err := h.authenticator.VerifyToken(tokens.IDToken)
+		if err != nil {
+			log.Printf("[AUTH] ID 토큰 검증 실패: %v", err)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인 실패: ID 토큰을 검증할 수 없습니다"})
+			return
+		}
+		if nonce, _ := claims.Lookup("nonce"); nonce != state.Nonce {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "로그인 실패: nonce가 일치하지 않습니다"})
+			return
+		}
+	}
+
+	session := auth.Session{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+		EndsAt:       time.Now().Add(h.sessionTTL()),
+	}
+	if err := h.setSessionCookie(c, &session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Redirect(http.StatusFound, state.Redirect)
+}
+
+// LogoutHandler는 세션을 종료합니다.
+// 세션 쿠키를 삭제하고 액세스 토큰을 폐기 목록에 추가하며, IdP가 지원하면 갱신 토큰 폐기와 IdP 로그아웃도 수행합니다.
+func (h *RouteHandler) LogoutHandler(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if session, ok := h.readSession(c); ok {
+		if claims, err := h.authenticator.VerifyToken(session.AccessToken); err == nil {
+			expiresAt := time.Now().Add(h.revocationTTL())
+			if claims.ExpiresAt != nil {
+				expiresAt = claims.ExpiresAt.Time
+			}
+			if err := h.denylist.RevokeToken(ctx, auth.TokenID(claims, session.AccessToken), expiresAt); err != nil {
+				log.Printf("[AUTH] 로그아웃 토큰 폐기 실패: %v", err)
+			}
+		}
+		if session.RefreshToken != "" {
+			if err := h.oidc.Revoke(ctx, session.RefreshToken, "refresh_token"); err != nil {
+				log.Printf("[AUTH] 갱신 토큰 폐기 실패: %v", err)
+			}
+		}
+	}
+	h.setCookie(c, h.sessionCookie(), "", "/", -1)
+
+	target := h.config.OIDCPostLogoutRedirectURL
+	if target == "" {
+		target = "/"
+	}
+	postLogout := ""
+	if strings.HasPrefix(target, "https://") || strings.HasPrefix(target, "http://") {
+		postLogout = target
+	}
+	if endSession, ok := h.oidc.EndSessionURL(ctx, postLogout); ok {
+		target = endSession
+	}
+	c.Redirect(http.StatusFound, target)
+}
+
+// applySession은 Authorization 헤더가 없는 요청에 세션 쿠키의 액세스 토큰을 Authorization 헤더로 설정하고, 설정했으면 true를 반환합니다.
+// 액세스 토큰이 곧 만료되면 갱신 토큰으로 갱신하고 세션 쿠키를 교체합니다. 세션 쿠키는 업스트림에 전달하지 않습니다.
+func (h *RouteHandler) applySession(c *gin.Context) bool {
+	if h.sessions == nil {
+		return false
+	}
+	session, ok := h.readSession(c)
+	removeCookie(c.Request, h.sessionCookie())
+	if !ok || c.GetHeader("Authorization") != "" {
+		return false
+	}
+
+	now := time.Now()
+	if session.RefreshToken != "" && !session.ExpiresAt.IsZero() && session.ExpiresAt.Before(now.Add(sessionRefreshSkew)) {
+		refreshed, err := h.refreshSession(c.Request.Context(), session)
+		switch {
+		case err == nil:
+			session = refreshed
+			if err := h.setSessionCookie(c, session); err != nil {
+				log.Printf("[AUTH] 세션 쿠키 설정 실패: %v", err)
+			}
+		case session.ExpiresAt.After(now):
+			// 갱신에 실패해도 아직 만료되지 않은 토큰은 사용
+			log.Printf("[AUTH] 세션 갱신 실패: %v", err)
+		default:
+			log.Printf("[AUTH] 세션 갱신 실패, 세션 종료: %v", err)
+			h.setCookie(c, h.sessionCookie(), "", "/", -1)
+			return false
+		}
+	}
+
+	c.Request.Header.Set("Authorization", "Bearer "+session.AccessToken)
+	return true
+}
+
+// readSession은 세션 쿠키를 읽습니다. 쿠키가 없거나, 올바르지 않거나, 세션이 만료되었으면 false를 반환합니다.
+func (h *RouteHandler) readSession(c *gin.Context) (*auth.Session, bool) {
+	if h.sessions == nil {
+		return nil, false
+	}
+	encoded, err := c.Cookie(h.sessionCookie())
+	if err != nil || encoded == "" {
+		return nil, false
+	}
+
+	var session auth.Session
+	if err := h.sessions.Decode(h.sessionCookie(), encoded, &session); err != nil || !session.EndsAt.After(time.Now()) {
+		h.setCookie(c, h.sessionCookie(), "", "/", -1)
+		return nil, false
+	}
+	return &session, true
+}
+
+// refreshSession은 갱신 토큰으로 세션의 액세스 토큰을 갱신합니다.
+// 같은 갱신 토큰의 동시 요청은 한 번만 갱신하며, 브라우저가 새 쿠키를 받기 전의 요청을 위해 결과를 잠시 재사용합니다
+// (IdP가 갱신 토큰을 교체하면 이전 갱신 토큰으로는 다시 갱신할 수 없으므로).
+func (h *RouteHandler) refreshSession(ctx context.Context, session *auth.Session) (*auth.Session, error) {
+	flight := &sessionRefresh{done: make(chan struct{})}
+	if existing, loaded := h.sessionRefreshes.LoadOrStore(session.RefreshToken, flight); loaded {
+		other := existing.(*sessionRefresh)
+		<-other.done
+		return other.session, other.err
+	}
+
+	// 요청이 취소되어도 기다리는 다른 요청을 위해 갱신을 완료
+	tokens, err := h.oidc.Refresh(context.WithoutCancel(ctx), session.RefreshToken)
+	if err != nil {
+		flight.err = err
+		h.sessionRefreshes.Delete(session.RefreshToken)
+	} else {
+		flight.session = &auth.Session{
+			AccessToken:  tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresAt:    tokens.ExpiresAt,
+			EndsAt:       session.EndsAt,
+		}
+		time.AfterFunc(refreshResultGrace, func() { h.sessionRefreshes.Delete(session.RefreshToken) })
+	}
+	close(flight.done)
+	return flight.session, flight.err
+}
+
+// setSessionCookie는 세션을 암호화하여 세션 쿠키로 설정합니다. 쿠키는 세션 만료 시각까지 유지됩니다.
+func (h *RouteHandler) setSessionCookie(c *gin.Context, session *auth.Session) error {
+	encoded, err := h.sessions.Encode(h.sessionCookie(), session)
+	if err != nil {
+		return err
+	}
+	if len(encoded) > maxCookieBytes {
+		log.Printf("[AUTH] 세션 쿠키가 %d바이트로 브라우저 제한을 넘을 수 있습니다", len(encoded))
+	}
+	h.setCookie(c, h.sessionCookie(), encoded, "/", int(time.Until(session.EndsAt)/time.Second))
+	return nil
+}
+
+// setCookie는 HttpOnly, SameSite=Lax 쿠키를 설정합니다. maxAge가 음수이면 쿠키를 삭제합니다.
+// IdP에서 돌아오는 최상위 이동에도 쿠키가 전달되도록 Strict 대신 Lax를 사용합니다.
+func (h *RouteHandler) setCookie(c *gin.Context, name, value, path string, maxAge int) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		MaxAge:   maxAge,
+		Secure:   h.config.OIDCCookieSecure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// sessionCookie는 세션 쿠키 이름을 반환합니다.
+func (h *RouteHandler) sessionCookie() string {
+	if h.config.OIDCSessionCookie != "" {
+		return h.config.OIDCSessionCookie
+	}
+	return config.DefaultSessionCookie
+}
+
+// sessionTTL은 세션 최대 유지 시간을 반환합니다.
+func (h *RouteHandler) sessionTTL() time.Duration {
+	if h.config.OIDCSessionTTL > 0 {
+		return h.config.OIDCSessionTTL
+	}
+	return defaultSessionTTL
+}
+
+// safeRedirect는 로그인 후 이동할 경로를 반환합니다. 다른 사이트로 이동하는 열린 리디렉션을 막기 위해 같은 사이트의 경로만 허용합니다.
+// 브라우저는 URL의 탭과 줄바꿈을 제거하고 \를 /로 해석하므로("/\t/evil.com" → "//evil.com") 제어 문자나 \가 있는 경로도 거부합니다.
+func safeRedirect(target string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") {
+		return "/"
+	}
+	for _, r := range target {
+		if r < 0x20 || r == 0x7f || r == '\\' {
+			return "/"
+		}
+	}
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Scheme != "" || parsed.Host != "" {
+		return "/"
+	}
+	return target
+}
+
+// removeCookie는 요청의 Cookie 헤더에서 쿠키 하나를 제거합니다.
+func removeCookie(req *http.Request, name string) {
+	cookies := req.Cookies()
+	kept := make([]string, 0, len(cookies))
+	for _, cookie := range cookies {
+		if cookie.Name != name {
+			kept = append(kept, cookie.String())
+		}
+	}
+	if len(kept) == len(cookies) {
+		return
+	}
+
+	req.Header.Del("Cookie")
+	if len(kept) > 0 {
+		req.Header.Set("Cookie", strings.Join(kept, "; "))
+	}
+}
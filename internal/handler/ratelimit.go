@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/middleware"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
+)
+
+// SetRateLimitStore는 라우트별 속도 제한 정책이 사용할 저장소와 장애 처리 방식을 설정합니다.
+// 전역 속도 제한기와 같은 저장소를 사용하면 여러 인스턴스가 라우트별 한도도 공유합니다.
+// RegisterRoutes 전에 호출해야 합니다.
+func (h *RouteHandler) SetRateLimitStore(store ratelimiter.Store, failureMode ratelimiter.FailureMode) {
+	if h.ownsRateLimitStore {
+		h.rateLimitStore.Close()
+		h.ownsRateLimitStore = false
+	}
+	h.rateLimitStore = store
+	h.rateLimitFailureMode = failureMode
+}
+
+// configureRateLimits는 라우트의 속도 제한 정책을 구성합니다.
+func (t *routeTable) configureRateLimits(h *RouteHandler, rt *routeRuntime) {
+	rt.rateLimits = nil
+	for i, policy := range rt.route.RateLimits {
+		// 이름 없는 정책은 라우트마다 독립된 한도를 가짐
+		name := policy.Name
+		if name == "" {
+			name = fmt.Sprintf("route=%s %s#%d", strings.Join(rt.route.Methods, ","), rt.route.Path, i)
+		}
+
+		burst := policy.Burst
+		if burst == 0 {
+			burst = policy.Limit
+		}
+
+		compiled := middleware.RateLimitPolicy{
+			Name:    name,
+			Key:     middleware.RateLimitKey(policy.Key, policy.Header),
+			Limiter: h.newPolicyLimiter(policy, policy.Limit, burst),
+		}
+		for _, tier := range policy.Tiers {
+			limit, tierBurst := tier.Limit, tier.Burst
+			if limit == 0 {
+				limit = scaleLimit(policy.Limit, tier.Multiplier)
+			}
+			if tierBurst == 0 {
+				tierBurst = limit
+				if tier.Multiplier > 0 {
+					tierBurst = scaleLimit(burst, tier.Multiplier)
+				}
+			}
+			compiled.Tiers = append(compiled.Tiers, middleware.RateLimitTier{
+				Role:    tier.Role,
+				Limiter: h.newPolicyLimiter(policy, limit, tierBurst),
+			})
+		}
+
+		rt.rateLimits = append(rt.rateLimits, compiled)
+	}
+}
+
+// newPolicyLimiter는 정책의 알고리즘으로 공유 저장소를 사용하는 속도 제한기를 생성합니다.
+func (h *RouteHandler) newPolicyLimiter(policy config.RateLimitPolicy, limit, burst int) ratelimiter.RateLimiter {
+	window := time.Duration(policy.Window) * time.Second
+	if policy.Algorithm == config.RateLimitAlgorithmSlidingWindow {
+		return ratelimiter.NewSlidingWindowWithStore(h.rateLimitStore, window, limit, h.rateLimitFailureMode)
+	}
+
+	limiter := ratelimiter.NewWithStore(h.rateLimitStore, window, limit, h.rateLimitFailureMode)
+	limiter.SetBurst(burst)
+	return limiter
+}
+
+// scaleLimit은 한도에 배수를 곱합니다 (최소 1).
+func scaleLimit(limit int, multiplier float64) int {
+	scaled := int(math.Round(float64(limit) * multiplier))
+	if scaled < 1 {
+		return 1
+	}
+	return scaled
+}
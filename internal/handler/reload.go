@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+)
+
+// RouterBuilder는 라우트 구성으로 라우트 테이블이 등록된 새 gin.Engine을 생성하는 함수입니다.
+type RouterBuilder func(routes *config.RoutesConfig) (*gin.Engine, error)
+
+// RouteReloader는 라우트 테이블을 무중단으로 교체하는 http.Handler입니다.
+// 새 라우트 테이블은 별도로 구성된 뒤 원자적으로 교체되며,
+// 이미 처리 중인 요청과 WebSocket 릴레이는 기존 테이블에서 끝까지 처리됩니다.
+type RouteReloader struct {
+	build    RouterBuilder
+	path     string
+	current  atomic.Pointer[gin.Engine]
+	mu       sync.Mutex // 동시 리로드 방지
+	checksum [sha256.Size]byte
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRouteReloader는 초기 라우트 테이블을 구성하고 새 RouteReloader를 생성합니다.
+func NewRouteReloader(routesPath string, build RouterBuilder) (*RouteReloader, error) {
+	r := &RouteReloader{
+		build: build,
+		path:  routesPath,
+		quit:  make(chan struct{}),
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ServeHTTP는 현재 라우트 테이블로 요청을 전달합니다.
+func (r *RouteReloader) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.current.Load().ServeHTTP(w, req)
+}
+
+// Engine은 현재 활성화된 gin.Engine을 반환합니다.
+func (r *RouteReloader) Engine() *gin.Engine {
+	return r.current.Load()
+}
+
+// Reload는 라우트 구성 파일을 다시 읽어 새 라우트 테이블로 교체합니다.
+// 구성이 유효하지 않으면 오류를 반환하고 기존 라우트 테이블을 유지합니다.
+func (r *RouteReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 파일을 한 번만 읽어 체크섬과 라우트 구성에 같은 내용을 사용 (읽는 사이에 파일이 바뀌어도 다음 변경을 놓치지 않음)
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return fmt.Errorf("라우트 구성 파일 읽기 실패: %v", err)
+	}
+	// 변경 감지를 위해 체크섬 기록 (실패한 구성도 반복 시도하지 않음)
+	r.checksum = sha256.Sum256(data)
+
+	routes, err := config.ParseRoutesConfig(data)
+	if err != nil {
+		return err
+	}
+
+	engine, err := r.safeBuild(routes)
+	if err != nil {
+		return err
+	}
+
+	old := r.current.Swap(engine)
+	if old != nil {
+		log.Printf("[RELOAD] 라우트 테이블 교체 완료: %s", r.path)
+	}
+
+	return nil
+}
+
+// Watch는 라우트 구성 파일을 주기적으로 확인하여 변경 시 자동으로 리로드합니다.
+func (r *RouteReloader) Watch(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if !r.changed() {
+					continue
+				}
+
+				log.Printf("[RELOAD] 라우트 구성 파일 변경 감지: %s", r.path)
+				if err := r.Reload(); err != nil {
+					log.Printf("[RELOAD] 라우트 리로드 실패, 기존 라우트를 유지합니다: %v", err)
+				}
+			case <-r.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop은 파일 감시를 중지합니다.
+func (r *RouteReloader) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.quit)
+	})
+}
+
+// changed는 마지막 리로드 이후 구성 파일 내용이 변경되었는지 확인합니다.
+func (r *RouteReloader) changed() bool {
+	sum, err := fileChecksum(r.path)
+	if err != nil {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return !bytes.Equal(sum[:], r.checksum[:])
+}
+
+// safeBuild는 라우트 테이블을 구성하며, 라우트 등록 중 발생한 패닉을 오류로 변환합니다.
+func (r *RouteReloader) safeBuild(routes *config.RoutesConfig) (engine *gin.Engine, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			engine = nil
+			err = fmt.Errorf("라우트 테이블 구성 실패: %v", rec)
+		}
+	}()
+
+	engine, err = r.build(routes)
+	if err != nil {
+		return nil, err
+	}
+	if engine == nil {
+		return nil, errors.New("라우트 테이블 구성 실패: 빈 라우터")
+	}
+
+	return engine, nil
+}
+
+// fileChecksum은 파일 내용의 SHA-256 체크섬을 계산합니다.
+func fileChecksum(path string) ([sha256.Size]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+)
+
+// retryCountHeader는 업스트림 요청 재시도 횟수를 알리는 응답 헤더입니다.
+const retryCountHeader = "X-Retry-Count"
+
+// 재시도 정책 기본값
+const (
+	defaultRetryAttempts    = 3
+	defaultRetryBackoffBase = 50 * time.Millisecond
+	defaultRetryBackoffMax  = time.Second
+	defaultRetryMaxBodySize = 64 * 1024
+)
+
+var (
+	defaultRetryStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+	defaultRetryErrors      = []string{circuitbreaker.ErrorTypeConnection, circuitbreaker.ErrorTypeTimeout}
+)
+
+// retryPolicy는 라우트에 적용할 재시도 정책입니다.
+type retryPolicy struct {
+	attempts      int
+	statusCodes   []int
+	errors        []string
+	backoffBase   time.Duration
+	backoffMax    time.Duration
+	perTryTimeout time.Duration
+	nonIdempotent bool
+	maxBodySize   int64
+}
+
+// newRetryPolicy는 라우트 설정으로 재시도 정책을 생성합니다. 설정이 없으면 nil을 반환합니다.
+func newRetryPolicy(cfg *config.RetryConfig) *retryPolicy {
+	if cfg == nil {
+		return nil
+	}
+
+	p := &retryPolicy{
+		attempts:      cfg.Attempts,
+		statusCodes:   cfg.StatusCodes,
+		errors:        cfg.Errors,
+		backoffBase:   time.Duration(cfg.BackoffBase) * time.Millisecond,
+		backoffMax:    time.Duration(cfg.BackoffMax) * time.Millisecond,
+		perTryTimeout: time.Duration(cfg.PerTryTimeout) * time.Millisecond,
+		nonIdempotent: cfg.NonIdempotent,
+		maxBodySize:   cfg.MaxBodySize,
+	}
+
+	if p.attempts <= 0 {
+		p.attempts = defaultRetryAttempts
+	}
+	if p.statusCodes == nil {
+		p.statusCodes = defaultRetryStatusCodes
+	}
+	if p.errors == nil {
+		p.errors = defaultRetryErrors
+	}
+	if p.backoffBase <= 0 {
+		p.backoffBase = defaultRetryBackoffBase
+	}
+	if p.backoffMax <= 0 {
+		p.backoffMax = defaultRetryBackoffMax
+	}
+	if p.backoffMax < p.backoffBase {
+		p.backoffMax = p.backoffBase
+	}
+	if p.maxBodySize <= 0 {
+		p.maxBodySize = defaultRetryMaxBodySize
+	}
+
+	return p
+}
+
+// maxAttempts는 요청에 허용되는 최대 시도 횟수를 반환합니다.
+// 멱등하지 않은 메서드는 라우트가 허용한 경우에만 재시도합니다.
+func (p *retryPolicy) maxAttempts(method string) int {
+	if p == nil || (!p.nonIdempotent && !isIdempotentMethod(method)) {
+		return 1
+	}
+	return p.attempts
+}
+
+// retryReason은 결과가 재시도 대상이면 메트릭에 기록할 사유를, 아니면 빈 문자열을 반환합니다.
+func (p *retryPolicy) retryReason(resp *http.Response, err error, targetScoped bool) string {
+	if err != nil {
+		// 차단된 요청은 대상 서버별 서킷 브레이커인 경우에만 다른 대상으로 재시도
+		if errors.Is(err, circuitbreaker.ErrCircuitOpen) || errors.Is(err, circuitbreaker.ErrTooManyRequests) {
+			if targetScoped {
+				return "circuit_open"
+			}
+			return ""
+		}
+
+		errorType := circuitbreaker.ErrorType(err)
+		for _, t := range p.errors {
+			if t == errorType {
+				return errorType
+			}
+		}
+		return ""
+	}
+
+	if resp != nil {
+		for _, code := range p.statusCodes {
+			if resp.StatusCode == code {
+				return strconv.Itoa(code)
+			}
+		}
+	}
+	return ""
+}
+
+// backoff는 retry번째 재시도 전 대기 시간을 반환합니다.
+// 지수적으로 증가하는 대기 시간의 절반에 무작위 지터를 더합니다.
+func (p *retryPolicy) backoff(retry int) time.Duration {
+	d := p.backoffBase
+	for i := 1; i < retry && d < p.backoffMax; i++ {
+		d *= 2
+	}
+	if d > p.backoffMax {
+		d = p.backoffMax
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// bufferRequestBody는 재전송을 위해 요청 본문을 limit 바이트까지 메모리에 읽어 둡니다.
+// 본문이 limit를 넘으면 읽은 부분과 나머지를 이어 붙여 원래 본문을 복원하고 false를 반환합니다.
+func bufferRequestBody(req *http.Request, limit int64) ([]byte, bool, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, true, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, limit+1))
+	if err != nil {
+		return nil, false, err
+	}
+
+	if int64(len(body)) > limit {
+		req.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+		return nil, false, nil
+	}
+
+	req.Body.Close()
+	return body, true, nil
+}
+
+// resolveRetryTarget은 이전 시도에서 사용하지 않은 대상 서버를 우선하여 선택합니다.
+// 모든 대상 서버를 이미 시도한 경우 로드 밸런서가 선택한 서버를 그대로 사용합니다.
+func (rt *routeRuntime) resolveRetryTarget(tried map[string]bool) (string, string, error) {
+	targetURL, backend, err := rt.resolveTarget()
+	if err != nil || backend == "" {
+		return targetURL, backend, err
+	}
+
+	for i := 1; tried[backend] && i < len(rt.balancer.GetTargets()); i++ {
+		rt.release(backend)
+		if targetURL, backend, err = rt.resolveTarget(); err != nil {
+			return "", "", err
+		}
+	}
+	return targetURL, backend, nil
+}
+
+// forwardAttempt는 업스트림 요청을 한 번 시도합니다. 시도별 타임아웃이 있으면 응답 본문을 닫을 때 타임아웃 컨텍스트를 해제합니다.
+func (h *RouteHandler) forwardAttempt(ctx context.Context, req *http.Request, rt *routeRuntime, targetPath string, stripPath bool, stripPrefix string) (*http.Response, error) {
+	if rt.retry == nil || rt.retry.perTryTimeout <= 0 {
+		return h.transportFor(rt).ForwardRequest(ctx, req, targetPath, stripPath, stripPrefix)
+	}
+
+	tryCtx, cancel := context.WithTimeout(ctx, rt.retry.perTryTimeout)
+	resp, err := h.transportFor(rt).ForwardRequest(tryCtx, req, targetPath, stripPath, stripPrefix)
+	if err != nil || resp.Body == nil {
+		cancel()
+		return resp, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose는 닫을 때 시도별 타임아웃 컨텍스트를 해제하는 응답 본문입니다.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close는 응답 본문을 닫고 타임아웃 컨텍스트를 해제합니다.
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// drainResponse는 재시도로 버려지는 응답 본문을 닫습니다.
+func drainResponse(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+	}
+}
+
+// isIdempotentMethod는 재시도해도 안전한 HTTP 메서드인지 확인합니다.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
+)
+
+// defaultRevocationTTL은 REVOCATION_DEFAULT_TTL이 없을 때 만료 시각을 알 수 없는 폐기 항목의 유지 시간입니다.
+const defaultRevocationTTL = 24 * time.Hour
+
+// SetRevocationStore는 토큰 폐기 목록 저장소와 장애 처리 방식을 설정합니다.
+// 공유 저장소(redis)를 사용하면 한 인스턴스에서 폐기한 토큰을 모든 인스턴스가 거부합니다.
+// 설정하지 않으면 메모리 저장소를 사용합니다. 저장소는 호출자가 닫아야 합니다.
+func (h *RouteHandler) SetRevocationStore(store auth.RevocationStore, failureMode ratelimiter.FailureMode) {
+	h.denylist = auth.NewDenylist(store)
+	h.revocationFailureMode = failureMode
+}
+
+// checkRevocation은 토큰이 폐기되었는지 확인합니다. 폐기된 토큰이면 응답을 기록하고 false를 반환합니다.
+// 저장소 장애 시 open이면 요청을 허용하고, closed이면 503으로 거부합니다.
+func (h *RouteHandler) checkRevocation(c *gin.Context, claims *auth.Claims, token string) bool {
+	revoked, err := h.denylist.IsRevoked(c.Request.Context(), claims, token)
+	if err != nil {
+		log.Printf("[AUTH] 폐기 목록 확인 실패: %v", err)
+		if h.revocationFailureMode == ratelimiter.FailClosed {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "인증 상태를 확인할 수 없습니다"})
+			c.Abort()
+			return false
+		}
+		return true
+	}
+
+	if revoked {
+		log.Printf("[AUTH] 폐기된 토큰 - 사용자 ID: %s, 토큰 ID: %s", claims.Subject, claims.ID)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "인증 실패: 폐기된 토큰입니다"})
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// revocationRequest는 토큰 폐기 요청입니다. token, jti, subject 중 하나를 지정합니다.
+type revocationRequest struct {
+	Token     string     `json:"token"`     // 폐기할 토큰 원문 (만료 시각은 토큰의 exp)
+	JTI       string     `json:"jti"`       // 폐기할 토큰 ID
+	Subject   string     `json:"subject"`   // 모든 토큰을 폐기할 사용자 ID
+	ExpiresAt *time.Time `json:"expiresAt"` // 폐기 항목 만료 시각 (jti, subject에 사용, 생략 시 기본 유지 시간)
+}
+
+// RevokeHandler는 토큰 하나(token, jti) 또는 사용자의 모든 토큰(subject)을 폐기합니다.
+// 폐기 항목은 토큰이 만료되는 시각에 자동으로 삭제됩니다.
+func (h *RouteHandler) RevokeHandler(c *gin.Context) {
+	var req revocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 요청 본문입니다: " + err.Error()})
+		return
+	}
+
+	targets := 0
+	for _, value := range []string{req.Token, req.JTI, req.Subject} {
+		if value != "" {
+			targets++
+		}
+	}
+	if targets != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "token, jti, subject 중 하나를 지정해야 합니다"})
+		return
+	}
+
+	expiresAt := time.Now().Add(h.revocationTTL())
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expiresAt은 현재 시각 이후여야 합니다"})
+			return
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	ctx := c.Request.Context()
+	revocation := gin.H{}
+	var err error
+	switch {
+	case req.Subject != "":
+		var revokedAt time.Time
+		revokedAt, err = h.denylist.RevokeSubject(ctx, req.Subject, expiresAt)
+		revocation["subject"] = req.Subject
+		revocation["revokedAt"] = revokedAt.Format(time.RFC3339)
+
+	default:
+		tokenID := req.JTI
+		if req.Token != "" {
+			claims, verifyErr := h.authenticator.VerifyToken(req.Token)
+			if verifyErr != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "유효하지 않은 토큰입니다: " + verifyErr.Error()})
+				return
+			}
+			tokenID = auth.TokenID(claims, req.Token)
+			if claims.ExpiresAt != nil {
+				expiresAt = claims.ExpiresAt.Time
+			}
+			revocation["subject"] = claims.Subject
+		}
+		err = h.denylist.RevokeToken(ctx, tokenID, expiresAt)
+		revocation["jti"] = tokenID
+	}
+	if err != nil {
+		log.Printf("[AUTH] 토큰 폐기 실패: %v", err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "토큰 폐기 실패: " + err.Error()})
+		return
+	}
+
+	revocation["expiresAt"] = expiresAt.Format(time.RFC3339)
+	log.Printf("[AUTH] 토큰 폐기: %v", revocation)
+	c.JSON(http.StatusOK, gin.H{"revoked": revocation})
+}
+
+// revocationTTL은 만료 시각을 알 수 없는 폐기 항목의 유지 시간을 반환합니다.
+func (h *RouteHandler) revocationTTL() time.Duration {
+	if h.config.RevocationDefaultTTL > 0 {
+		return h.config.RevocationDefaultTTL
+	}
+	return defaultRevocationTTL
+}
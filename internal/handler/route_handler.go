@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -9,6 +11,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -16,26 +20,52 @@ import (
 
 	"github.com/isinthesky/api-gateway/internal/auth"
 	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/metrics"
+	"github.com/isinthesky/api-gateway/internal/middleware"
 	"github.com/isinthesky/api-gateway/internal/proxy"
 	"github.com/isinthesky/api-gateway/pkg/cache"
 	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+	"github.com/isinthesky/api-gateway/pkg/concurrency"
+	"github.com/isinthesky/api-gateway/pkg/healthcheck"
 	"github.com/isinthesky/api-gateway/pkg/loadbalancer"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
 )
 
 // RouteHandler는 API 라우트를 처리하는 핸들러입니다.
 type RouteHandler struct {
 	loadBalancer    loadbalancer.LoadBalancer
-	circuitBreaker  *circuitbreaker.CircuitBreaker
+	breakers        *circuitbreaker.Registry
 	cache           cache.CacheProvider
 	config          *config.Config
 	wsUpgrader      websocket.Upgrader
 	authenticator   auth.Authenticator
+	identity        *identityPropagator // 업스트림에 전달할 사용자 정보 헤더
+	apiKeys         *auth.APIKeyAuthenticator // API 키 인증기 (nil이면 API 키 인증 비활성화)
+	table           atomic.Pointer[routeTable] // 현재 활성화된 라우트 테이블
+	healthChecker   *healthcheck.Checker
+	metrics         *metrics.Collector // nil이면 메트릭을 기록하지 않음
+	transports      *proxy.TransportPool // 업스트림별 공유 연결 풀
+	concurrency     *concurrency.Registry // 라우트/업스트림별 동시성 제한기
+	revalidating    sync.Map              // 백그라운드 재검증 중인 캐시 키
+	cacheFlights    sync.Map              // 업스트림 요청이 진행 중인 캐시 미스 (키 -> *cacheFlight)
+	apiKeyLimits    sync.Map              // API 키별 속도 제한 정책 (키 ID와 한도 -> *middleware.RateLimitPolicy)
+
+	oidc             *auth.OIDCProvider // 브라우저 로그인 IdP (nil이면 비활성화)
+	sessions         *auth.SessionCodec // 세션 쿠키 코덱
+	sessionRefreshes sync.Map           // 진행 중이거나 최근 완료된 세션 갱신 (갱신 토큰 -> *sessionRefresh)
+
+	denylist              *auth.Denylist          // 폐기된 토큰 목록
+	revocationFailureMode ratelimiter.FailureMode // 폐기 목록 저장소 장애 시 처리 방식
+
+	rateLimitStore       ratelimiter.Store       // 라우트별 속도 제한 정책이 사용하는 저장소
+	rateLimitFailureMode ratelimiter.FailureMode // 저장소 장애 시 처리 방식
+	ownsRateLimitStore   bool                    // 핸들러가 생성한 저장소인지 여부 (Close에서 닫음)
 }
 
 // NewRouteHandler는 새로운 RouteHandler를 생성합니다.
 func NewRouteHandler(
 	lb loadbalancer.LoadBalancer,
-	cb *circuitbreaker.CircuitBreaker,
+	breakers *circuitbreaker.Registry,
 	cacheProvider cache.CacheProvider,
 	cfg *config.Config,
 ) *RouteHandler {
@@ -62,32 +92,77 @@ func NewRouteHandler(
 
 	return &RouteHandler{
 		loadBalancer:    lb,
-		circuitBreaker:  cb,
+		breakers:        breakers,
 		cache:           cacheProvider,
 		config:          cfg,
 		wsUpgrader:      wsUpgrader,
 		authenticator:   authenticator,
+		identity:        newIdentityPropagator(cfg),
+		healthChecker:   healthcheck.New(),
+		transports:      proxy.NewTransportPool(newTransportDefaults(cfg)),
+		concurrency:     concurrency.NewRegistry(),
+
+		rateLimitStore:       ratelimiter.NewMemoryStore(time.Minute),
+		rateLimitFailureMode: ratelimiter.FailOpen,
+		ownsRateLimitStore:   true,
+
+		denylist:              auth.NewDenylist(auth.NewMemoryRevocationStore()),
+		revocationFailureMode: ratelimiter.FailOpen,
 	}
 }
 
-// RegisterRoutes는 라우터에 모든 라우트를 등록합니다.
-func (h *RouteHandler) RegisterRoutes(router *gin.Engine) error {
-	// 헬스 체크 엔드포인트
-	router.GET("/health", h.HealthCheckHandler)
+// SetMetricsCollector는 재시도 등 핸들러 내부 이벤트를 기록할 메트릭 수집기를 설정합니다.
+func (h *RouteHandler) SetMetricsCollector(collector *metrics.Collector) {
+	h.metrics = collector
+	if collector != nil {
+		h.transports.SetObserver(collector.ObserveUpstreamConnection)
+		collector.WatchCache(h.cache.Stats)
+	}
+}
 
+// SetAuthenticator는 토큰 검증에 사용할 인증기를 설정합니다.
+// 기본 인증기는 JWT_SECRET_KEY로 HMAC 토큰만 검증합니다. RegisterRoutes 전에 호출해야 합니다.
+func (h *RouteHandler) SetAuthenticator(authenticator auth.Authenticator) {
+	h.authenticator = authenticator
+}
+
+// Close는 핸들러가 사용하는 백그라운드 작업을 중지합니다.
+func (h *RouteHandler) Close() {
+	h.healthChecker.Stop()
+	h.transports.Close()
+	if h.ownsRateLimitStore {
+		h.rateLimitStore.Close()
+	}
+}
 
-	// 라우트 설정 로드
-	routes, err := h.config.LoadRoutes()
+// RegisterRoutes는 라우트 구성 파일을 읽어 라우터에 모든 라우트를 등록합니다.
+func (h *RouteHandler) RegisterRoutes(router *gin.Engine) error {
+	routesConfig, err := h.config.LoadRoutesConfig()
 	if err != nil {
 		return err
 	}
+	return h.RegisterRoutesFrom(router, routesConfig)
+}
+
+// RegisterRoutesFrom은 이미 읽은 라우트 구성으로 라우터에 모든 라우트를 등록합니다.
+func (h *RouteHandler) RegisterRoutesFrom(router *gin.Engine, routesConfig *config.RoutesConfig) error {
+	// 헬스 체크 엔드포인트
+	router.GET("/health", h.HealthCheckHandler)
+
+	// 관리자 엔드포인트
+	h.registerAdminRoutes(router)
+
+	// 브라우저 로그인 엔드포인트 (OIDC 설정 시)
+	h.registerLoginRoutes(router)
+
+	routes := routesConfig.Routes
 
 	// 라우트 그룹화
-	var rootRoutes []config.Route        // 루트 경로 라우트 ("/")
-	var apiRoutes []config.Route         // API 관련 라우트 ("/api/*")
-	var specificRoutes []config.Route    // 특정 경로 라우트 (예: "/login")
-	var rootCatchAllRoute *config.Route  // 루트 캐치올 라우트 ("/*proxyPath")
-	var wsRoutes []config.Route          // WebSocket 라우트
+	var rootRoutes []*routeRuntime        // 루트 경로 라우트 ("/")
+	var apiRoutes []*routeRuntime         // API 관련 라우트 ("/api/*")
+	var specificRoutes []*routeRuntime    // 특정 경로 라우트 (예: "/login")
+	var rootCatchAllRoute *routeRuntime   // 루트 캐치올 라우트 ("/*proxyPath")
+	var wsRoutes []*routeRuntime          // WebSocket 라우트
 
 	// WebSocket 라우트 추가 (설정에 존재하지 않는 경우)
 	wsPathExists := false
@@ -112,86 +187,137 @@ func (h *RouteHandler) RegisterRoutes(router *gin.Engine) error {
 		routes = append(routes, wsRoute)
 	}
 
+	// 라우트 실행 상태 구성 (라우트별 로드 밸런서 등)
+	table := newRouteTable()
+
 	// 라우트 분류
 	for _, route := range routes {
+		rt, err := table.add(h, route, routesConfig)
+		if err != nil {
+			return err
+		}
+
 		// WebSocket 라우트
 		if strings.HasPrefix(route.Path, "/ws") || strings.HasPrefix(route.Path, "/websocket") {
-			wsRoutes = append(wsRoutes, route)
+			wsRoutes = append(wsRoutes, rt)
 			continue
 		}
 
 		// 일반 HTTP 라우트
 		if route.Path == "/" {
-			rootRoutes = append(rootRoutes, route)
+			rootRoutes = append(rootRoutes, rt)
 		} else if strings.HasPrefix(route.Path, "/api") {
-			apiRoutes = append(apiRoutes, route)
+			apiRoutes = append(apiRoutes, rt)
 		} else if route.Path == "/*proxyPath" || route.Path == "/*path" {
-			routeCopy := route
-			rootCatchAllRoute = &routeCopy
+			rootCatchAllRoute = rt
 		} else {
-			specificRoutes = append(specificRoutes, route)
+			specificRoutes = append(specificRoutes, rt)
 		}
 	}
 
 	// 1. 루트 라우트 등록
-	for _, route := range rootRoutes {
-		h.registerHTTPRoute(router, route)
+	for _, rt := range rootRoutes {
+		h.registerHTTPRoute(router, rt)
 	}
 
 	// 2. 특정 경로 라우트 등록
-	for _, route := range specificRoutes {
-		h.registerHTTPRoute(router, route)
+	for _, rt := range specificRoutes {
+		h.registerHTTPRoute(router, rt)
 	}
 
 	// 3. API 라우트 등록 (그룹 사용)
 	apiGroup := router.Group("/api")
-	for _, route := range apiRoutes {
+	for _, rt := range apiRoutes {
 		// "/api" 접두사 제거
-		subPath := strings.TrimPrefix(route.Path, "/api")
-		h.registerHTTPRouteGroup(apiGroup, subPath, route)
+		subPath := strings.TrimPrefix(rt.route.Path, "/api")
+		h.registerHTTPRouteGroup(apiGroup, subPath, rt)
 	}
 
 	// 4. WebSocket 라우트 등록
-	for _, route := range wsRoutes {
-		h.registerWebSocketRoute(router, route)
+	for _, rt := range wsRoutes {
+		h.registerWebSocketRoute(router, rt)
 	}
 
 	// 5. 루트 캐치올 라우트 등록 (있는 경우)
 	if rootCatchAllRoute != nil {
-		log.Println("루트 캐치올 라우트 등록:", rootCatchAllRoute.Path, "->", rootCatchAllRoute.TargetURL)
-		
+		catchAll := rootCatchAllRoute.route
+		log.Println("루트 캐치올 라우트 등록:", catchAll.Path, "->", catchAll.TargetURL)
+
+		// 캐치올 라우트의 모든 경로가 하나의 핸들러 체인(로드 밸런서)을 공유
+		handlers := h.buildHandlerChain(rootCatchAllRoute)
+
 		// 특정 정적 경로에 대한 캐치올 처리
 		for _, prefix := range []string{"/web", "/assets", "/static", "/public", "/images"} {
-			for _, method := range rootCatchAllRoute.Methods {
+			for _, method := range catchAll.Methods {
 				pathWithSuffix := fmt.Sprintf("%s/*path", prefix)
-				log.Printf("캐치올 핸들러 등록: %s %s -> %s", method, pathWithSuffix, rootCatchAllRoute.TargetURL)
+				log.Printf("캐치올 핸들러 등록: %s %s -> %s", method, pathWithSuffix, catchAll.TargetURL)
 				
-				handlers := h.buildHandlerChain(*rootCatchAllRoute)
 				router.Handle(method, pathWithSuffix, handlers...)
 			}
 		}
 		
 		// NoRoute 핸들러 등록 (매칭되지 않는 모든 경로)
-		router.NoRoute(h.buildHandlerChain(*rootCatchAllRoute)...)
+		router.NoRoute(handlers...)
 	}
 
+	// 라우트 등록이 모두 성공한 경우에만 새 라우트 테이블 반영
+	h.table.Store(table)
+
+	// 새 로드 밸런서를 헬스 체크 대상으로 교체 (기존 대상 상태는 유지)
+	h.healthChecker.SetGroups(table.healthGroups())
+
+	// 더 이상 사용하지 않는 라우트/대상의 서킷 브레이커 제거 (나머지는 상태 유지)
+	h.breakers.Retain(func(key string) bool {
+		return table.breakerKeys[key]
+	})
+
+	// 더 이상 사용하지 않는 업스트림의 연결 풀 정리 (설정이 같은 연결 풀은 재사용)
+	h.transports.Retain(func(name string) bool {
+		return table.transportNames[name]
+	})
+
+	// 더 이상 사용하지 않는 라우트/업스트림의 동시성 제한기 제거
+	h.concurrency.Retain(func(key string) bool {
+		return table.concurrencyKeys[key]
+	})
+
 	return nil
 }
 
 // HealthCheckHandler는 상태 확인 엔드포인트 핸들러입니다.
 func (h *RouteHandler) HealthCheckHandler(c *gin.Context) {
+	upstreams := h.healthChecker.Status()
+
+	// 사용 가능한 대상이 하나도 없는 업스트림이 있으면 degraded
+	status := "ok"
+	for _, targets := range upstreams {
+		available := false
+		for _, target := range targets {
+			if target.Healthy {
+				available = true
+				break
+			}
+		}
+		if !available {
+			status = "degraded"
+			break
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status": "ok",
+		"status": status,
 		"time":   time.Now().Format(time.RFC3339),
 		"version": "1.0.0",
+		"upstreams": upstreams,
 	})
 }
 
 // registerHTTPRoute는 HTTP 라우트를 등록합니다.
-func (h *RouteHandler) registerHTTPRoute(router *gin.Engine, route config.Route) {
+func (h *RouteHandler) registerHTTPRoute(router *gin.Engine, rt *routeRuntime) {
+	route := rt.route
 	log.Printf("라우트 등록: %s %s -> %s", strings.Join(route.Methods, ","), route.Path, route.TargetURL)
 	
-	handlers := h.buildHandlerChain(route)
+	handlers := h.buildHandlerChain(rt)
 	
 	for _, method := range route.Methods {
 		switch method {
@@ -214,10 +340,11 @@ func (h *RouteHandler) registerHTTPRoute(router *gin.Engine, route config.Route)
 }
 
 // registerHTTPRouteGroup는 라우터 그룹에 HTTP 라우트를 등록합니다.
-func (h *RouteHandler) registerHTTPRouteGroup(group *gin.RouterGroup, path string, route config.Route) {
+func (h *RouteHandler) registerHTTPRouteGroup(group *gin.RouterGroup, path string, rt *routeRuntime) {
+	route := rt.route
 	log.Printf("그룹 라우트 등록: %s %s -> %s", strings.Join(route.Methods, ","), path, route.TargetURL)
 	
-	handlers := h.buildHandlerChain(route)
+	handlers := h.buildHandlerChain(rt)
 	
 	for _, method := range route.Methods {
 		switch method {
@@ -240,24 +367,35 @@ func (h *RouteHandler) registerHTTPRouteGroup(group *gin.RouterGroup, path strin
 }
 
 // registerWebSocketRoute는 WebSocket 라우트를 등록합니다.
-func (h *RouteHandler) registerWebSocketRoute(router *gin.Engine, route config.Route) {
+func (h *RouteHandler) registerWebSocketRoute(router *gin.Engine, rt *routeRuntime) {
+	route := rt.route
 	log.Printf("WebSocket 라우트 등록: %s -> %s", route.Path, route.TargetURL)
 	
 	var handlers []gin.HandlerFunc
 	
 	// 인증이 필요한 경우
 	if route.RequireAuth {
-		handlers = append(handlers, h.authMiddleware())
+		handlers = append(handlers, h.authMiddlewareFor(route.AuthMode))
+	}
+	if route.Authorization != nil {
+		handlers = append(handlers, h.authorizeMiddleware(route.Authorization))
+	}
+	handlers = append(handlers, h.identityMiddleware())
+
+	// 라우트별 속도 제한 (인증 후 사용자/역할 기준으로 적용)
+	if len(rt.rateLimits) > 0 {
+		handlers = append(handlers, middleware.PolicyRateLimit(rt.rateLimits))
 	}
 	
 	// WebSocket 프록시 핸들러 추가
-	handlers = append(handlers, h.webSocketProxyHandler(route))
+	handlers = append(handlers, h.webSocketProxyHandler(rt))
 	
 	router.GET(route.Path, handlers...)
 }
 
 // buildHandlerChain은 라우트에 필요한 미들웨어 핸들러 체인을 구성합니다.
-func (h *RouteHandler) buildHandlerChain(route config.Route) []gin.HandlerFunc {
+func (h *RouteHandler) buildHandlerChain(rt *routeRuntime) []gin.HandlerFunc {
+	route := rt.route
 	var handlers []gin.HandlerFunc
 
 	log.Printf("라우트 핸들러 체인 구성: %s, 인증 필요: %v\n", route.Path, route.RequireAuth)
@@ -269,38 +407,57 @@ func (h *RouteHandler) buildHandlerChain(route config.Route) []gin.HandlerFunc {
 
 	handlers = append(handlers, h.cookieToHeaderMiddleware())
 
+	// CSRF 검사 (쿠키로 인증한 상태 변경 요청)
+	if rt.csrf != nil {
+		handlers = append(handlers, h.csrfMiddleware(rt.csrf))
+	}
+
 	// 인증 미들웨어 (필요한 경우)
 	if route.RequireAuth {
 		log.Println("authMiddleware 추가")
-		handlers = append(handlers, h.authMiddleware())
+		handlers = append(handlers, h.authMiddlewareFor(route.AuthMode))
+	}
+
+	// 권한 확인 (역할, 스코프, aud, 클레임 조건)
+	if route.Authorization != nil {
+		handlers = append(handlers, h.authorizeMiddleware(route.Authorization))
+	}
+
+	// 업스트림에 전달할 사용자 정보 (클라이언트가 보낸 사용자 정보 헤더는 항상 제거)
+	handlers = append(handlers, h.identityMiddleware())
+
+	// 라우트별 속도 제한 (인증 후 사용자/역할 기준으로 적용)
+	if len(rt.rateLimits) > 0 {
+		handlers = append(handlers, middleware.PolicyRateLimit(rt.rateLimits))
 	}
 	
 	// 캐싱 미들웨어 (활성화된 경우)
 	if h.config.EnableCaching && route.Cacheable {
-		handlers = append(handlers, h.cacheMiddleware())
+		handlers = append(handlers, h.cacheMiddleware(rt))
+	}
+
+	// 동시성 제한 (캐시 히트는 업스트림에 전달되지 않으므로 캐시 이후에 적용)
+	if len(rt.concurrency) > 0 {
+		handlers = append(handlers, middleware.ConcurrencyLimit(rt.concurrency, h.metrics))
 	}
 	
 	// 프록시 핸들러 추가
-	handlers = append(handlers, h.httpProxyHandler(route))
+	handlers = append(handlers, h.httpProxyHandler(rt))
 	
 	return handlers
 }
 
 // httpProxyHandler는 HTTP 요청을 프록시하는 핸들러를 반환합니다.
-func (h *RouteHandler) httpProxyHandler(route config.Route) gin.HandlerFunc {
+func (h *RouteHandler) httpProxyHandler(rt *routeRuntime) gin.HandlerFunc {
+	route := rt.route
 	return func(c *gin.Context) {
-		// 로드 밸런서에서 대상 서버 선택
-		targetURL, err := h.loadBalancer.NextTarget()
-		if err != nil {
-			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "사용 가능한 백엔드 서버가 없습니다"})
-			c.Abort()
-			return
-		}
+		// WebSocket 요청인지 확인
+		if websocket.IsWebSocketUpgrade(c.Request) {
+			log.Printf("[WARN] HTTP 핸들러로 WebSocket 요청이 들어왔습니다: %s - WebSocket 핸들러로 리다이렉트합니다", c.Request.URL.Path)
 
-		// 라우트별 대상 경로 구성
-		targetPath := route.TargetURL
-		if !strings.HasPrefix(targetPath, "http://") && !strings.HasPrefix(targetPath, "https://") {
-			targetPath = fmt.Sprintf("%s%s", targetURL, targetPath)
+			// WebSocket 요청은 별도 처리
+			h.webSocketProxyHandler(rt)(c)
+			return
 		}
 
 		// 요청 컨텍스트 설정
@@ -309,37 +466,111 @@ func (h *RouteHandler) httpProxyHandler(route config.Route) gin.HandlerFunc {
 		// 경로 스트립 여부 결정
 		stripPath := route.StripPrefix != ""
 
-		// WebSocket 요청인지 확인
-		if websocket.IsWebSocketUpgrade(c.Request) {
-			log.Printf("[WARN] HTTP 핸들러로 WebSocket 요청이 들어왔습니다: %s - WebSocket 핸들러로 리다이렉트합니다", c.Request.URL.Path)
-			
-			// WebSocket 요청은 별도 처리
-			wsTargetURL := targetPath
-			if strings.HasPrefix(wsTargetURL, "http://") {
-				wsTargetURL = "ws://" + strings.TrimPrefix(wsTargetURL, "http://")
-			} else if strings.HasPrefix(wsTargetURL, "https://") {
-				wsTargetURL = "wss://" + strings.TrimPrefix(wsTargetURL, "https://")
+		// 재시도할 수 있는 요청은 본문을 재전송할 수 있도록 버퍼링
+		attempts := rt.retry.maxAttempts(c.Request.Method)
+		var body []byte
+		if attempts > 1 {
+			buffered, ok, err := bufferRequestBody(c.Request, rt.retry.maxBodySize)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "요청 본문을 읽을 수 없습니다"})
+				c.Abort()
+				return
 			}
-			
-			proxy.WebSocketProxy(c.Writer, c.Request, wsTargetURL, h.wsUpgrader)
-			return
+			if !ok {
+				log.Printf("[RETRY] 요청 본문이 %d바이트를 넘어 재시도하지 않습니다: %s %s", rt.retry.maxBodySize, c.Request.Method, c.Request.URL.Path)
+				attempts = 1
+			}
+			body = buffered
 		}
 
-		// 서킷 브레이커를 통해 요청 실행
-		resp, err := h.circuitBreaker.Execute(
-			func() (interface{}, error) {
-				return proxy.ForwardRequest(reqCtx, c.Request, targetPath, stripPath, route.StripPrefix)
-			},
-		)
+		// 시도한 대상 서버는 요청이 끝날 때까지 활성 연결로 유지하여 재시도 시 다른 서버가 선택되도록 함
+		tried := make(map[string]bool)
+		var backends []string
+		defer func() {
+			for _, backend := range backends {
+				rt.release(backend)
+			}
+		}()
+
+		var resp interface{}
+		var err error
+		retries := 0
+		for attempt := 1; ; attempt++ {
+			// 로드 밸런서에서 대상 서버 선택 및 라우트별 대상 경로 구성
+			targetPath, backend, resolveErr := rt.resolveRetryTarget(tried)
+			if resolveErr != nil {
+				if attempt == 1 {
+					c.JSON(http.StatusServiceUnavailable, gin.H{"error": "사용 가능한 백엔드 서버가 없습니다"})
+					c.Abort()
+					return
+				}
+				// 재시도할 대상이 없으면 이전 시도 결과로 응답
+				break
+			}
+			if backend != "" {
+				tried[backend] = true
+				backends = append(backends, backend)
+			}
+
+			// 이전 시도의 응답은 버리고 다시 시도
+			if httpResp, ok := resp.(*http.Response); ok {
+				drainResponse(httpResp)
+			}
+
+			if body != nil {
+				c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			// 라우트 또는 대상 서버의 서킷 브레이커를 통해 요청 실행
+			resp, err = h.breakerFor(rt, backend).Execute(
+				func() (interface{}, error) {
+					return h.forwardAttempt(reqCtx, c.Request, rt, targetPath, stripPath, route.StripPrefix)
+				},
+			)
+
+			// 수동 헬스 체크에 프록시 결과 반영 (5xx 또는 연결 오류는 실패)
+			h.reportUpstreamResult(rt, backend, resp, err)
+
+			if attempt >= attempts || reqCtx.Err() != nil {
+				break
+			}
+
+			httpResp, _ := resp.(*http.Response)
+			reason := rt.retry.retryReason(httpResp, err, rt.targetScoped)
+			if reason == "" {
+				break
+			}
+
+			// 지수 백오프 후 재시도 (클라이언트 요청이 끝나면 중단)
+			retries++
+			log.Printf("[RETRY] 업스트림 요청 재시도 (%d/%d, 사유: %s): %s %s", retries, attempts-1, reason, c.Request.Method, c.Request.URL.Path)
+			if h.metrics != nil {
+				h.metrics.ObserveRetry(route.Path, reason)
+			}
+
+			timer := time.NewTimer(rt.retry.backoff(retries))
+			select {
+			case <-timer.C:
+			case <-reqCtx.Done():
+				timer.Stop()
+			}
+			if reqCtx.Err() != nil {
+				break
+			}
+		}
+
+		if rt.retry != nil {
+			c.Writer.Header().Set(retryCountHeader, strconv.Itoa(retries))
+		}
 
 		if err != nil {
 			// 요청 실패 처리
 			statusCode := http.StatusBadGateway
-			switch err {
-			case circuitbreaker.ErrCircuitOpen:
+			switch {
+			case errors.Is(err, circuitbreaker.ErrCircuitOpen), errors.Is(err, circuitbreaker.ErrTooManyRequests):
 				statusCode = http.StatusServiceUnavailable
 				log.Printf("[CIRCUIT] 서킷 열림 상태로 요청 거부: %s %s", c.Request.Method, c.Request.URL.Path)
-			case context.DeadlineExceeded, context.Canceled:
+			case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
 				statusCode = http.StatusGatewayTimeout
 				log.Printf("[TIMEOUT] 요청 타임아웃: %s %s", c.Request.Method, c.Request.URL.Path)
 			default:
@@ -387,7 +618,7 @@ func (h *RouteHandler) httpProxyHandler(route config.Route) gin.HandlerFunc {
 }
 
 // webSocketProxyHandler는 WebSocket 요청을 프록시하는 핸들러를 반환합니다.
-func (h *RouteHandler) webSocketProxyHandler(route config.Route) gin.HandlerFunc {
+func (h *RouteHandler) webSocketProxyHandler(rt *routeRuntime) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// WebSocket 요청인지 확인
 		if !websocket.IsWebSocketUpgrade(c.Request) {
@@ -397,37 +628,19 @@ func (h *RouteHandler) webSocketProxyHandler(route config.Route) gin.HandlerFunc
 			return
 		}
 		
-		// 로드 밸런서에서 대상 서버 선택
-		targetURL, err := h.loadBalancer.NextTarget()
+		// 로드 밸런서에서 대상 서버 선택 및 라우트별 대상 경로 구성
+		targetPath, backend, err := rt.resolveTarget()
 		if err != nil {
 			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "사용 가능한 백엔드 서버가 없습니다"})
 			c.Abort()
 			return
 		}
 
-		// 라우트별 대상 경로 구성
-		targetPath := route.TargetURL
-		
+		// WebSocket 릴레이가 끝나면 활성 연결 수 반환
+		defer rt.release(backend)
+
 		// 대상 URL이 WebSocket 스킴이 아닌 경우 변환
-		if !strings.HasPrefix(targetPath, "ws://") && !strings.HasPrefix(targetPath, "wss://") {
-			// HTTP/HTTPS URL을 WS/WSS로 변환
-			if strings.HasPrefix(targetURL, "https://") {
-				targetURL = "wss://" + strings.TrimPrefix(targetURL, "https://")
-			} else {
-				targetURL = "ws://" + strings.TrimPrefix(targetURL, "http://")
-			}
-			
-			// 대상 경로 구성
-			if strings.HasSuffix(targetURL, "/") && strings.HasPrefix(targetPath, "/") {
-				// 중복 슬래시 방지
-				targetPath = targetURL + strings.TrimPrefix(targetPath, "/")
-			} else if !strings.HasSuffix(targetURL, "/") && !strings.HasPrefix(targetPath, "/") {
-				// 슬래시 추가
-				targetPath = targetURL + "/" + targetPath
-			} else {
-				targetPath = targetURL + targetPath
-			}
-		}
+		targetPath = toWebSocketURL(targetPath)
 		
 		log.Printf("[WS] WebSocket 프록시 시작: %s -> %s", c.Request.URL.Path, targetPath)
 
@@ -469,12 +682,18 @@ func (h *RouteHandler) timeoutMiddleware(timeout time.Duration) gin.HandlerFunc
 }
 
 
-// authMiddleware는 JWT 인증을 수행하는 핸들러를 반환합니다.
+// cookieToHeaderMiddleware는 access_token 쿠키나 브라우저 로그인 세션의 토큰을 Authorization 헤더로 옮기는 핸들러를 반환합니다.
 func (h *RouteHandler) cookieToHeaderMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {		
 		tokenCookie, err := c.Cookie("access_token")
 		if err == nil && tokenCookie != "" {
 			c.Request.Header.Set("Authorization", "Bearer "+tokenCookie)
+			c.Set(cookieAuthContextKey, true)
+		}
+
+		// 브라우저 로그인 세션 (OIDC 설정 시)
+		if h.applySession(c) {
+			c.Set(cookieAuthContextKey, true)
 		}
 
 		c.Next()
@@ -507,6 +726,13 @@ func (h *RouteHandler) authMiddleware() gin.HandlerFunc {
 
 		// 토큰 검증
 		claims, err := h.authenticator.VerifyToken(token)
+		if errors.Is(err, auth.ErrIntrospectionUnavailable) {
+			// 토큰이 유효하지 않은 것이 아니라 인증 서비스 장애이므로 재시도할 수 있도록 503으로 응답
+			log.Printf("[AUTH] 토큰 확인 실패: %v", err)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "인증 상태를 확인할 수 없습니다"})
+			c.Abort()
+			return
+		}
 		if err != nil {
 			os.Stdout.Write([]byte(fmt.Sprintf("인증 실패: 토큰 검증 실패 - %v\n", err)))
 			c.JSON(http.StatusUnauthorized, gin.H{"error": fmt.Sprintf("인증 실패: %v", err)})
@@ -514,136 +740,18 @@ func (h *RouteHandler) authMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// 폐기된 토큰 확인
+		if !h.checkRevocation(c, claims, token) {
+			return
+		}
+
 		os.Stdout.Write([]byte(fmt.Sprintf("인증 성공 - 사용자 ID: %s, 역할: %v\n", claims.Subject, claims.Roles)))
 
 		// 인증 정보를 컨텍스트에 저장
 		c.Set("userId", claims.Subject)
 		c.Set("roles", claims.Roles)
+		c.Set(claimsContextKey, claims)
 
 		c.Next()
 	}
 }
-
-// cacheMiddleware는 응답 캐싱을 처리하는 핸들러를 반환합니다.
-func (h *RouteHandler) cacheMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// GET 요청만 캐싱
-		if c.Request.Method != http.MethodGet {
-			c.Next()
-			return
-		}
-
-		// 캐시 키 생성
-		cacheKey := generateCacheKey(c.Request)
-
-		// 캐시에서 응답 조회
-		if cachedResponse, found := h.cache.Get(cacheKey); found {
-			// 캐시된 응답 헤더 복원
-			headers := cachedResponse.Headers
-			for key, values := range headers {
-				for _, value := range values {
-					c.Writer.Header().Add(key, value)
-				}
-			}
-
-			// 캐시 헤더 추가
-			c.Writer.Header().Set("X-Cache", "HIT")
-
-			// 상태 코드 및 내용 설정
-			c.Writer.WriteHeader(cachedResponse.StatusCode)
-			c.Writer.Write(cachedResponse.Body)
-
-			// 처리 완료
-			c.Abort()
-			return
-		}
-
-		// 응답 캡처를 위한 래퍼 설정
-		responseWriter := newCacheResponseWriter(c.Writer)
-		c.Writer = responseWriter
-
-		// 다음 핸들러 실행
-		c.Next()
-
-		// 성공 응답만 캐시 (2xx)
-		if responseWriter.Status() >= 200 && responseWriter.Status() < 300 {
-			// Cache-Control 헤더 확인
-			cacheControl := responseWriter.Header().Get("Cache-Control")
-			if !strings.Contains(cacheControl, "no-store") && !strings.Contains(cacheControl, "private") {
-				// 캐시 TTL 계산
-				ttl := h.config.CacheTTL
-				if maxAge := extractMaxAge(cacheControl); maxAge > 0 {
-					ttl = time.Duration(maxAge) * time.Second
-				}
-
-				// 캐시된 응답 저장
-				h.cache.Set(cacheKey, &cache.CachedResponse{
-					StatusCode: responseWriter.Status(),
-					Headers:    responseWriter.Header(),
-					Body:       responseWriter.Body(),
-				}, ttl)
-			}
-		}
-	}
-}
-
-// generateCacheKey는 요청에 대한 고유한 캐시 키를 생성합니다.
-func generateCacheKey(req *http.Request) string {
-	return fmt.Sprintf("%s:%s:%s", req.Method, req.URL.Path, req.URL.RawQuery)
-}
-
-// extractMaxAge는 Cache-Control 헤더에서 max-age 값을 추출합니다.
-func extractMaxAge(cacheControl string) int {
-	if cacheControl == "" {
-		return 0
-	}
-
-	for _, directive := range strings.Split(cacheControl, ",") {
-		directive = strings.TrimSpace(directive)
-		if strings.HasPrefix(directive, "max-age=") {
-			age := strings.TrimPrefix(directive, "max-age=")
-			if maxAge, err := strconv.Atoi(age); err == nil {
-				return maxAge
-			}
-		}
-	}
-
-	return 0
-}
-
-// cacheResponseWriter는 응답을 캡처하기 위한 http.ResponseWriter 래퍼입니다.
-type cacheResponseWriter struct {
-	gin.ResponseWriter
-	body   []byte
-	status int
-}
-
-// newCacheResponseWriter는 새로운 cacheResponseWriter를 생성합니다.
-func newCacheResponseWriter(writer gin.ResponseWriter) *cacheResponseWriter {
-	return &cacheResponseWriter{
-		ResponseWriter: writer,
-		status:         http.StatusOK,
-	}
-}
-
-// Write는 응답 본문을 캡처합니다.
-func (w *cacheResponseWriter) Write(b []byte) (int, error) {
-	w.body = append(w.body, b...)
-	return w.ResponseWriter.Write(b)
-}
-
-// WriteHeader는 응답 상태 코드를 캡처합니다.
-func (w *cacheResponseWriter) WriteHeader(code int) {
-	w.status = code
-	w.ResponseWriter.WriteHeader(code)
-}
-
-// Status는 캡처된 상태 코드를 반환합니다.
-func (w *cacheResponseWriter) Status() int {
-	return w.status
-}
-
-// Body는 캡처된 응답 본문을 반환합니다.
-func (w *cacheResponseWriter) Body() []byte {
-	return w.body
-}
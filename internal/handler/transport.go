@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/proxy"
+)
+
+// defaultTransportName은 업스트림 그룹을 사용하지 않는 라우트가 공유하는 연결 풀 이름입니다.
+const defaultTransportName = "default"
+
+// configureTransport는 라우트가 사용할 연결 풀 이름과 설정을 구성합니다.
+// 같은 업스트림을 사용하는 라우트는 하나의 연결 풀을 공유합니다.
+func (t *routeTable) configureTransport(h *RouteHandler, rt *routeRuntime) {
+	rt.transportName = defaultTransportName
+	rt.transportConfig = h.transports.Defaults()
+	if rt.upstream != nil {
+		rt.transportName = rt.upstream.Name
+		rt.transportConfig = upstreamTransportConfig(h.transports.Defaults(), rt.upstream.Transport)
+	}
+
+	t.transportNames[rt.transportName] = true
+}
+
+// transportFor는 요청을 전달할 연결 풀을 반환합니다.
+func (h *RouteHandler) transportFor(rt *routeRuntime) *proxy.Transport {
+	return h.transports.Get(rt.transportName, rt.transportConfig)
+}
+
+// newTransportDefaults는 환경 변수 설정으로 연결 풀 기본 설정을 구성합니다.
+func newTransportDefaults(cfg *config.Config) proxy.TransportConfig {
+	return proxy.TransportConfig{
+		DialTimeout:           cfg.UpstreamDialTimeout,
+		TLSHandshakeTimeout:   cfg.UpstreamTLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.UpstreamResponseHeaderTimeout,
+		IdleConnTimeout:       cfg.UpstreamIdleConnTimeout,
+		MaxIdleConns:          cfg.UpstreamMaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.UpstreamMaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.UpstreamMaxConnsPerHost,
+		HTTP2:                 cfg.UpstreamHTTP2,
+		ProxyFromEnvironment:  cfg.UpstreamProxyFromEnvironment,
+	}
+}
+
+// upstreamTransportConfig는 기본 설정에 업스트림별 설정을 덮어쓴 연결 풀 설정을 반환합니다.
+func upstreamTransportConfig(defaults proxy.TransportConfig, override *config.TransportConfig) proxy.TransportConfig {
+	cfg := defaults
+	if override == nil {
+		return cfg
+	}
+
+	if override.DialTimeout > 0 {
+		cfg.DialTimeout = time.Duration(override.DialTimeout) * time.Millisecond
+	}
+	if override.TLSHandshakeTimeout > 0 {
+		cfg.TLSHandshakeTimeout = time.Duration(override.TLSHandshakeTimeout) * time.Millisecond
+	}
+	if override.ResponseHeaderTimeout > 0 {
+		cfg.ResponseHeaderTimeout = time.Duration(override.ResponseHeaderTimeout) * time.Millisecond
+	}
+	if override.IdleConnTimeout > 0 {
+		cfg.IdleConnTimeout = time.Duration(override.IdleConnTimeout) * time.Millisecond
+	}
+	if override.MaxIdleConns > 0 {
+		cfg.MaxIdleConns = override.MaxIdleConns
+	}
+	if override.MaxIdleConnsPerHost > 0 {
+		cfg.MaxIdleConnsPerHost = override.MaxIdleConnsPerHost
+	}
+	if override.MaxConnsPerHost > 0 {
+		cfg.MaxConnsPerHost = override.MaxConnsPerHost
+	}
+	if override.HTTP2 != nil {
+		cfg.HTTP2 = *override.HTTP2
+	}
+	if override.ProxyFromEnvironment != nil {
+		cfg.ProxyFromEnvironment = *override.ProxyFromEnvironment
+	}
+
+	return cfg
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/middleware"
+	"github.com/isinthesky/api-gateway/internal/proxy"
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+	"github.com/isinthesky/api-gateway/pkg/healthcheck"
+	"github.com/isinthesky/api-gateway/pkg/loadbalancer"
+)
+
+// routeRuntime은 라우트 테이블 세대마다 라우트별로 구성되는 실행 상태입니다.
+type routeRuntime struct {
+	route      config.Route
+	upstream   *config.Upstream          // 업스트림 그룹 (업스트림 라우트인 경우)
+	balancer   loadbalancer.LoadBalancer // nil이면 route.TargetURL로 직접 전달
+	targetPath string                    // 로드 밸런서가 선택한 대상 서버 뒤에 붙일 경로
+
+	breakerConfig  circuitbreaker.Config            // 라우트에 적용할 서킷 브레이커 설정
+	breakerKey     string                           // 라우트 범위 서킷 브레이커 키
+	targetScoped   bool                             // 대상 서버별 서킷 브레이커 사용 여부
+	targetBreakers map[string]circuitbreaker.Config // 대상 서버 범위 서킷 브레이커 설정 (테이블 공유)
+
+	retry *retryPolicy // 재시도 정책 (nil이면 재시도하지 않음)
+	csrf  *csrfPolicy  // CSRF 검사 정책 (nil이면 검사하지 않음)
+
+	transportName   string                // 요청을 전달할 연결 풀 이름
+	transportConfig proxy.TransportConfig // 연결 풀 설정
+
+	rateLimits  []middleware.RateLimitPolicy  // 라우트별 속도 제한 정책
+	concurrency []middleware.ConcurrencyScope // 라우트/업스트림 동시성 제한 (라우트 먼저 적용)
+}
+
+// routeTable은 RegisterRoutes 한 번으로 구성된 라우트 실행 상태 모음입니다.
+type routeTable struct {
+	routes          []*routeRuntime
+	breakerKeys     map[string]bool                  // 테이블에서 사용하는 서킷 브레이커 키
+	targetBreakers  map[string]circuitbreaker.Config // 대상 서버 범위 서킷 브레이커 설정
+	transportNames  map[string]bool                  // 테이블에서 사용하는 연결 풀 이름
+	concurrencyKeys map[string]bool                  // 테이블에서 사용하는 동시성 제한기 키
+}
+
+// newRouteTable은 빈 라우트 테이블을 생성합니다.
+func newRouteTable() *routeTable {
+	return &routeTable{
+		breakerKeys:     make(map[string]bool),
+		targetBreakers:  make(map[string]circuitbreaker.Config),
+		transportNames:  make(map[string]bool),
+		concurrencyKeys: make(map[string]bool),
+	}
+}
+
+// add는 라우트의 실행 상태를 구성하여 테이블에 추가합니다.
+func (t *routeTable) add(h *RouteHandler, route config.Route, routesConfig *config.RoutesConfig) (*routeRuntime, error) {
+	if err := h.checkAuthMode(route); err != nil {
+		return nil, err
+	}
+
+	rt := &routeRuntime{route: route}
+
+	switch {
+	case route.Upstream != "":
+		// 업스트림 그룹을 사용하는 라우트는 라우트마다 독립된 로드 밸런서를 가짐
+		upstream, ok := routesConfig.Upstream(route.Upstream)
+		if !ok {
+			return nil, fmt.Errorf("라우트 %s: 정의되지 않은 업스트림입니다: %s", route.Path, route.Upstream)
+		}
+
+		lb, err := newUpstreamBalancer(upstream)
+		if err != nil {
+			return nil, fmt.Errorf("라우트 %s: %v", route.Path, err)
+		}
+
+		rt.upstream = &upstream
+		rt.balancer = lb
+		rt.targetPath = upstreamTargetPath(route.TargetURL)
+
+	case isAbsoluteURL(route.TargetURL):
+		// 절대 URL은 대상 서버로 직접 전달
+		rt.balancer = nil
+
+	default:
+		// 상대 경로는 전역 로드 밸런서(BACKEND_URLS)의 대상 서버 뒤에 붙임
+		rt.balancer = h.loadBalancer
+		rt.targetPath = route.TargetURL
+	}
+
+	t.configureBreaker(h, rt)
+	rt.retry = newRetryPolicy(route.Retry)
+	rt.csrf = h.newCSRFPolicy(route.CSRF)
+	t.configureTransport(h, rt)
+	t.configureRateLimits(h, rt)
+	t.configureConcurrency(h, rt)
+
+	t.routes = append(t.routes, rt)
+	return rt, nil
+}
+
+// healthGroups는 헬스 체크가 설정된 업스트림별로 대상 서버와 로드 밸런서를 묶어 반환합니다.
+func (t *routeTable) healthGroups() []healthcheck.Group {
+	var groups []healthcheck.Group
+	index := make(map[string]int)
+
+	for _, rt := range t.routes {
+		upstream := rt.upstream
+		if upstream == nil || (upstream.HealthCheck == nil && upstream.PassiveHealth == nil) {
+			continue
+		}
+
+		// 같은 업스트림을 사용하는 라우트들의 로드 밸런서를 하나의 그룹으로 묶음
+		if i, ok := index[upstream.Name]; ok {
+			groups[i].Balancers = append(groups[i].Balancers, rt.balancer)
+			continue
+		}
+
+		group := healthcheck.Group{
+			Name:      upstream.Name,
+			Balancers: []loadbalancer.LoadBalancer{rt.balancer},
+		}
+		for _, target := range upstream.Targets {
+			group.Targets = append(group.Targets, strings.TrimSuffix(target.URL, "/"))
+		}
+		if hc := upstream.HealthCheck; hc != nil {
+			group.Active = &healthcheck.Config{
+				Path:               hc.Path,
+				Interval:           time.Duration(hc.Interval) * time.Second,
+				Timeout:            time.Duration(hc.Timeout) * time.Second,
+				ExpectedStatus:     hc.ExpectedStatus,
+				HealthyThreshold:   hc.HealthyThreshold,
+				UnhealthyThreshold: hc.UnhealthyThreshold,
+			}
+		}
+		if ph := upstream.PassiveHealth; ph != nil {
+			group.Passive = &healthcheck.PassiveConfig{
+				MaxFailures: ph.MaxFailures,
+				Cooldown:    time.Duration(ph.Cooldown) * time.Second,
+			}
+		}
+
+		index[upstream.Name] = len(groups)
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// resolveTarget은 요청을 전달할 대상 URL을 결정합니다.
+// 로드 밸런서에서 대상 서버를 선택한 경우 선택된 서버 URL도 함께 반환하며,
+// 호출자는 응답 처리가 끝나면 release를 호출해야 합니다.
+func (rt *routeRuntime) resolveTarget() (targetURL string, backend string, err error) {
+	if rt.balancer == nil {
+		return rt.route.TargetURL, "", nil
+	}
+
+	backend, err = rt.balancer.NextTarget()
+	if err != nil {
+		return "", "", err
+	}
+
+	return joinTargetURL(backend, rt.targetPath), backend, nil
+}
+
+// release는 선택했던 대상 서버의 활성 연결 수를 반환합니다.
+func (rt *routeRuntime) release(backend string) {
+	if rt.balancer != nil && backend != "" {
+		loadbalancer.ReleaseConn(rt.balancer, backend)
+	}
+}
+
+// reportUpstreamResult는 업스트림 대상 서버의 프록시 결과를 수동 헬스 체크에 반영합니다.
+func (h *RouteHandler) reportUpstreamResult(rt *routeRuntime, backend string, resp interface{}, err error) {
+	if rt.upstream == nil || backend == "" {
+		return
+	}
+
+	// 서킷 브레이커가 요청을 차단한 경우는 대상 서버 상태와 무관
+	if errors.Is(err, circuitbreaker.ErrCircuitOpen) || errors.Is(err, circuitbreaker.ErrTooManyRequests) {
+		return
+	}
+
+	success := err == nil
+	if httpResp, ok := resp.(*http.Response); ok && httpResp != nil && httpResp.StatusCode >= 500 {
+		success = false
+	}
+
+	h.healthChecker.ReportResult(rt.upstream.Name, backend, success)
+}
+
+// newUpstreamBalancer는 업스트림 그룹 구성으로 새 로드 밸런서를 생성합니다.
+func newUpstreamBalancer(upstream config.Upstream) (loadbalancer.LoadBalancer, error) {
+	lb, err := loadbalancer.NewWithStrategy(upstream.Strategy)
+	if err != nil {
+		return nil, fmt.Errorf("업스트림 %s: %v", upstream.Name, err)
+	}
+
+	for _, target := range upstream.Targets {
+		if err := lb.AddTarget(strings.TrimSuffix(target.URL, "/"), target.Weight); err != nil {
+			return nil, fmt.Errorf("업스트림 %s: 대상 추가 실패 (%s): %v", upstream.Name, target.URL, err)
+		}
+	}
+
+	return lb, nil
+}
+
+// upstreamTargetPath는 업스트림 라우트의 targetURL에서 전달 경로만 추출합니다.
+func upstreamTargetPath(targetURL string) string {
+	if !isAbsoluteURL(targetURL) {
+		return targetURL
+	}
+
+	u, err := url.Parse(targetURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
+}
+
+// isAbsoluteURL은 스킴을 포함한 절대 URL인지 확인합니다.
+func isAbsoluteURL(target string) bool {
+	for _, scheme := range []string{"http://", "https://", "ws://", "wss://"} {
+		if strings.HasPrefix(target, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinTargetURL은 대상 서버 URL과 경로를 중복/누락 슬래시 없이 결합합니다.
+func joinTargetURL(base, path string) string {
+	if path == "" {
+		return base
+	}
+
+	switch {
+	case strings.HasSuffix(base, "/") && strings.HasPrefix(path, "/"):
+		return base + strings.TrimPrefix(path, "/")
+	case !strings.HasSuffix(base, "/") && !strings.HasPrefix(path, "/"):
+		return base + "/" + path
+	default:
+		return base + path
+	}
+}
+
+// toWebSocketURL은 HTTP/HTTPS URL을 WS/WSS URL로 변환합니다.
+func toWebSocketURL(target string) string {
+	switch {
+	case strings.HasPrefix(target, "https://"):
+		return "wss://" + strings.TrimPrefix(target, "https://")
+	case strings.HasPrefix(target, "http://"):
+		return "ws://" + strings.TrimPrefix(target, "http://")
+	case strings.HasPrefix(target, "ws://"), strings.HasPrefix(target, "wss://"):
+		return target
+	default:
+		return "ws://" + target
+	}
+}
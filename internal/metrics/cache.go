@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/isinthesky/api-gateway/pkg/cache"
+)
+
+// cacheStatsCollector는 수집 시점에 응답 캐시 통계를 읽어 메트릭으로 내보내는 수집기입니다.
+type cacheStatsCollector struct {
+	mu     sync.RWMutex
+	source func() cache.Stats // nil이면 메트릭을 내보내지 않음
+
+	lookups   *prometheus.Desc
+	evictions *prometheus.Desc
+	rejected  *prometheus.Desc
+	entries   *prometheus.Desc
+	bytes     *prometheus.Desc
+}
+
+// newCacheStatsCollector는 새로운 캐시 통계 수집기를 생성합니다.
+func newCacheStatsCollector() *cacheStatsCollector {
+	return &cacheStatsCollector{
+		lookups: prometheus.NewDesc(
+			"api_gateway_cache_lookups_total",
+			"API Gateway 응답 캐시 조회 횟수 (result: hit, miss)",
+			[]string{"result"}, nil,
+		),
+		evictions: prometheus.NewDesc(
+			"api_gateway_cache_evictions_total",
+			"API Gateway 응답 캐시 용량 초과로 제거한 항목 수",
+			nil, nil,
+		),
+		rejected: prometheus.NewDesc(
+			"api_gateway_cache_rejected_total",
+			"API Gateway 응답 캐시 항목 크기 제한을 넘어 저장하지 않은 항목 수",
+			nil, nil,
+		),
+		entries: prometheus.NewDesc(
+			"api_gateway_cache_entries",
+			"API Gateway 응답 캐시 현재 항목 수",
+			nil, nil,
+		),
+		bytes: prometheus.NewDesc(
+			"api_gateway_cache_bytes",
+			"API Gateway 응답 캐시 현재 항목 크기 합계 (바이트)",
+			nil, nil,
+		),
+	}
+}
+
+// Describe는 prometheus.Collector 인터페이스를 구현합니다.
+func (c *cacheStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.lookups
+	ch <- c.evictions
+	ch <- c.rejected
+	ch <- c.entries
+	ch <- c.bytes
+}
+
+// Collect는 prometheus.Collector 인터페이스를 구현합니다.
+func (c *cacheStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	source := c.source
+	c.mu.RUnlock()
+	if source == nil {
+		return
+	}
+
+	stats := source()
+	ch <- prometheus.MustNewConstMetric(c.lookups, prometheus.CounterValue, float64(stats.Hits), "hit")
+	ch <- prometheus.MustNewConstMetric(c.lookups, prometheus.CounterValue, float64(stats.Misses), "miss")
+	ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions))
+	ch <- prometheus.MustNewConstMetric(c.rejected, prometheus.CounterValue, float64(stats.Rejected))
+	ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(stats.Entries))
+	ch <- prometheus.MustNewConstMetric(c.bytes, prometheus.GaugeValue, float64(stats.Bytes))
+}
+
+// WatchCache는 응답 캐시 통계를 메트릭으로 내보내도록 설정합니다.
+func (c *Collector) WatchCache(stats func() cache.Stats) {
+	c.cacheStats.mu.Lock()
+	defer c.cacheStats.mu.Unlock()
+	c.cacheStats.source = stats
+}
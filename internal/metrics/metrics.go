@@ -20,10 +20,19 @@ type Collector struct {
 	ratelimitTotal    *prometheus.CounterVec
 	errorTotal        *prometheus.CounterVec
 	inFlightRequests  *prometheus.GaugeVec
+	retryTotal        *prometheus.CounterVec
+	upstreamConnTotal *prometheus.CounterVec
+	concurrencyLimit  *prometheus.GaugeVec
+	concurrencyInFlight *prometheus.GaugeVec
+	loadShedTotal     *prometheus.CounterVec
+	cacheStats        *cacheStatsCollector
 }
 
 // NewCollector는 새로운 메트릭 수집기를 생성합니다.
 func NewCollector() *Collector {
+	cacheStats := newCacheStatsCollector()
+	prometheus.MustRegister(cacheStats)
+
 	return &Collector{
 		requestTotal: promauto.NewCounterVec(
 			prometheus.CounterOpts{
@@ -91,6 +100,42 @@ func NewCollector() *Collector {
 			},
 			[]string{"method", "path"},
 		),
+		retryTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_gateway_upstream_retries_total",
+				Help: "API Gateway 업스트림 요청 재시도 횟수",
+			},
+			[]string{"path", "reason"},
+		),
+		upstreamConnTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_gateway_upstream_connections_total",
+				Help: "API Gateway 업스트림 요청이 사용한 연결 수 (reused: keep-alive 연결 재사용 여부)",
+			},
+			[]string{"upstream", "reused"},
+		),
+		concurrencyLimit: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "api_gateway_concurrency_limit",
+				Help: "API Gateway 라우트/업스트림별 현재 동시 요청 한도",
+			},
+			[]string{"scope"},
+		),
+		concurrencyInFlight: promauto.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "api_gateway_concurrency_in_flight",
+				Help: "API Gateway 라우트/업스트림별 동시성 제한기가 처리 중인 요청 수",
+			},
+			[]string{"scope"},
+		),
+		loadShedTotal: promauto.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "api_gateway_load_shed_total",
+				Help: "API Gateway 동시 요청 한도 초과로 거부한 요청 수",
+			},
+			[]string{"scope", "reason"},
+		),
+		cacheStats: cacheStats,
 	}
 }
 
@@ -131,6 +176,32 @@ func (c *Collector) ObserveCircuitBreaker(path string, status string) {
 	c.circuitBreakerTotal.WithLabelValues(path, status).Inc()
 }
 
+// ObserveRetry는 업스트림 요청 재시도를 기록합니다.
+func (c *Collector) ObserveRetry(path string, reason string) {
+	c.retryTotal.WithLabelValues(path, reason).Inc()
+}
+
+// ObserveUpstreamConnection은 업스트림 요청의 연결 재사용 여부를 기록합니다.
+func (c *Collector) ObserveUpstreamConnection(upstream string, reused bool) {
+	c.upstreamConnTotal.WithLabelValues(upstream, strconv.FormatBool(reused)).Inc()
+}
+
+// SetConcurrencyLimit은 라우트/업스트림의 현재 동시 요청 한도를 기록합니다.
+func (c *Collector) SetConcurrencyLimit(scope string, limit int) {
+	c.concurrencyLimit.WithLabelValues(scope).Set(float64(limit))
+}
+
+// SetConcurrencyInFlight는 라우트/업스트림의 동시성 제한기가 처리 중인 요청 수를 기록합니다.
+// 게이트웨이 전체에서 처리 중인 요청 수는 api_gateway_in_flight_requests 게이지로 확인합니다.
+func (c *Collector) SetConcurrencyInFlight(scope string, inFlight int) {
+	c.concurrencyInFlight.WithLabelValues(scope).Set(float64(inFlight))
+}
+
+// ObserveLoadShed는 동시 요청 한도 초과로 거부한 요청을 기록합니다.
+func (c *Collector) ObserveLoadShed(scope string, reason string) {
+	c.loadShedTotal.WithLabelValues(scope, reason).Inc()
+}
+
 // ObserveRateLimit는 속도 제한 적용을 기록합니다.
 func (c *Collector) ObserveRateLimit(path string, clientIP string) {
 	c.ratelimitTotal.WithLabelValues(path, clientIP).Inc()
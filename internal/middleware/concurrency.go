@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/isinthesky/api-gateway/internal/metrics"
+	"github.com/isinthesky/api-gateway/pkg/concurrency"
+)
+
+// ConcurrencyScope는 요청에 적용할 동시성 제한기와 범위(라우트 또는 업스트림) 이름입니다.
+type ConcurrencyScope struct {
+	Name       string
+	Limiter    *concurrency.Limiter
+	RetryAfter time.Duration // 거부 응답의 Retry-After
+}
+
+// ConcurrencyLimit은 처리 중인 요청 수를 범위별로 제한하는 미들웨어입니다.
+// 모든 범위에서 슬롯을 얻어야 요청을 처리하며, 대기열이 가득 차거나 대기 시간이 지나면 503으로 거부합니다.
+// 요청 처리 결과와 지연 시간은 적응형 한도 조정에 반영되며, 이후 핸들러에서 패닉이 발생해도 슬롯을 반환합니다.
+// 범위별 한도와 처리 중인 요청 수를 기록하며, collector가 nil이면 메트릭을 기록하지 않습니다.
+func ConcurrencyLimit(scopes []ConcurrencyScope, collector *metrics.Collector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokens := make([]*concurrency.Token, 0, len(scopes))
+		for _, scope := range scopes {
+			token, err := scope.Limiter.Acquire(c.Request.Context())
+			if err != nil {
+				for _, acquired := range tokens {
+					acquired.Release(concurrency.OutcomeIgnore)
+				}
+				shed(c, scope, err, collector)
+				return
+			}
+			tokens = append(tokens, token)
+		}
+
+		recordConcurrency(scopes, collector)
+
+		completed := false
+		defer func() {
+			// 패닉으로 중단된 요청은 한도 조정에 반영하지 않음
+			outcome := concurrency.OutcomeIgnore
+			if completed {
+				outcome = concurrencyOutcome(c)
+			}
+			for _, token := range tokens {
+				token.Release(outcome)
+			}
+			recordConcurrency(scopes, collector)
+		}()
+
+		c.Next()
+		completed = true
+	}
+}
+
+// recordConcurrency는 범위별 현재 한도와 처리 중인 요청 수를 기록합니다.
+func recordConcurrency(scopes []ConcurrencyScope, collector *metrics.Collector) {
+	if collector == nil {
+		return
+	}
+	for _, scope := range scopes {
+		stats := scope.Limiter.Stats()
+		collector.SetConcurrencyLimit(scope.Name, stats.Limit)
+		collector.SetConcurrencyInFlight(scope.Name, stats.InFlight)
+	}
+}
+
+// shed는 슬롯을 얻지 못한 요청을 거부합니다.
+func shed(c *gin.Context, scope ConcurrencyScope, err error, collector *metrics.Collector) {
+	// 클라이언트가 요청을 취소한 경우 응답할 필요 없음
+	if errors.Is(err, context.Canceled) {
+		c.Abort()
+		return
+	}
+
+	reason := "queue_timeout"
+	if errors.Is(err, concurrency.ErrLimitExceeded) {
+		reason = "queue_full"
+	}
+	if collector != nil {
+		collector.ObserveLoadShed(scope.Name, reason)
+	}
+
+	retryAfter := int(scope.RetryAfter.Seconds())
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.JSON(http.StatusServiceUnavailable, gin.H{
+		"error":       "서버가 혼잡하여 요청을 처리할 수 없습니다",
+		"message":     "잠시 후 다시 시도해주세요",
+		"retry_after": retryAfter, // 초 단위
+	})
+	c.Abort()
+}
+
+// concurrencyOutcome은 요청 처리 결과를 한도 조정에 반영할 방식으로 분류합니다.
+// 업스트림 과부하를 나타내는 503, 504 응답은 한도를 줄이고, 클라이언트가 취소한 요청은 반영하지 않습니다.
+func concurrencyOutcome(c *gin.Context) concurrency.Outcome {
+	if errors.Is(c.Request.Context().Err(), context.Canceled) {
+		return concurrency.OutcomeIgnore
+	}
+
+	switch c.Writer.Status() {
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return concurrency.OutcomeDropped
+	}
+	return concurrency.OutcomeSuccess
+}
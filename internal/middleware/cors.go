@@ -7,9 +7,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// corsAllowHeaders는 교차 출처 요청에 허용하는 기본 요청 헤더입니다.
+const corsAllowHeaders = "Authorization, Content-Type, Accept, Origin, X-Requested-With, X-Request-ID"
+
 // CORS는 Cross-Origin Resource Sharing 미들웨어를 설정합니다.
-func CORS(allowedOrigins []string) gin.HandlerFunc {
+// allowHeaders는 기본 헤더 외에 허용할 요청 헤더입니다 (예: CSRF 토큰 헤더).
+func CORS(allowedOrigins []string, allowHeaders ...string) gin.HandlerFunc {
 	allowAll := len(allowedOrigins) == 1 && allowedOrigins[0] == "*"
+	headers := strings.Join(append([]string{corsAllowHeaders}, allowHeaders...), ", ")
 
 	return func(c *gin.Context) {
 		origin := c.Request.Header.Get("Origin")
@@ -17,20 +22,14 @@ func CORS(allowedOrigins []string) gin.HandlerFunc {
 		// Access-Control-Allow-Origin 헤더 설정
 		if allowAll {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		} else if origin != "" {
-			// 허용된 오리진 확인
-			for _, allowed := range allowedOrigins {
-				if allowed == origin {
-					c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-					break
-				}
-			}
+		} else if IsTrustedOrigin(allowedOrigins, origin) {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 		}
 
 		// OPTIONS 요청 처리 (프리플라이트)
 		if c.Request.Method == "OPTIONS" {
 			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept, Origin, X-Requested-With, X-Request-ID")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", headers)
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 			c.Writer.Header().Set("Access-Control-Max-Age", "86400") // 24시간
 			c.AbortWithStatus(http.StatusNoContent)
@@ -39,7 +38,7 @@ func CORS(allowedOrigins []string) gin.HandlerFunc {
 
 		// 기본 CORS 헤더 설정
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, Accept, Origin, X-Requested-With, X-Request-ID")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", headers)
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type, X-Request-ID")
 
@@ -47,6 +46,20 @@ func CORS(allowedOrigins []string) gin.HandlerFunc {
 	}
 }
 
+// IsTrustedOrigin은 오리진이 허용 목록에 명시되어 있는지 확인합니다. CORS와 CSRF 검사가 같은 목록(ALLOWED_ORIGINS)을 사용합니다.
+// 와일드카드(*)는 명시된 오리진으로 보지 않으며, 불투명 오리진("null")은 허용하지 않습니다.
+func IsTrustedOrigin(allowedOrigins []string, origin string) bool {
+	if origin == "" || origin == "null" {
+		return false
+	}
+	for _, allowed := range allowedOrigins {
+		if allowed != "*" && strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
 // hasSuffix는 문자열이 지정된 접미사 중 하나로 끝나는지 확인합니다.
 func hasSuffix(s string, suffixes []string) bool {
 	for _, suffix := range suffixes {
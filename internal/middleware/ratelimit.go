@@ -5,37 +5,28 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/isinthesky/api-gateway/internal/config"
 	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
 )
 
 // RateLimit은 요청 속도를 제한하는 미들웨어입니다.
+// 모든 응답에 한도 상태 헤더를 설정하며, 거부된 요청에는 Retry-After 헤더를 설정합니다.
 func RateLimit(limiter ratelimiter.RateLimiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 클라이언트 식별자 (IP 주소 사용)
-		clientID := c.ClientIP()
-		
-		// IP 기반 키 생성
-		key := clientID + ":" + c.FullPath()
-		
+		// 클라이언트 IP와 경로로 키 생성
+		key := c.ClientIP() + ":" + c.FullPath()
+
 		// 속도 제한 확인
-		if !limiter.Allow(key) {
-			// 요청 거부
+		if status := checkRateLimit(c, "global", limiter, key); !status.Allowed {
 			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "요청 속도 제한 초과",
-				"message": "잠시 후 다시 시도해주세요",
-				"retry_after": 1, // 초 단위
+				"error":       "요청 속도 제한 초과",
+				"message":     "잠시 후 다시 시도해주세요",
+				"retry_after": retryAfterSeconds(status), // 초 단위
 			})
-			
-			// 클라이언트에게 재시도 시간 알림
-			c.Header("Retry-After", "1")
-			c.Header("X-RateLimit-Limit", "1") // 실제로는 설정값 사용
-			c.Header("X-RateLimit-Remaining", "0")
-			c.Header("X-RateLimit-Reset", time.Now().Add(time.Second).Format(time.RFC1123))
-			
 			c.Abort()
 			return
 		}
-		
+
 		c.Next()
 	}
 }
@@ -45,38 +36,127 @@ func DynamicRateLimit(configs map[string]ratelimiter.RateLimiter) gin.HandlerFun
 	return func(c *gin.Context) {
 		path := c.FullPath()
 		clientID := c.ClientIP()
-		
+
 		// 경로에 맞는 리미터 선택
-		var limiter ratelimiter.RateLimiter
-		var found bool
-		
-		if limiter, found = configs[path]; !found {
+		name := path
+		limiter, found := configs[path]
+		if !found {
 			// 경로별 설정이 없으면 기본 리미터 사용
+			name = "default"
 			limiter = configs["default"]
 		}
-		
+
 		// 속도 제한 확인
 		key := clientID + ":" + path
-		if !limiter.Allow(key) {
+		if status := checkRateLimit(c, name, limiter, key); !status.Allowed {
 			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error": "요청 속도 제한 초과",
+				"error":   "요청 속도 제한 초과",
 				"message": "잠시 후 다시 시도해주세요",
 			})
 			c.Abort()
 			return
 		}
-		
+
+		c.Next()
+	}
+}
+
+// RateLimitPolicy는 요청 키별로 적용할 속도 제한 정책입니다.
+type RateLimitPolicy struct {
+	Name    string                      // 속도 제한기 키 구분용 정책 이름
+	Key     func(c *gin.Context) string // 요청을 구분할 키
+	Limiter ratelimiter.RateLimiter     // 일치하는 등급이 없을 때 사용할 속도 제한기
+	Tiers   []RateLimitTier             // 역할별 속도 제한기 (먼저 일치한 항목 사용)
+}
+
+// RateLimitTier는 역할별로 적용할 속도 제한기입니다.
+type RateLimitTier struct {
+	Role    string
+	Limiter ratelimiter.RateLimiter
+}
+
+// PolicyRateLimit은 라우트별 속도 제한 정책을 적용하는 미들웨어입니다.
+// 모든 정책을 통과해야 요청이 허용되며, 역할 등급은 인증 미들웨어가 설정한 roles로 결정합니다.
+func PolicyRateLimit(policies []RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, policy := range policies {
+			if !policy.Enforce(c) {
+				return
+			}
+		}
+
 		c.Next()
 	}
 }
 
+// Enforce는 요청 하나에 정책을 적용합니다. 한도를 초과하면 429로 응답하고 요청을 중단한 뒤 false를 반환합니다.
+func (p RateLimitPolicy) Enforce(c *gin.Context) bool {
+	limiter, tier := p.limiterFor(c)
+	key := p.Name + ":" + tier + ":" + p.Key(c)
+
+	if status := checkRateLimit(c, p.Name, limiter, key); !status.Allowed {
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":       "요청 속도 제한 초과",
+			"message":     "잠시 후 다시 시도해주세요",
+			"retry_after": retryAfterSeconds(status), // 초 단위
+		})
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// limiterFor는 요청의 역할에 맞는 속도 제한기와 등급 이름을 반환합니다.
+func (p RateLimitPolicy) limiterFor(c *gin.Context) (ratelimiter.RateLimiter, string) {
+	if len(p.Tiers) > 0 {
+		if value, exists := c.Get("roles"); exists {
+			roles, _ := value.([]string)
+			for _, tier := range p.Tiers {
+				for _, role := range roles {
+					if role == tier.Role {
+						return tier.Limiter, "role=" + tier.Role
+					}
+				}
+			}
+		}
+	}
+	return p.Limiter, "default"
+}
+
+// APIKeyIDContextKey는 API 키 인증 미들웨어가 인증된 API 키 ID를 저장하는 gin 컨텍스트 키입니다 (apiKey 속도 제한 정책이 사용).
+const APIKeyIDContextKey = "apiKeyId"
+
+// RateLimitKey는 정책 키 유형에 맞는 요청 키 추출 함수를 반환합니다.
+// 키 값이 없는 요청(비인증 사용자, 헤더 누락 등)은 클라이언트 IP로 구분합니다.
+func RateLimitKey(kind, header string) func(c *gin.Context) string {
+	var value func(c *gin.Context) string
+	switch kind {
+	case config.RateLimitKeyUser:
+		value = func(c *gin.Context) string { return c.GetString("userId") }
+	case config.RateLimitKeyHeader:
+		value = func(c *gin.Context) string { return c.GetHeader(header) }
+	case config.RateLimitKeyAPIKey:
+		// API 키 인증을 거친 요청의 키 ID로 구분 (키 원문은 저장소에 남기지 않음)
+		value = func(c *gin.Context) string { return c.GetString(APIKeyIDContextKey) }
+	default:
+		return func(c *gin.Context) string { return "ip=" + c.ClientIP() }
+	}
+
+	return func(c *gin.Context) string {
+		if v := value(c); v != "" {
+			return kind + "=" + v
+		}
+		return "ip=" + c.ClientIP()
+	}
+}
+
 // IPBasedRateLimit은 IP 주소 기반 속도 제한 미들웨어입니다.
 func IPBasedRateLimit(limiter ratelimiter.RateLimiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		clientIP := c.ClientIP()
 		
 		// IP 기반 제한 확인
-		if !limiter.Allow(clientIP) {
+		if status := checkRateLimit(c, "ip", limiter, clientIP); !status.Allowed {
 			c.JSON(http.StatusTooManyRequests, gin.H{
 				"error": "IP 기반 요청 속도 제한 초과",
 				"message": "잠시 후 다시 시도해주세요",
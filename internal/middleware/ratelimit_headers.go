@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
+)
+
+// rateLimitStatusKey는 요청에 적용된 속도 제한 정책 상태 목록을 보관하는 컨텍스트 키입니다.
+const rateLimitStatusKey = "rateLimitStatuses"
+
+// policyStatus는 정책 이름과 속도 제한 상태입니다.
+type policyStatus struct {
+	name   string
+	status ratelimiter.Status
+}
+
+// checkRateLimit은 요청 1개를 속도 제한기에 기록하고 지금까지 적용된 모든 정책의 상태로 응답 헤더를 설정합니다.
+// 거부된 경우 실제 보충 시간으로 계산한 Retry-After 헤더도 설정합니다.
+func checkRateLimit(c *gin.Context, name string, limiter ratelimiter.RateLimiter, key string) ratelimiter.Status {
+	var status ratelimiter.Status
+	if sl, ok := limiter.(ratelimiter.StatusLimiter); ok {
+		status = sl.AllowNStatus(key, 1)
+	} else {
+		// 상태를 제공하지 않는 속도 제한기는 허용 여부만 알 수 있음
+		status = ratelimiter.Status{Allowed: limiter.Allow(key), RetryAfter: time.Second}
+	}
+
+	var statuses []policyStatus
+	if value, exists := c.Get(rateLimitStatusKey); exists {
+		statuses, _ = value.([]policyStatus)
+	}
+	statuses = append(statuses, policyStatus{name: name, status: status})
+	c.Set(rateLimitStatusKey, statuses)
+
+	setRateLimitHeaders(c, statuses)
+	if !status.Allowed {
+		c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(status)))
+	}
+
+	return status
+}
+
+// setRateLimitHeaders는 정책 상태로 X-RateLimit-* 헤더와 IETF RateLimit/RateLimit-Policy 헤더를 설정합니다.
+// X-RateLimit-* 헤더는 남은 요청 수가 가장 적은 정책을 기준으로 합니다.
+func setRateLimitHeaders(c *gin.Context, statuses []policyStatus) {
+	var limits, policies []string
+	var tightest *ratelimiter.Status
+
+	for i := range statuses {
+		status := &statuses[i].status
+		if status.Limit <= 0 {
+			continue
+		}
+
+		name := quoteHeaderString(statuses[i].name)
+		limits = append(limits, fmt.Sprintf("%s;r=%d;t=%d", name, status.Remaining, ceilSeconds(status.ResetAfter)))
+		policies = append(policies, fmt.Sprintf("%s;q=%d;w=%d", name, status.Limit, ceilSeconds(status.Window)))
+
+		if tightest == nil || status.Remaining < tightest.Remaining ||
+			(status.Remaining == tightest.Remaining && status.ResetAfter > tightest.ResetAfter) {
+			tightest = status
+		}
+	}
+
+	if tightest == nil {
+		return
+	}
+
+	c.Header("X-RateLimit-Limit", strconv.Itoa(tightest.Limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(tightest.Remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(resetUnix(tightest.ResetAfter), 10))
+	c.Header("RateLimit", strings.Join(limits, ", "))
+	c.Header("RateLimit-Policy", strings.Join(policies, ", "))
+}
+
+// retryAfterSeconds는 거부된 요청이 허용될 때까지 남은 시간(초, 최소 1)을 반환합니다.
+func retryAfterSeconds(status ratelimiter.Status) int {
+	if seconds := ceilSeconds(status.RetryAfter); seconds > 1 {
+		return seconds
+	}
+	return 1
+}
+
+// resetUnix는 한도가 모두 회복되는 시각을 Unix 초 단위로 올림하여 반환합니다.
+func resetUnix(resetAfter time.Duration) int64 {
+	reset := time.Now().Add(resetAfter)
+	if reset.Nanosecond() > 0 {
+		return reset.Unix() + 1
+	}
+	return reset.Unix()
+}
+
+// ceilSeconds는 시간을 초 단위로 올림합니다.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// quoteHeaderString은 정책 이름을 구조화 필드 문자열로 인용합니다.
+func quoteHeaderString(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r > 0x7e:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
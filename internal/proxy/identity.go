@@ -0,0 +1,54 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+)
+
+// DefaultIdentityHeaders는 요청 컨텍스트에 Identity가 없을 때 제거하는 사용자 정보 헤더입니다.
+var DefaultIdentityHeaders = []string{
+	"X-User-Id",
+	"X-User-Email",
+	"X-User-Name",
+	"X-User-Roles",
+	"X-User-Scopes",
+	"X-Internal-Token",
+}
+
+// Identity는 게이트웨이가 검증한 사용자 정보를 업스트림에 전달하는 헤더입니다.
+// 업스트림은 이 헤더를 신뢰하므로, 클라이언트가 보낸 같은 이름의 헤더는 항상 제거합니다.
+type Identity struct {
+	Strip  []string    // 클라이언트가 보낸 값을 제거할 헤더
+	Header http.Header // 제거 후 설정할 헤더 (인증되지 않은 요청이면 비어 있음)
+}
+
+type identityContextKey struct{}
+
+// WithIdentity는 업스트림에 전달할 사용자 정보를 컨텍스트에 저장합니다.
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFrom은 컨텍스트에 저장된 사용자 정보를 반환합니다. 없으면 nil을 반환합니다.
+func IdentityFrom(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey{}).(*Identity)
+	return identity
+}
+
+// applyIdentity는 클라이언트가 보낸 사용자 정보 헤더를 제거하고 컨텍스트의 사용자 정보 헤더를 설정합니다.
+func applyIdentity(ctx context.Context, header http.Header) {
+	identity := IdentityFrom(ctx)
+	if identity == nil {
+		for _, name := range DefaultIdentityHeaders {
+			header.Del(name)
+		}
+		return
+	}
+
+	for _, name := range identity.Strip {
+		header.Del(name)
+	}
+	for name, values := range identity.Header {
+		header[name] = append([]string(nil), values...)
+	}
+}
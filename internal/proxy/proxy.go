@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -76,8 +77,23 @@ func HTTPProxyHandler(proxy *HTTPProxy, targetURL string, stripPrefix bool) gin.
 	}
 }
 
-// ForwardRequest는 HTTP 요청을 대상 서버로 전달합니다.
+// defaultTransport는 연결 풀을 지정하지 않은 요청이 공유하는 연결 풀입니다 (http.DefaultTransport와 같은 설정).
+var defaultTransport = NewTransport("default", TransportConfig{
+	DialTimeout:          30 * time.Second,
+	TLSHandshakeTimeout:  10 * time.Second,
+	IdleConnTimeout:      90 * time.Second,
+	MaxIdleConns:         100,
+	HTTP2:                true,
+	ProxyFromEnvironment: true,
+})
+
+// ForwardRequest는 HTTP 요청을 공유 기본 연결 풀을 통해 대상 서버로 전달합니다.
 func ForwardRequest(ctx context.Context, req *http.Request, targetURL string, stripPath bool, stripPrefix string) (*http.Response, error) {
+	return defaultTransport.ForwardRequest(ctx, req, targetURL, stripPath, stripPrefix)
+}
+
+// ForwardRequest는 HTTP 요청을 이 연결 풀을 통해 대상 서버로 전달합니다.
+func (t *Transport) ForwardRequest(ctx context.Context, req *http.Request, targetURL string, stripPath bool, stripPrefix string) (*http.Response, error) {
 	// 대상 URL 파싱
 	target, err := url.Parse(targetURL)
 	if err != nil {
@@ -98,6 +114,9 @@ func ForwardRequest(ctx context.Context, req *http.Request, targetURL string, st
 		}
 	}
 
+	// 클라이언트가 보낸 사용자 정보 헤더 제거 후 검증된 사용자 정보 설정
+	applyIdentity(ctx, targetReq.Header)
+
 	// 경로 처리
 	log.Printf("[PROXY-FWD] 경로 처리 시작 - 원본 경로: %s", req.URL.Path)
 	
@@ -163,13 +182,10 @@ func ForwardRequest(ctx context.Context, req *http.Request, targetURL string, st
 	log.Printf("[PROXY-FWD] 최종 요청 전달: %s %s -> %s (%s)", 
 		targetReq.Method, req.URL.Path, targetReq.URL.String(), targetReq.Host)
 
-	// HTTP 클라이언트 생성
-	client := &http.Client{}
-
-	// 요청 전송
-	resp, err := client.Do(targetReq)
+	// 공유 연결 풀로 요청 전송
+	resp, err := t.do(targetReq)
 	if err != nil {
-		return nil, fmt.Errorf("프록시 요청 실패: %v", err)
+		return nil, fmt.Errorf("프록시 요청 실패: %w", err)
 	}
 
 	// 응답 로깅
@@ -207,6 +223,9 @@ func WebSocketProxy(w http.ResponseWriter, r *http.Request, targetURL string, up
 		}
 	}
 
+	// 클라이언트가 보낸 사용자 정보 헤더 제거 후 검증된 사용자 정보 설정
+	applyIdentity(r.Context(), requestHeader)
+
 	// 클라이언트 IP 헤더 설정
 	requestHeader.Set("X-Forwarded-For", r.RemoteAddr)
 	requestHeader.Set("X-Real-IP", r.RemoteAddr)
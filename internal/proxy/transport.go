@@ -0,0 +1,195 @@
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TransportConfig는 업스트림 연결 풀 설정입니다. 0인 값은 net/http 기본 동작(제한 없음)을 따릅니다.
+type TransportConfig struct {
+	DialTimeout           time.Duration // TCP 연결 수립 타임아웃
+	TLSHandshakeTimeout   time.Duration // TLS 핸드셰이크 타임아웃
+	ResponseHeaderTimeout time.Duration // 요청 전송 후 응답 헤더 수신까지의 타임아웃
+	IdleConnTimeout       time.Duration // 유휴 연결 유지 시간
+	MaxIdleConns          int           // 전체 최대 유휴 연결 수
+	MaxIdleConnsPerHost   int           // 대상 서버별 최대 유휴 연결 수 (0이면 2)
+	MaxConnsPerHost       int           // 대상 서버별 최대 연결 수
+	HTTP2                 bool          // TLS 대상 서버와 HTTP/2 사용
+	ProxyFromEnvironment  bool          // HTTP_PROXY, HTTPS_PROXY, NO_PROXY 환경 변수 사용
+}
+
+// ConnObserver는 업스트림 요청이 연결을 얻을 때마다 호출되며, reused는 keep-alive 연결 재사용 여부입니다.
+type ConnObserver func(upstream string, reused bool)
+
+// Transport는 하나의 업스트림이 공유하는 연결 풀입니다.
+type Transport struct {
+	name      string
+	config    TransportConfig
+	transport *http.Transport
+	client    *http.Client
+	pool      *TransportPool // 연결 관찰자 조회용 (nil이면 기록하지 않음)
+}
+
+// NewTransport는 설정에 맞는 연결 풀을 생성합니다.
+func NewTransport(name string, config TransportConfig) *Transport {
+	dialer := &net.Dialer{
+		Timeout:   config.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   config.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: config.ResponseHeaderTimeout,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       config.MaxConnsPerHost,
+		ForceAttemptHTTP2:     config.HTTP2,
+		ExpectContinueTimeout: time.Second,
+	}
+	if config.ProxyFromEnvironment {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	return &Transport{
+		name:      name,
+		config:    config,
+		transport: transport,
+		client:    &http.Client{Transport: transport},
+	}
+}
+
+// Name은 연결 풀 이름(업스트림 이름)을 반환합니다.
+func (t *Transport) Name() string {
+	return t.name
+}
+
+// Config는 연결 풀 설정을 반환합니다.
+func (t *Transport) Config() TransportConfig {
+	return t.config
+}
+
+// CloseIdleConnections는 유휴 연결을 모두 닫습니다.
+func (t *Transport) CloseIdleConnections() {
+	t.transport.CloseIdleConnections()
+}
+
+// do는 요청을 전송하고 연결 재사용 여부를 관찰자에게 알립니다.
+func (t *Transport) do(req *http.Request) (*http.Response, error) {
+	if observe := t.pool.observer(); observe != nil {
+		trace := &httptrace.ClientTrace{
+			GotConn: func(info httptrace.GotConnInfo) {
+				observe(t.name, info.Reused)
+			},
+		}
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	}
+	return t.client.Do(req)
+}
+
+// TransportPool은 업스트림별 연결 풀을 관리합니다.
+// 설정이 바뀌지 않은 연결 풀은 라우트 리로드 후에도 재사용됩니다.
+type TransportPool struct {
+	mutex      sync.RWMutex
+	defaults   TransportConfig
+	transports map[string]*Transport
+	observe    ConnObserver
+}
+
+// NewTransportPool은 기본 설정을 가진 연결 풀 모음을 생성합니다.
+func NewTransportPool(defaults TransportConfig) *TransportPool {
+	return &TransportPool{
+		defaults:   defaults,
+		transports: make(map[string]*Transport),
+	}
+}
+
+// Defaults는 업스트림별 설정이 없을 때 사용할 기본 설정을 반환합니다.
+func (p *TransportPool) Defaults() TransportConfig {
+	return p.defaults
+}
+
+// SetObserver는 연결 재사용 여부를 기록할 관찰자를 설정합니다.
+func (p *TransportPool) SetObserver(observe ConnObserver) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.observe = observe
+}
+
+func (p *TransportPool) observer() ConnObserver {
+	if p == nil {
+		return nil
+	}
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.observe
+}
+
+// Get은 이름에 해당하는 연결 풀을 반환합니다.
+// 등록되지 않았거나 설정이 변경된 경우 새 연결 풀을 생성하고 이전 연결 풀의 유휴 연결을 닫습니다.
+func (p *TransportPool) Get(name string, config TransportConfig) *Transport {
+	p.mutex.RLock()
+	existing, ok := p.transports[name]
+	p.mutex.RUnlock()
+	if ok && existing.config == config {
+		return existing
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// 잠금을 기다리는 동안 다른 요청이 생성했을 수 있음
+	existing, ok = p.transports[name]
+	if ok && existing.config == config {
+		return existing
+	}
+	if ok {
+		existing.CloseIdleConnections()
+	}
+
+	transport := NewTransport(name, config)
+	transport.pool = p
+	p.transports[name] = transport
+	return transport
+}
+
+// Retain은 keep이 true를 반환하는 이름의 연결 풀만 남기고 나머지의 유휴 연결을 닫아 제거합니다.
+func (p *TransportPool) Retain(keep func(name string) bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for name, transport := range p.transports {
+		if !keep(name) {
+			transport.CloseIdleConnections()
+			delete(p.transports, name)
+		}
+	}
+}
+
+// Names는 등록된 연결 풀 이름을 정렬하여 반환합니다.
+func (p *TransportPool) Names() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	names := make([]string, 0, len(p.transports))
+	for name := range p.transports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close는 모든 연결 풀의 유휴 연결을 닫습니다.
+func (p *TransportPool) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, transport := range p.transports {
+		transport.CloseIdleConnections()
+	}
+}
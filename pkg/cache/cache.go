@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"container/list"
 	"log"
 	"net/http"
 	"sync"
@@ -12,7 +13,42 @@ type CachedResponse struct {
 	StatusCode int
 	Headers    http.Header
 	Body       []byte
-	Expiry     time.Time
+	Expiry     time.Time // 저장소에서 제거되는 시각
+
+	// Vary가 있으면 응답이 아닌 보조 키 목록입니다. 실제 응답은 이 요청 헤더 값으로 만든 보조 키에 저장됩니다.
+	Vary []string
+
+	// 신선도 정보 (RFC 9111)
+	StoredAt             time.Time     // 저장(또는 재검증)한 시각
+	InitialAge           time.Duration // 저장 시점의 응답 나이
+	Lifetime             time.Duration // 신선도 유지 기간
+	MustRevalidate       bool          // 만료 후 재검증 없이 사용할 수 없음
+	StaleWhileRevalidate time.Duration // 만료 후 백그라운드 재검증 동안 사용할 수 있는 기간
+	StaleIfError         time.Duration // 만료 후 업스트림 오류 시 사용할 수 있는 기간
+}
+
+// Clone은 응답의 복사본을 반환합니다.
+func (r *CachedResponse) Clone() *CachedResponse {
+	clone := *r
+	clone.Headers = r.Headers.Clone()
+	clone.Body = append([]byte(nil), r.Body...)
+	clone.Vary = append([]string(nil), r.Vary...)
+	return &clone
+}
+
+// Size는 응답이 차지하는 대략적인 메모리 크기(바이트)를 반환합니다.
+func (r *CachedResponse) Size() int64 {
+	size := int64(len(r.Body))
+	for name, values := range r.Headers {
+		size += int64(len(name))
+		for _, value := range values {
+			size += int64(len(value))
+		}
+	}
+	for _, name := range r.Vary {
+		size += int64(len(name))
+	}
+	return size
 }
 
 // CacheProvider는 캐싱 기능을 제공하는 인터페이스입니다.
@@ -22,21 +58,112 @@ type CacheProvider interface {
 	Delete(key string)
 	Clear()
 	Close()
+	Stats() Stats
+
+	// Range는 만료되지 않은 모든 항목에 대해 fn을 호출하며, fn이 false를 반환하면 중단합니다.
+	// 조회 통계와 LRU 순서에는 영향을 주지 않으며, fn 안에서 Delete를 호출할 수 있습니다.
+	Range(fn func(Entry) bool)
+}
+
+// Entry는 저장된 캐시 항목입니다. Response는 읽기 전용으로 사용해야 합니다.
+type Entry struct {
+	Key      string
+	Response *CachedResponse
+	Size     int64 // 저장소에서 차지하는 크기 (바이트)
+}
+
+// Purge는 match와 일치하는 항목을 모두 삭제하고 삭제한 키를 반환합니다.
+func Purge(provider CacheProvider, match func(Entry) bool) []string {
+	var keys []string
+	provider.Range(func(entry Entry) bool {
+		if match(entry) {
+			keys = append(keys, entry.Key)
+		}
+		return true
+	})
+	for _, key := range keys {
+		provider.Delete(key)
+	}
+	return keys
+}
+
+// Stats는 캐시 통계입니다. 조회/제거 횟수는 생성 후 누적 값입니다.
+// Vary 목록 항목의 조회 성공은 세지 않으며, 이어지는 보조 키 조회의 결과로 한 번만 기록됩니다.
+type Stats struct {
+	Hits      uint64 `json:"hits"`      // 조회 성공 횟수
+	Misses    uint64 `json:"misses"`    // 조회 실패 횟수 (만료 포함)
+	Evictions uint64 `json:"evictions"` // 용량 초과로 제거한 항목 수
+	Rejected  uint64 `json:"rejected"`  // 항목 크기 제한을 넘어 저장하지 않은 항목 수
+	Entries   int    `json:"entries"`   // 현재 항목 수
+	Bytes     int64  `json:"bytes"`     // 현재 항목 크기 합계 (바이트)
+}
+
+// Config는 메모리 캐시 설정입니다.
+type Config struct {
+	DefaultTTL   time.Duration // TTL을 지정하지 않은 항목의 수명
+	MaxEntries   int           // 최대 항목 수
+	MaxBytes     int64         // 최대 항목 크기 합계 (바이트)
+	MaxItemBytes int64         // 항목 하나의 최대 크기 (바이트, 넘으면 저장하지 않음)
+}
+
+// 기본 용량
+const (
+	DefaultMaxEntries   = 10000
+	DefaultMaxBytes     = 256 * 1024 * 1024 // 256MB
+	DefaultMaxItemBytes = 1024 * 1024       // 1MB
+)
+
+// withDefaults는 0 이하의 용량 제한에 기본값을 적용한 설정을 반환합니다.
+func (c Config) withDefaults() Config {
+	if c.MaxEntries <= 0 {
+		c.MaxEntries = DefaultMaxEntries
+	}
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = DefaultMaxBytes
+	}
+	if c.MaxItemBytes <= 0 {
+		c.MaxItemBytes = DefaultMaxItemBytes
+	}
+	if c.MaxItemBytes > c.MaxBytes {
+		c.MaxItemBytes = c.MaxBytes
+	}
+	return c
 }
 
 // MemoryCache는 메모리 기반 캐시 구현체입니다.
+// 항목 수와 크기 합계가 한도를 넘으면 가장 오래 사용하지 않은 항목부터 제거합니다 (LRU).
 type MemoryCache struct {
-	items      map[string]*CachedResponse
-	mu         sync.RWMutex
+	mu         sync.Mutex
+	items      map[string]*list.Element // 키 -> lru 항목
+	lru        *list.List               // 최근 사용 순서 (앞쪽이 최근, *entry)
+	config     Config
 	defaultTTL time.Duration
+	stats      Stats
 	quit       chan struct{}
 }
 
-// New는 새로운 메모리 캐시를 생성합니다.
+// entry는 LRU 목록의 캐시 항목입니다.
+type entry struct {
+	key      string
+	response *CachedResponse
+	size     int64
+}
+
+// New는 기본 용량 제한을 가진 새로운 메모리 캐시를 생성합니다.
 func New(defaultTTL time.Duration) *MemoryCache {
+	return NewWithConfig(Config{DefaultTTL: defaultTTL})
+}
+
+// NewWithConfig는 지정한 설정으로 새로운 메모리 캐시를 생성합니다.
+// 0 이하의 용량 제한에는 기본값을 사용합니다.
+func NewWithConfig(config Config) *MemoryCache {
+	config = config.withDefaults()
+
 	cache := &MemoryCache{
-		items:      make(map[string]*CachedResponse),
-		defaultTTL: defaultTTL,
+		items:      make(map[string]*list.Element),
+		lru:        list.New(),
+		config:     config,
+		defaultTTL: config.DefaultTTL,
 		quit:       make(chan struct{}),
 	}
 
@@ -48,38 +175,54 @@ func New(defaultTTL time.Duration) *MemoryCache {
 
 // Get은 캐시에서 키에 해당하는 응답을 가져옵니다.
 func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
-	c.mu.RLock()
-	item, found := c.items[key]
-	c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
+	element, found := c.items[key]
 	if !found {
+		c.stats.Misses++
 		return nil, false
 	}
 
+	item := element.Value.(*entry).response
 	now := time.Now()
 	// 만료 시간과 현재 시간의 차이가 2ms 이상일 때만 만료로 처리
 	if now.Sub(item.Expiry) > 2*time.Millisecond {
 		// 디버깅을 위한 로그 추가
 		log.Printf("캐시 만료: key=%s, 만료시간=%v, 현재시간=%v, 차이=%v", key, item.Expiry, now, now.Sub(item.Expiry))
-		c.Delete(key)
+		c.removeElement(element)
+		c.stats.Misses++
 		return nil, false
 	}
 
+	c.lru.MoveToFront(element)
+	// Vary 목록 항목은 보조 키 조회에서 기록
+	if len(item.Vary) == 0 {
+		c.stats.Hits++
+	}
 	return item, true
 }
 
 // Set은 응답을 캐시에 저장합니다.
+// 항목 크기 제한을 넘는 응답은 저장하지 않으며, 용량을 넘으면 가장 오래 사용하지 않은 항목부터 제거합니다.
 func (c *MemoryCache) Set(key string, response *CachedResponse, ttl time.Duration) {
+	// 응답 객체 복사
+	newResponse := response.Clone()
+	size := int64(len(key)) + newResponse.Size()
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	// 응답 객체 복사
-	newResponse := &CachedResponse{
-		StatusCode: response.StatusCode,
-		Headers:    response.Headers.Clone(), // HTTP 헤더 복사
-		Body:       make([]byte, len(response.Body)),
+	// 같은 키의 이전 응답은 새 응답으로 교체되거나, 새 응답을 저장할 수 없으면 더 이상 유효하지 않음
+	if element, found := c.items[key]; found {
+		c.removeElement(element)
+	}
+
+	if size > c.config.MaxItemBytes {
+		log.Printf("캐시 저장 거부: key=%s, 크기=%d바이트, 항목 크기 제한=%d바이트", key, size, c.config.MaxItemBytes)
+		c.stats.Rejected++
+		return
 	}
-	copy(newResponse.Body, response.Body)
 
 	// TTL이 지정되지 않은 경우 기본값 사용
 	if ttl <= 0 {
@@ -89,12 +232,19 @@ func (c *MemoryCache) Set(key string, response *CachedResponse, ttl time.Duratio
 	// 만료 시간 설정
 	now := time.Now()
 	newResponse.Expiry = now.Add(ttl)
-	
+
 	// 디버깅을 위한 로그 추가
 	log.Printf("캐시 저장: key=%s, TTL=%v, 만료시간=%v, 현재시간=%v", key, ttl, newResponse.Expiry, now)
 
 	// 캐시에 저장
-	c.items[key] = newResponse
+	c.items[key] = c.lru.PushFront(&entry{key: key, response: newResponse, size: size})
+	c.stats.Bytes += size
+
+	// 용량을 넘으면 가장 오래 사용하지 않은 항목부터 제거
+	for len(c.items) > c.config.MaxEntries || c.stats.Bytes > c.config.MaxBytes {
+		c.removeElement(c.lru.Back())
+		c.stats.Evictions++
+	}
 }
 
 // Delete는 캐시에서 키에 해당하는 항목을 삭제합니다.
@@ -102,7 +252,9 @@ func (c *MemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	delete(c.items, key)
+	if element, found := c.items[key]; found {
+		c.removeElement(element)
+	}
 }
 
 // Clear는 캐시의 모든 항목을 삭제합니다.
@@ -110,7 +262,39 @@ func (c *MemoryCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.items = make(map[string]*CachedResponse)
+	c.items = make(map[string]*list.Element)
+	c.lru.Init()
+	c.stats.Bytes = 0
+}
+
+// Stats는 캐시 통계를 반환합니다.
+func (c *MemoryCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.items)
+	return stats
+}
+
+// Range는 만료되지 않은 모든 항목에 대해 fn을 호출합니다. 호출 시점의 항목 목록을 사용합니다.
+func (c *MemoryCache) Range(fn func(Entry) bool) {
+	now := time.Now()
+	c.mu.Lock()
+	entries := make([]Entry, 0, len(c.items))
+	for element := c.lru.Front(); element != nil; element = element.Next() {
+		item := element.Value.(*entry)
+		if now.Before(item.response.Expiry) {
+			entries = append(entries, Entry{Key: item.key, Response: item.response, Size: item.size})
+		}
+	}
+	c.mu.Unlock()
+
+	for _, e := range entries {
+		if !fn(e) {
+			return
+		}
+	}
 }
 
 // Close는 캐시 리소스를 정리합니다.
@@ -118,6 +302,13 @@ func (c *MemoryCache) Close() {
 	close(c.quit)
 }
 
+// removeElement는 항목을 제거합니다. 호출자는 잠금을 보유해야 합니다.
+func (c *MemoryCache) removeElement(element *list.Element) {
+	item := c.lru.Remove(element).(*entry)
+	delete(c.items, item.key)
+	c.stats.Bytes -= item.size
+}
+
 // startCleaner는 만료된 캐시 항목을 정기적으로 제거하는 백그라운드 작업을 시작합니다.
 func (c *MemoryCache) startCleaner() {
 	ticker := time.NewTicker(5 * time.Minute)
@@ -139,9 +330,9 @@ func (c *MemoryCache) cleanExpired() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for key, item := range c.items {
-		if now.After(item.Expiry) {
-			delete(c.items, key)
+	for _, element := range c.items {
+		if now.After(element.Value.(*entry).response.Expiry) {
+			c.removeElement(element)
 		}
 	}
 }
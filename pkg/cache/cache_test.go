@@ -184,3 +184,170 @@ func TestMemoryCache(t *testing.T) {
 		}
 	})
 }
+
+func TestMemoryCacheLimits(t *testing.T) {
+	response := func(size int) *CachedResponse {
+		return &CachedResponse{StatusCode: 200, Headers: http.Header{}, Body: make([]byte, size)}
+	}
+
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		cache := NewWithConfig(Config{DefaultTTL: time.Minute, MaxEntries: 2})
+		defer cache.Close()
+
+		cache.Set("a", response(1), 0)
+		cache.Set("b", response(1), 0)
+		_, found := cache.Get("a") // a를 최근 사용으로 갱신
+		assert.True(t, found)
+		cache.Set("c", response(1), 0)
+
+		_, found = cache.Get("b")
+		assert.False(t, found, "가장 오래 사용하지 않은 항목이 제거되어야 함")
+		_, found = cache.Get("a")
+		assert.True(t, found)
+		_, found = cache.Get("c")
+		assert.True(t, found)
+		assert.Equal(t, uint64(1), cache.Stats().Evictions)
+	})
+
+	t.Run("EvictsByTotalBytes", func(t *testing.T) {
+		cache := NewWithConfig(Config{DefaultTTL: time.Minute, MaxBytes: 250, MaxItemBytes: 200})
+		defer cache.Close()
+
+		cache.Set("a", response(100), 0)
+		cache.Set("b", response(100), 0)
+		cache.Set("c", response(100), 0)
+
+		stats := cache.Stats()
+		assert.Equal(t, 2, stats.Entries)
+		assert.Equal(t, int64(202), stats.Bytes, "본문과 키 크기의 합")
+		_, found := cache.Get("a")
+		assert.False(t, found)
+	})
+
+	t.Run("RejectsOversizedItem", func(t *testing.T) {
+		cache := NewWithConfig(Config{DefaultTTL: time.Minute, MaxItemBytes: 100})
+		defer cache.Close()
+
+		cache.Set("a", response(10), 0)
+		cache.Set("a", response(200), 0)
+
+		_, found := cache.Get("a")
+		assert.False(t, found, "크기 제한을 넘는 응답은 저장하지 않고 이전 응답도 제거해야 함")
+		stats := cache.Stats()
+		assert.Equal(t, uint64(1), stats.Rejected)
+		assert.Equal(t, int64(0), stats.Bytes)
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		cache := NewWithConfig(Config{DefaultTTL: time.Minute})
+		defer cache.Close()
+
+		headers := http.Header{}
+		headers.Set("ETag", `"v1"`)
+		cache.Set("key", &CachedResponse{StatusCode: 200, Headers: headers, Body: []byte("body")}, 0)
+		cache.Get("key")
+		cache.Get("key")
+		cache.Get("missing")
+
+		assert.Equal(t, Stats{Hits: 2, Misses: 1, Entries: 1, Bytes: int64(len("key") + len("body") + len("Etag") + len(`"v1"`))}, cache.Stats())
+
+		cache.Delete("key")
+		assert.Equal(t, int64(0), cache.Stats().Bytes)
+	})
+}
+
+// assertRangeAndPurge는 저장소의 Range와 Purge 동작을 검사합니다.
+func assertRangeAndPurge(t *testing.T, provider CacheProvider) {
+	provider.Set("GET:/a", sampleResponse("a"), time.Minute)
+	provider.Set("GET:/b", sampleResponse("b"), time.Minute)
+	provider.Set("GET:/c", sampleResponse("c"), 50*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	before := provider.Stats()
+
+	entries := map[string]string{}
+	provider.Range(func(entry Entry) bool {
+		assert.Positive(t, entry.Size)
+		entries[entry.Key] = string(entry.Response.Body)
+		return true
+	})
+	assert.Equal(t, map[string]string{"GET:/a": "a", "GET:/b": "b"}, entries, "만료된 항목은 제외해야 함")
+	assert.Equal(t, before.Hits, provider.Stats().Hits, "Range는 조회 통계에 영향을 주지 않아야 함")
+
+	visited := 0
+	provider.Range(func(Entry) bool {
+		visited++
+		return false
+	})
+	assert.Equal(t, 1, visited, "fn이 false를 반환하면 중단해야 함")
+
+	purged := Purge(provider, func(entry Entry) bool { return entry.Key == "GET:/a" })
+	assert.Equal(t, []string{"GET:/a"}, purged)
+	_, found := provider.Get("GET:/a")
+	assert.False(t, found)
+	_, found = provider.Get("GET:/b")
+	assert.True(t, found)
+}
+
+func TestRangeAndPurge(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		cache := NewWithConfig(Config{DefaultTTL: time.Minute})
+		defer cache.Close()
+		assertRangeAndPurge(t, cache)
+	})
+
+	t.Run("Disk", func(t *testing.T) {
+		cache, err := NewDiskCache(t.TempDir(), Config{DefaultTTL: time.Minute})
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer cache.Close()
+		assertRangeAndPurge(t, cache)
+	})
+
+	t.Run("Redis", func(t *testing.T) {
+		cache := NewRedisCache(RedisConfig{Addr: newRedisFake(t, "").Addr(), DefaultTTL: time.Minute})
+		defer cache.Close()
+		assertRangeAndPurge(t, cache)
+	})
+}
+
+// assertVaryLookupStats는 Vary 목록 항목을 거친 조회가 통계에 한 번만 기록되는지 검사합니다.
+func assertVaryLookupStats(t *testing.T, provider CacheProvider) {
+	provider.Set("GET:/v", &CachedResponse{Vary: []string{"Accept-Encoding"}}, time.Minute)
+	provider.Set("GET:/v|vary:Accept-Encoding=gzip", sampleResponse("gzip"), time.Minute)
+
+	for _, variant := range []string{"gzip", "br"} {
+		list, found := provider.Get("GET:/v")
+		if assert.True(t, found) {
+			assert.Equal(t, []string{"Accept-Encoding"}, list.Vary)
+		}
+		provider.Get("GET:/v|vary:Accept-Encoding=" + variant)
+	}
+
+	stats := provider.Stats()
+	assert.Equal(t, uint64(1), stats.Hits, "Vary 목록 항목의 조회는 세지 않아야 함")
+	assert.Equal(t, uint64(1), stats.Misses, "보조 키 조회 실패는 한 번의 실패로 기록해야 함")
+}
+
+func TestVaryLookupStats(t *testing.T) {
+	t.Run("Memory", func(t *testing.T) {
+		cache := NewWithConfig(Config{DefaultTTL: time.Minute})
+		defer cache.Close()
+		assertVaryLookupStats(t, cache)
+	})
+
+	t.Run("Disk", func(t *testing.T) {
+		cache, err := NewDiskCache(t.TempDir(), Config{DefaultTTL: time.Minute})
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer cache.Close()
+		assertVaryLookupStats(t, cache)
+	})
+
+	t.Run("Redis", func(t *testing.T) {
+		cache := NewRedisCache(RedisConfig{Addr: newRedisFake(t, "").Addr(), DefaultTTL: time.Minute})
+		defer cache.Close()
+		assertVaryLookupStats(t, cache)
+	})
+}
@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// storedEntry는 외부 저장소(Redis, 디스크)에 저장하는 캐시 항목 형식입니다.
+type storedEntry struct {
+	Key      string          `json:"key"`
+	Response *CachedResponse `json:"response"`
+}
+
+// encodeEntry는 캐시 항목을 헤더와 신선도 정보를 포함하여 직렬화합니다.
+func encodeEntry(key string, response *CachedResponse) ([]byte, error) {
+	return json.Marshal(storedEntry{Key: key, Response: response})
+}
+
+// decodeEntry는 직렬화된 캐시 항목을 복원합니다.
+func decodeEntry(data []byte) (string, *CachedResponse, error) {
+	var entry storedEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return "", nil, err
+	}
+	if entry.Response == nil {
+		return "", nil, fmt.Errorf("cache entry %q has no response", entry.Key)
+	}
+	return entry.Key, entry.Response, nil
+}
@@ -0,0 +1,362 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// diskFileSuffix는 디스크 캐시 항목 파일의 확장자입니다.
+const diskFileSuffix = ".cache"
+
+// DiskCache는 로컬 디렉터리에 항목마다 파일 하나로 응답을 저장하는 캐시 구현체입니다.
+// 게이트웨이를 재시작해도 캐시가 유지되며, 항목 색인은 메모리에 두고 시작 시 디렉터리에서 다시 만듭니다.
+// 항목 수와 크기 합계가 한도를 넘으면 가장 오래 사용하지 않은 항목부터 제거합니다 (LRU).
+type DiskCache struct {
+	dir        string
+	config     Config
+	defaultTTL time.Duration
+
+	mu    sync.Mutex
+	items map[string]*list.Element // 키 -> lru 항목
+	lru   *list.List               // 최근 사용 순서 (앞쪽이 최근, *diskEntry)
+	stats Stats
+	quit  chan struct{}
+}
+
+// diskEntry는 디스크 캐시 항목의 색인입니다.
+type diskEntry struct {
+	key    string
+	file   string
+	size   int64
+	expiry time.Time
+}
+
+// sameAs는 두 색인 항목이 같은 파일과 만료 시각을 가리키는지 확인합니다.
+func (e *diskEntry) sameAs(other diskEntry) bool {
+	return e.file == other.file && e.expiry.Equal(other.expiry)
+}
+
+// NewDiskCache는 dir에 항목을 저장하는 디스크 캐시를 생성합니다.
+// 디렉터리에 남아 있는 항목은 다시 사용하며, 만료되었거나 읽을 수 없는 항목은 삭제합니다.
+func NewDiskCache(dir string, config Config) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	config = config.withDefaults()
+	cache := &DiskCache{
+		dir:        dir,
+		config:     config,
+		defaultTTL: config.DefaultTTL,
+		items:      make(map[string]*list.Element),
+		lru:        list.New(),
+		quit:       make(chan struct{}),
+	}
+	if err := cache.load(); err != nil {
+		return nil, err
+	}
+
+	// 캐시 만료 클리너 시작
+	go cache.startCleaner()
+
+	return cache, nil
+}
+
+// Get은 캐시에서 키에 해당하는 응답을 가져옵니다.
+// 파일은 잠금 밖에서 읽고, 읽는 동안 색인 항목이 바뀌지 않았는지 다시 확인한 뒤 LRU 순서와 통계를 갱신합니다.
+func (c *DiskCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	element, found := c.items[key]
+	if !found {
+		c.stats.Misses++
+		c.mu.Unlock()
+		return nil, false
+	}
+	item := *element.Value.(*diskEntry)
+	if time.Now().After(item.expiry) {
+		c.removeElement(element)
+		c.stats.Misses++
+		c.mu.Unlock()
+		return nil, false
+	}
+	c.mu.Unlock()
+
+	data, err := os.ReadFile(item.file)
+	var response *CachedResponse
+	if err == nil {
+		var storedKey string
+		storedKey, response, err = decodeEntry(data)
+		if err == nil && storedKey != key {
+			err = fmt.Errorf("stored key mismatch: %s", storedKey)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 읽는 동안 삭제되거나 새 응답으로 교체된 항목은 읽은 내용을 확신할 수 없으므로 조회 실패로 처리
+	element, found = c.items[key]
+	if !found || !element.Value.(*diskEntry).sameAs(item) {
+		c.stats.Misses++
+		return nil, false
+	}
+	if err != nil {
+		log.Printf("[CACHE] 디스크 캐시 항목을 읽을 수 없습니다: key=%s - %v", key, err)
+		c.removeElement(element)
+		c.stats.Misses++
+		return nil, false
+	}
+
+	c.lru.MoveToFront(element)
+	// Vary 목록 항목은 보조 키 조회에서 기록
+	if len(response.Vary) == 0 {
+		c.stats.Hits++
+	}
+	return response, true
+}
+
+// Set은 응답을 캐시에 저장합니다.
+// 항목 크기 제한을 넘는 응답은 저장하지 않으며, 용량을 넘으면 가장 오래 사용하지 않은 항목부터 제거합니다.
+// 파일은 잠금 밖에서 쓰고, 쓴 뒤에 색인을 갱신합니다.
+func (c *DiskCache) Set(key string, response *CachedResponse, ttl time.Duration) {
+	// TTL이 지정되지 않은 경우 기본값 사용
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+
+	stored := response.Clone()
+	stored.Expiry = time.Now().Add(ttl)
+
+	data, err := encodeEntry(key, stored)
+	if err != nil {
+		log.Printf("[CACHE] 응답 직렬화 실패: key=%s - %v", key, err)
+		return
+	}
+	size := int64(len(data))
+
+	c.mu.Lock()
+	// 같은 키의 이전 응답은 새 응답으로 교체되거나, 새 응답을 저장할 수 없으면 더 이상 유효하지 않음
+	if element, found := c.items[key]; found {
+		c.removeElement(element)
+	}
+	if size > c.config.MaxItemBytes {
+		c.stats.Rejected++
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+
+	file := c.fileFor(key)
+	if err := writeFileAtomic(file, data); err != nil {
+		log.Printf("[CACHE] 디스크 캐시 저장 실패: key=%s - %v", key, err)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// 파일을 쓰는 동안 다른 Set이 같은 키를 색인에 추가했으면 파일은 그대로 두고 색인만 교체
+	if element, found := c.items[key]; found {
+		c.unlinkElement(element)
+	}
+	c.items[key] = c.lru.PushFront(&diskEntry{key: key, file: file, size: size, expiry: stored.Expiry})
+	c.stats.Bytes += size
+	c.evict()
+}
+
+// Delete는 캐시에서 키에 해당하는 항목을 삭제합니다.
+func (c *DiskCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, found := c.items[key]; found {
+		c.removeElement(element)
+	}
+}
+
+// Clear는 캐시의 모든 항목을 삭제합니다.
+func (c *DiskCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, element := range c.items {
+		c.removeElement(element)
+	}
+}
+
+// Stats는 캐시 통계를 반환합니다.
+func (c *DiskCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.items)
+	return stats
+}
+
+// Range는 만료되지 않은 모든 항목에 대해 fn을 호출합니다. 호출 시점의 항목 목록을 사용합니다.
+func (c *DiskCache) Range(fn func(Entry) bool) {
+	now := time.Now()
+	c.mu.Lock()
+	items := make([]diskEntry, 0, len(c.items))
+	for element := c.lru.Front(); element != nil; element = element.Next() {
+		if item := element.Value.(*diskEntry); now.Before(item.expiry) {
+			items = append(items, *item)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, item := range items {
+		data, err := os.ReadFile(item.file)
+		if err != nil {
+			continue // 그 사이 삭제된 항목
+		}
+		key, response, err := decodeEntry(data)
+		if err != nil || key != item.key {
+			continue
+		}
+		if !fn(Entry{Key: key, Response: response, Size: item.size}) {
+			return
+		}
+	}
+}
+
+// Close는 캐시 리소스를 정리합니다. 저장된 항목은 디스크에 남습니다.
+func (c *DiskCache) Close() {
+	close(c.quit)
+}
+
+// load는 디렉터리의 항목 파일로 색인을 만듭니다. 최근에 수정된 파일을 최근 사용한 항목으로 간주합니다.
+func (c *DiskCache) load() error {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*"+diskFileSuffix))
+	if err != nil {
+		return err
+	}
+
+	type loaded struct {
+		entry   *diskEntry
+		modTime time.Time
+	}
+	var entries []loaded
+	now := time.Now()
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		key, response, err := decodeEntry(data)
+		if err != nil || now.After(response.Expiry) || c.fileFor(key) != file {
+			os.Remove(file)
+			continue
+		}
+		entries = append(entries, loaded{
+			entry:   &diskEntry{key: key, file: file, size: int64(len(data)), expiry: response.Expiry},
+			modTime: info.ModTime(),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.After(entries[j].modTime) })
+	for _, e := range entries {
+		c.items[e.entry.key] = c.lru.PushBack(e.entry)
+		c.stats.Bytes += e.entry.size
+	}
+	c.evict()
+
+	// 이전 실행에서 남은 임시 파일 정리
+	if temps, err := filepath.Glob(filepath.Join(c.dir, "*.tmp")); err == nil {
+		for _, temp := range temps {
+			os.Remove(temp)
+		}
+	}
+	return nil
+}
+
+// fileFor는 키를 저장할 파일 경로를 반환합니다.
+func (c *DiskCache) fileFor(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+diskFileSuffix)
+}
+
+// evict는 용량을 넘으면 가장 오래 사용하지 않은 항목부터 제거합니다. 호출자는 잠금을 보유해야 합니다.
+func (c *DiskCache) evict() {
+	for len(c.items) > c.config.MaxEntries || c.stats.Bytes > c.config.MaxBytes {
+		c.removeElement(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// removeElement는 항목과 파일을 제거합니다. 호출자는 잠금을 보유해야 합니다.
+func (c *DiskCache) removeElement(element *list.Element) {
+	item := c.unlinkElement(element)
+	if err := os.Remove(item.file); err != nil && !os.IsNotExist(err) {
+		log.Printf("[CACHE] 디스크 캐시 파일 삭제 실패: %s - %v", item.file, err)
+	}
+}
+
+// unlinkElement는 파일은 두고 색인에서 항목을 제거합니다. 호출자는 잠금을 보유해야 합니다.
+func (c *DiskCache) unlinkElement(element *list.Element) *diskEntry {
+	item := c.lru.Remove(element).(*diskEntry)
+	delete(c.items, item.key)
+	c.stats.Bytes -= item.size
+	return item
+}
+
+// startCleaner는 만료된 캐시 항목을 정기적으로 제거하는 백그라운드 작업을 시작합니다.
+func (c *DiskCache) startCleaner() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.cleanExpired()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// cleanExpired는 만료된 캐시 항목을 제거합니다.
+func (c *DiskCache) cleanExpired() {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, element := range c.items {
+		if now.After(element.Value.(*diskEntry).expiry) {
+			c.removeElement(element)
+		}
+	}
+}
+
+// writeFileAtomic은 임시 파일에 쓴 뒤 이름을 바꿔 읽는 쪽이 쓰다 만 파일을 보지 않도록 합니다.
+func writeFileAtomic(file string, data []byte) error {
+	temp, err := os.CreateTemp(filepath.Dir(file), strings.TrimSuffix(filepath.Base(file), diskFileSuffix)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		os.Remove(temp.Name())
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		os.Remove(temp.Name())
+		return err
+	}
+	return os.Rename(temp.Name(), file)
+}
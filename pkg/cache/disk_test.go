@@ -0,0 +1,143 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCache(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		cache, err := NewDiskCache(t.TempDir(), Config{DefaultTTL: time.Minute})
+		require.NoError(t, err)
+		defer cache.Close()
+
+		original := sampleResponse(`{"id":1}`)
+		cache.Set("GET:/a:", original, 0)
+
+		retrieved, found := cache.Get("GET:/a:")
+		require.True(t, found)
+		assert.Equal(t, original.Headers, retrieved.Headers)
+		assert.Equal(t, original.Body, retrieved.Body)
+		assert.Equal(t, original.Lifetime, retrieved.Lifetime)
+
+		cache.Delete("GET:/a:")
+		_, found = cache.Get("GET:/a:")
+		assert.False(t, found)
+		assert.Equal(t, 0, cache.Stats().Entries)
+	})
+
+	t.Run("PersistsAcrossRestart", func(t *testing.T) {
+		dir := t.TempDir()
+		cache, err := NewDiskCache(dir, Config{DefaultTTL: time.Minute})
+		require.NoError(t, err)
+		cache.Set("kept", sampleResponse("kept"), 0)
+		cache.Set("expired", sampleResponse("expired"), 50*time.Millisecond)
+		cache.Close()
+
+		// 깨진 파일과 남은 임시 파일은 시작 시 정리
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "broken"+diskFileSuffix), []byte("{"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "partial.tmp"), []byte("x"), 0644))
+		time.Sleep(100 * time.Millisecond)
+
+		reopened, err := NewDiskCache(dir, Config{DefaultTTL: time.Minute})
+		require.NoError(t, err)
+		defer reopened.Close()
+
+		retrieved, found := reopened.Get("kept")
+		require.True(t, found, "재시작 후에도 캐시 항목을 사용할 수 있어야 함")
+		assert.Equal(t, "kept", string(retrieved.Body))
+		_, found = reopened.Get("expired")
+		assert.False(t, found)
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, files, 1, "만료되었거나 읽을 수 없는 파일은 삭제되어야 함")
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		cache, err := NewDiskCache(t.TempDir(), Config{DefaultTTL: time.Minute, MaxEntries: 2, MaxItemBytes: 1024})
+		require.NoError(t, err)
+		defer cache.Close()
+
+		cache.Set("a", sampleResponse("a"), 0)
+		cache.Set("b", sampleResponse("b"), 0)
+		cache.Get("a")
+		cache.Set("c", sampleResponse("c"), 0)
+
+		_, found := cache.Get("b")
+		assert.False(t, found, "가장 오래 사용하지 않은 항목이 제거되어야 함")
+		_, found = cache.Get("a")
+		assert.True(t, found)
+
+		cache.Set("large", sampleResponse(string(make([]byte, 2048))), 0)
+		_, found = cache.Get("large")
+		assert.False(t, found)
+
+		stats := cache.Stats()
+		assert.Equal(t, uint64(1), stats.Evictions)
+		assert.Equal(t, uint64(1), stats.Rejected)
+		assert.Equal(t, 2, stats.Entries)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		dir := t.TempDir()
+		cache, err := NewDiskCache(dir, Config{DefaultTTL: time.Minute})
+		require.NoError(t, err)
+		defer cache.Close()
+
+		cache.Set("a", sampleResponse("a"), 0)
+		cache.Set("b", sampleResponse("b"), 0)
+		cache.Clear()
+
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+		assert.Equal(t, Stats{}, cache.Stats())
+	})
+
+	t.Run("ConcurrentAccess", func(t *testing.T) {
+		dir := t.TempDir()
+		cache, err := NewDiskCache(dir, Config{DefaultTTL: time.Minute})
+		require.NoError(t, err)
+		defer cache.Close()
+
+		var wg sync.WaitGroup
+		for worker := 0; worker < 8; worker++ {
+			wg.Add(1)
+			go func(worker int) {
+				defer wg.Done()
+				for i := 0; i < 50; i++ {
+					key := fmt.Sprintf("key-%d", i%4)
+					cache.Set(key, sampleResponse(key), 0)
+					if response, found := cache.Get(key); found {
+						assert.Equal(t, key, string(response.Body), "다른 키의 응답이 반환되면 안 됩니다")
+					}
+				}
+			}(worker)
+		}
+		wg.Wait()
+
+		// 경합이 끝난 뒤 색인과 파일이 일치해야 함
+		stats := cache.Stats()
+		assert.Equal(t, 4, stats.Entries)
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, files, 4)
+		for i := 0; i < 4; i++ {
+			key := fmt.Sprintf("key-%d", i)
+			response, found := cache.Get(key)
+			require.True(t, found, key)
+			assert.Equal(t, key, string(response.Body))
+		}
+	})
+}
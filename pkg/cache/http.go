@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheControl은 Cache-Control 헤더의 지시어(소문자 이름 -> 값)입니다.
+type CacheControl map[string]string
+
+// ParseCacheControl은 Cache-Control 헤더 값들을 지시어로 분석합니다.
+func ParseCacheControl(values []string) CacheControl {
+	cc := make(CacheControl)
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			directive = strings.TrimSpace(directive)
+			if directive == "" {
+				continue
+			}
+
+			name, arg, _ := strings.Cut(directive, "=")
+			cc[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), `"`)
+		}
+	}
+	return cc
+}
+
+// Has는 지시어가 있는지 확인합니다.
+func (cc CacheControl) Has(name string) bool {
+	_, ok := cc[name]
+	return ok
+}
+
+// Seconds는 초 단위 값을 가진 지시어(max-age 등)를 반환합니다.
+func (cc CacheControl) Seconds(name string) (time.Duration, bool) {
+	value, ok := cc[name]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// Freshness는 응답 헤더로 계산한 공유 캐시의 신선도 정보입니다 (RFC 9111).
+type Freshness struct {
+	Lifetime             time.Duration // 신선도 유지 기간
+	InitialAge           time.Duration // 응답을 받을 때의 나이 (Age 헤더)
+	Explicit             bool          // s-maxage, max-age, Expires로 명시되었는지 여부
+	MustRevalidate       bool          // 만료 후 재검증 없이 사용할 수 없음
+	StaleWhileRevalidate time.Duration // 만료 후 백그라운드 재검증 동안 사용할 수 있는 기간
+	StaleIfError         time.Duration // 만료 후 업스트림 오류 시 사용할 수 있는 기간
+}
+
+// ComputeFreshness는 응답 헤더로 신선도 정보를 계산합니다.
+// 명시적인 만료 정보가 없으면 heuristic을 신선도 유지 기간으로 사용합니다.
+func ComputeFreshness(header http.Header, now time.Time, heuristic time.Duration) Freshness {
+	cc := ParseCacheControl(header.Values("Cache-Control"))
+	f := Freshness{Lifetime: heuristic}
+
+	if age, err := strconv.ParseInt(header.Get("Age"), 10, 64); err == nil && age > 0 {
+		f.InitialAge = time.Duration(age) * time.Second
+	}
+
+	// 공유 캐시는 s-maxage, max-age, Expires 순으로 사용
+	if sMaxAge, ok := cc.Seconds("s-maxage"); ok {
+		f.Lifetime, f.Explicit = sMaxAge, true
+		f.MustRevalidate = true // s-maxage는 proxy-revalidate를 포함
+	} else if maxAge, ok := cc.Seconds("max-age"); ok {
+		f.Lifetime, f.Explicit = maxAge, true
+	} else if expires := header.Get("Expires"); expires != "" {
+		f.Explicit = true
+		f.Lifetime = 0 // 잘못된 형식의 Expires는 이미 만료된 것으로 간주
+		if expiresAt, err := http.ParseTime(expires); err == nil {
+			date := now
+			if d, err := http.ParseTime(header.Get("Date")); err == nil {
+				date = d
+			}
+			if expiresAt.After(date) {
+				f.Lifetime = expiresAt.Sub(date)
+			}
+		}
+	}
+
+	// no-cache는 저장할 수 있지만 매번 재검증해야 함
+	if cc.Has("no-cache") {
+		f.Lifetime = 0
+		f.MustRevalidate = true
+	}
+	if cc.Has("must-revalidate") || cc.Has("proxy-revalidate") {
+		f.MustRevalidate = true
+	}
+
+	if !f.MustRevalidate {
+		f.StaleWhileRevalidate, _ = cc.Seconds("stale-while-revalidate")
+		f.StaleIfError, _ = cc.Seconds("stale-if-error")
+	}
+
+	return f
+}
+
+// Age는 현재 시각의 응답 나이를 반환합니다.
+func (r *CachedResponse) Age(now time.Time) time.Duration {
+	age := r.InitialAge + now.Sub(r.StoredAt)
+	if age < 0 {
+		return 0
+	}
+	return age
+}
+
+// IsFresh는 응답이 아직 신선한지 확인합니다.
+func (r *CachedResponse) IsFresh(now time.Time) bool {
+	return r.Age(now) < r.Lifetime
+}
+
+// staleness는 신선도 유지 기간이 지난 시간을 반환합니다.
+func (r *CachedResponse) staleness(now time.Time) time.Duration {
+	return r.Age(now) - r.Lifetime
+}
+
+// CanServeWhileRevalidating은 만료된 응답을 백그라운드 재검증 동안 사용할 수 있는지 확인합니다 (stale-while-revalidate).
+func (r *CachedResponse) CanServeWhileRevalidating(now time.Time) bool {
+	return !r.MustRevalidate && r.staleness(now) < r.StaleWhileRevalidate
+}
+
+// CanServeOnError는 업스트림 오류 시 만료된 응답을 사용할 수 있는지 확인합니다 (stale-if-error).
+func (r *CachedResponse) CanServeOnError(now time.Time) bool {
+	return !r.MustRevalidate && r.staleness(now) < r.StaleIfError
+}
+
+// HasValidators는 조건부 요청으로 재검증할 수 있는 검증자(ETag, Last-Modified)가 있는지 확인합니다.
+func (r *CachedResponse) HasValidators() bool {
+	return r.Headers.Get("ETag") != "" || r.Headers.Get("Last-Modified") != ""
+}
+
+// NotModified는 클라이언트의 조건부 요청 헤더(If-None-Match, If-Modified-Since)가
+// 캐시된 응답과 일치하여 304로 응답할 수 있는지 확인합니다 (RFC 9110 13.2.2).
+func (r *CachedResponse) NotModified(req *http.Request) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		etag := r.Headers.Get("ETag")
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || weakETag(candidate) == weakETag(etag) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := req.Header.Get("If-Modified-Since"); ims != "" {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+		modified, err := http.ParseTime(r.Headers.Get("Last-Modified"))
+		if err != nil {
+			return false
+		}
+		return !modified.After(since)
+	}
+
+	return false
+}
+
+// weakETag는 약한 비교를 위해 W/ 접두사를 제거합니다.
+func weakETag(etag string) string {
+	return strings.TrimPrefix(strings.TrimSpace(etag), "W/")
+}
+
+// ApplyFreshness는 신선도 정보를 응답에 반영합니다.
+func (r *CachedResponse) ApplyFreshness(f Freshness, storedAt time.Time) {
+	r.StoredAt = storedAt
+	r.InitialAge = f.InitialAge
+	r.Lifetime = f.Lifetime
+	r.MustRevalidate = f.MustRevalidate
+	r.StaleWhileRevalidate = f.StaleWhileRevalidate
+	r.StaleIfError = f.StaleIfError
+}
+
+// StorageTTL은 응답을 저장소에 보관할 기간을 반환합니다.
+// 만료 후 stale-while-revalidate/stale-if-error 기간과, 검증자가 있으면 재검증용 보관 기간(revalidateFor)을 더합니다.
+func (r *CachedResponse) StorageTTL(now time.Time, revalidateFor time.Duration) time.Duration {
+	ttl := r.Lifetime - r.Age(now)
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	grace := r.StaleWhileRevalidate
+	if r.StaleIfError > grace {
+		grace = r.StaleIfError
+	}
+	if r.HasValidators() && revalidateFor > grace {
+		grace = revalidateFor
+	}
+	return ttl + grace
+}
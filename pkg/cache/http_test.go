@@ -0,0 +1,94 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeFreshness(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Precedence", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Cache-Control", "max-age=60, s-maxage=30")
+		header.Set("Expires", now.Add(time.Hour).Format(http.TimeFormat))
+		f := ComputeFreshness(header, now, time.Minute)
+		assert.Equal(t, 30*time.Second, f.Lifetime, "공유 캐시는 s-maxage를 우선 사용해야 함")
+		assert.True(t, f.MustRevalidate)
+
+		header.Set("Cache-Control", "max-age=60")
+		assert.Equal(t, 60*time.Second, ComputeFreshness(header, now, time.Minute).Lifetime)
+
+		header.Del("Cache-Control")
+		header.Set("Date", now.Add(-10*time.Minute).Format(http.TimeFormat))
+		assert.Equal(t, 70*time.Minute, ComputeFreshness(header, now, time.Minute).Lifetime, "Expires - Date")
+
+		header.Set("Expires", "0")
+		assert.Equal(t, time.Duration(0), ComputeFreshness(header, now, time.Minute).Lifetime, "잘못된 Expires는 만료된 것으로 간주")
+	})
+
+	t.Run("Heuristic", func(t *testing.T) {
+		f := ComputeFreshness(http.Header{}, now, time.Minute)
+		assert.Equal(t, time.Minute, f.Lifetime)
+		assert.False(t, f.Explicit)
+	})
+
+	t.Run("StaleDirectives", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Cache-Control", `max-age=10, stale-while-revalidate=30, stale-if-error="60"`)
+		f := ComputeFreshness(header, now, time.Minute)
+		assert.Equal(t, 30*time.Second, f.StaleWhileRevalidate)
+		assert.Equal(t, 60*time.Second, f.StaleIfError)
+
+		header.Set("Cache-Control", "max-age=10, must-revalidate, stale-while-revalidate=30")
+		f = ComputeFreshness(header, now, time.Minute)
+		assert.Zero(t, f.StaleWhileRevalidate, "must-revalidate 응답은 만료 후 사용할 수 없음")
+
+		header.Set("Cache-Control", "max-age=10, no-cache")
+		f = ComputeFreshness(header, now, time.Minute)
+		assert.Zero(t, f.Lifetime)
+		assert.True(t, f.MustRevalidate)
+	})
+
+	t.Run("AgeAndStorageTTL", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("Cache-Control", "max-age=60, stale-if-error=30")
+		header.Set("Age", "20")
+		response := &CachedResponse{Headers: header}
+		response.ApplyFreshness(ComputeFreshness(header, now, time.Minute), now)
+
+		later := now.Add(10 * time.Second)
+		assert.Equal(t, 30*time.Second, response.Age(later))
+		assert.True(t, response.IsFresh(later))
+		assert.Equal(t, 60*time.Second, response.StorageTTL(later, time.Minute), "남은 신선도 30초 + stale-if-error 30초")
+
+		expired := now.Add(50 * time.Second)
+		assert.False(t, response.IsFresh(expired))
+		assert.True(t, response.CanServeOnError(expired))
+		assert.False(t, response.CanServeWhileRevalidating(expired))
+	})
+}
+
+func TestNotModified(t *testing.T) {
+	response := &CachedResponse{Headers: http.Header{}}
+	response.Headers.Set("ETag", `W/"abc"`)
+	response.Headers.Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+
+	request := func(name, value string) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(name, value)
+		return req
+	}
+
+	assert.True(t, response.NotModified(request("If-None-Match", `"abc"`)), "약한 비교")
+	assert.True(t, response.NotModified(request("If-None-Match", "*")))
+	assert.False(t, response.NotModified(request("If-None-Match", `"other"`)))
+	assert.True(t, response.NotModified(request("If-Modified-Since", "Mon, 02 Jan 2006 15:04:05 GMT")))
+	assert.False(t, response.NotModified(request("If-Modified-Since", "Sun, 01 Jan 2006 15:04:05 GMT")))
+}
@@ -0,0 +1,231 @@
+package cache
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/isinthesky/api-gateway/pkg/redis"
+)
+
+// RedisConfig는 Redis 캐시 설정입니다.
+type RedisConfig struct {
+	Addr         string        // host:port
+	Password     string        // AUTH 비밀번호 (비어 있으면 인증하지 않음)
+	DB           int           // SELECT할 데이터베이스 번호
+	KeyPrefix    string        // 모든 키 앞에 붙일 접두사 (기본 "cache:")
+	Timeout      time.Duration // 명령별 읽기/쓰기 타임아웃 (기본 100ms)
+	DefaultTTL   time.Duration // TTL을 지정하지 않은 항목의 수명
+	MaxItemBytes int64         // 항목 하나의 최대 크기 (바이트, 기본 1MB)
+}
+
+// RedisCache는 Redis 프로토콜(RESP)을 사용하는 서버에 응답을 저장하는 캐시 구현체입니다.
+// 여러 게이트웨이 인스턴스가 캐시를 공유하며, 항목 만료와 용량 관리는 서버(PX, maxmemory 정책)가 담당합니다.
+// 저장소 오류는 캐시 미스로 처리합니다.
+type RedisCache struct {
+	config RedisConfig
+	client *redis.Client
+
+	mu    sync.Mutex
+	stats Stats // 이 인스턴스의 조회/거부 횟수 (항목 수와 크기는 집계하지 않음)
+}
+
+// NewRedisCache는 Redis 캐시를 생성합니다. 연결은 첫 요청 시 수립됩니다.
+func NewRedisCache(config RedisConfig) *RedisCache {
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "cache:"
+	}
+	if config.MaxItemBytes <= 0 {
+		config.MaxItemBytes = DefaultMaxItemBytes
+	}
+
+	return &RedisCache{
+		config: config,
+		client: redis.NewClient(redis.Config{
+			Addr:     config.Addr,
+			Password: config.Password,
+			DB:       config.DB,
+			Timeout:  config.Timeout,
+		}),
+	}
+}
+
+// Get은 캐시에서 키에 해당하는 응답을 가져옵니다.
+func (c *RedisCache) Get(key string) (*CachedResponse, bool) {
+	reply, err := c.client.Do(context.Background(), "GET", c.config.KeyPrefix+key)
+	if err != nil {
+		log.Printf("[CACHE] Redis 조회 실패: key=%s - %v", key, err)
+	}
+
+	data, _ := reply.(string)
+	if data == "" {
+		c.count(func(s *Stats) { s.Misses++ })
+		return nil, false
+	}
+
+	storedKey, response, err := decodeEntry([]byte(data))
+	if err != nil || storedKey != key || time.Now().After(response.Expiry) {
+		c.count(func(s *Stats) { s.Misses++ })
+		return nil, false
+	}
+
+	// Vary 목록 항목은 보조 키 조회에서 기록
+	if len(response.Vary) == 0 {
+		c.count(func(s *Stats) { s.Hits++ })
+	}
+	return response, true
+}
+
+// Set은 응답을 캐시에 저장합니다. 항목 크기 제한을 넘는 응답은 저장하지 않습니다.
+func (c *RedisCache) Set(key string, response *CachedResponse, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.config.DefaultTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	stored := response.Clone()
+	stored.Expiry = time.Now().Add(ttl)
+
+	data, err := encodeEntry(key, stored)
+	if err != nil {
+		log.Printf("[CACHE] 응답 직렬화 실패: key=%s - %v", key, err)
+		return
+	}
+
+	if int64(len(key)+len(data)) > c.config.MaxItemBytes {
+		c.count(func(s *Stats) { s.Rejected++ })
+		c.Delete(key)
+		return
+	}
+
+	millis := ttl.Milliseconds()
+	if millis <= 0 {
+		millis = 1
+	}
+	if _, err := c.client.Do(context.Background(), "SET", c.config.KeyPrefix+key, string(data), "PX", strconv.FormatInt(millis, 10)); err != nil {
+		log.Printf("[CACHE] Redis 저장 실패: key=%s - %v", key, err)
+	}
+}
+
+// Delete는 캐시에서 키에 해당하는 항목을 삭제합니다.
+func (c *RedisCache) Delete(key string) {
+	if _, err := c.client.Do(context.Background(), "DEL", c.config.KeyPrefix+key); err != nil {
+		log.Printf("[CACHE] Redis 삭제 실패: key=%s - %v", key, err)
+	}
+}
+
+// Clear는 접두사가 같은 모든 항목을 삭제합니다.
+func (c *RedisCache) Clear() {
+	keys, err := c.scan(escapeGlob(c.config.KeyPrefix) + "*")
+	if err != nil {
+		log.Printf("[CACHE] Redis 항목 조회 실패: %v", err)
+		return
+	}
+
+	for start := 0; start < len(keys); start += 100 {
+		end := start + 100
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if _, err := c.client.Do(context.Background(), append([]string{"DEL"}, keys[start:end]...)...); err != nil {
+			log.Printf("[CACHE] Redis 삭제 실패: %v", err)
+			return
+		}
+	}
+}
+
+// Range는 접두사가 같은 모든 항목에 대해 fn을 호출합니다.
+// 다른 게이트웨이 인스턴스가 저장한 항목도 포함됩니다.
+func (c *RedisCache) Range(fn func(Entry) bool) {
+	keys, err := c.scan(escapeGlob(c.config.KeyPrefix) + "*")
+	if err != nil {
+		log.Printf("[CACHE] Redis 항목 조회 실패: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, stored := range keys {
+		reply, err := c.client.Do(context.Background(), "GET", stored)
+		if err != nil {
+			log.Printf("[CACHE] Redis 조회 실패: key=%s - %v", stored, err)
+			return
+		}
+		data, _ := reply.(string)
+		if data == "" {
+			continue // 그 사이 만료되거나 삭제된 항목
+		}
+
+		key, response, err := decodeEntry([]byte(data))
+		if err != nil || c.config.KeyPrefix+key != stored || now.After(response.Expiry) {
+			continue
+		}
+		if !fn(Entry{Key: key, Response: response, Size: int64(len(stored) + len(data))}) {
+			return
+		}
+	}
+}
+
+// Stats는 이 인스턴스의 캐시 통계를 반환합니다.
+func (c *RedisCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Close는 연결을 모두 닫습니다.
+func (c *RedisCache) Close() {
+	c.client.Close()
+}
+
+// scan은 SCAN으로 패턴과 일치하는 모든 키를 조회합니다.
+func (c *RedisCache) scan(pattern string) ([]string, error) {
+	var keys []string
+	cursor := "0"
+	for {
+		reply, err := c.client.Do(context.Background(), "SCAN", cursor, "MATCH", pattern, "COUNT", "100")
+		if err != nil {
+			return nil, err
+		}
+
+		values, ok := reply.([]interface{})
+		if !ok || len(values) != 2 {
+			return nil, redis.Error("unexpected SCAN reply")
+		}
+		cursor, _ = values[0].(string)
+		batch, _ := values[1].([]interface{})
+		for _, key := range batch {
+			if s, ok := key.(string); ok {
+				keys = append(keys, s)
+			}
+		}
+
+		if cursor == "0" || cursor == "" {
+			return keys, nil
+		}
+	}
+}
+
+// count는 잠금을 보유한 상태로 통계를 갱신합니다.
+func (c *RedisCache) count(update func(*Stats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	update(&c.stats)
+}
+
+// escapeGlob은 Redis 패턴의 특수 문자를 이스케이프합니다.
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,131 @@
+//go:build unit
+// +build unit
+
+package cache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/tests/mocks"
+)
+
+// newRedisFake는 테스트용 Redis 프로토콜 서버를 시작합니다.
+func newRedisFake(t *testing.T, password string) *mocks.RedisServer {
+	server, err := mocks.NewRedisServer(password)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// sampleResponse는 헤더와 신선도 정보를 가진 테스트 응답을 생성합니다.
+func sampleResponse(body string) *CachedResponse {
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	headers.Add("Vary", "Accept-Encoding")
+	headers.Add("Vary", "Accept-Language")
+	return &CachedResponse{
+		StatusCode:           http.StatusOK,
+		Headers:              headers,
+		Body:                 []byte(body),
+		StoredAt:             time.Now().Truncate(time.Second),
+		InitialAge:           5 * time.Second,
+		Lifetime:             time.Minute,
+		StaleWhileRevalidate: 30 * time.Second,
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		server := newRedisFake(t, "secret")
+		cache := NewRedisCache(RedisConfig{Addr: server.Addr(), Password: "secret", DefaultTTL: time.Minute})
+		defer cache.Close()
+
+		original := sampleResponse(`{"id":1}`)
+		cache.Set("GET:/a:", original, 0)
+
+		retrieved, found := cache.Get("GET:/a:")
+		require.True(t, found)
+		assert.Equal(t, original.StatusCode, retrieved.StatusCode)
+		assert.Equal(t, original.Headers, retrieved.Headers, "여러 값을 가진 헤더까지 복원되어야 함")
+		assert.Equal(t, original.Body, retrieved.Body)
+		assert.True(t, original.StoredAt.Equal(retrieved.StoredAt))
+		assert.Equal(t, original.InitialAge, retrieved.InitialAge)
+		assert.Equal(t, original.Lifetime, retrieved.Lifetime)
+		assert.Equal(t, original.StaleWhileRevalidate, retrieved.StaleWhileRevalidate)
+
+		_, found = cache.Get("GET:/b:")
+		assert.False(t, found)
+		assert.Equal(t, Stats{Hits: 1, Misses: 1}, cache.Stats())
+	})
+
+	t.Run("SharedBetweenInstances", func(t *testing.T) {
+		server := newRedisFake(t, "")
+		first := NewRedisCache(RedisConfig{Addr: server.Addr(), DefaultTTL: time.Minute})
+		defer first.Close()
+		second := NewRedisCache(RedisConfig{Addr: server.Addr(), DefaultTTL: time.Minute})
+		defer second.Close()
+
+		first.Set("key", sampleResponse("shared"), 0)
+		retrieved, found := second.Get("key")
+		require.True(t, found, "다른 게이트웨이 인스턴스의 캐시 항목을 사용할 수 있어야 함")
+		assert.Equal(t, "shared", string(retrieved.Body))
+
+		second.Delete("key")
+		_, found = first.Get("key")
+		assert.False(t, found)
+	})
+
+	t.Run("ExpiryAndLimits", func(t *testing.T) {
+		server := newRedisFake(t, "")
+		cache := NewRedisCache(RedisConfig{Addr: server.Addr(), MaxItemBytes: 512})
+		defer cache.Close()
+
+		cache.Set("short", sampleResponse("short"), 50*time.Millisecond)
+		_, found := cache.Get("short")
+		require.True(t, found)
+		time.Sleep(100 * time.Millisecond)
+		_, found = cache.Get("short")
+		assert.False(t, found, "TTL이 지나면 만료되어야 함")
+
+		cache.Set("large", sampleResponse(string(make([]byte, 1024))), time.Minute)
+		_, found = cache.Get("large")
+		assert.False(t, found, "항목 크기 제한을 넘는 응답은 저장하지 않아야 함")
+		assert.Equal(t, uint64(1), cache.Stats().Rejected)
+	})
+
+	t.Run("ClearOnlyPrefix", func(t *testing.T) {
+		server := newRedisFake(t, "")
+		gateway := NewRedisCache(RedisConfig{Addr: server.Addr(), KeyPrefix: "gw:", DefaultTTL: time.Minute})
+		defer gateway.Close()
+		other := NewRedisCache(RedisConfig{Addr: server.Addr(), KeyPrefix: "other:", DefaultTTL: time.Minute})
+		defer other.Close()
+
+		gateway.Set("a", sampleResponse("a"), 0)
+		gateway.Set("b", sampleResponse("b"), 0)
+		other.Set("a", sampleResponse("other"), 0)
+
+		gateway.Clear()
+		_, found := gateway.Get("a")
+		assert.False(t, found)
+		_, found = gateway.Get("b")
+		assert.False(t, found)
+		_, found = other.Get("a")
+		assert.True(t, found, "다른 접두사의 항목은 유지되어야 함")
+	})
+
+	t.Run("ServerUnavailable", func(t *testing.T) {
+		server := newRedisFake(t, "")
+		cache := NewRedisCache(RedisConfig{Addr: server.Addr(), DefaultTTL: time.Minute})
+		defer cache.Close()
+		server.Close()
+
+		cache.Set("key", sampleResponse("body"), 0)
+		_, found := cache.Get("key")
+		assert.False(t, found, "저장소 오류는 캐시 미스로 처리해야 함")
+	})
+}
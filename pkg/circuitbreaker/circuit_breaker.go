@@ -29,6 +29,21 @@ type Config struct {
 	TimeoutDuration  time.Duration // 열림 상태에서 반열림으로 전환하는 시간
 	HalfOpenMaxReqs  int           // 반열림 상태에서 허용할 최대 요청 수
 	SuccessThreshold int           // 닫힘 상태로 돌아가기 위한 연속 성공 횟수
+
+	// 실패 분류 설정
+	FailureStatusCodes []int         // 실패로 기록할 응답 상태 코드 (결과가 *http.Response인 경우)
+	SlowCallDuration   time.Duration // 이 시간 이상 걸린 호출을 지연 호출로 기록 (0이면 비활성화)
+	FailureErrorTypes  []string      // 실패로 기록할 오류 유형 (비어 있으면 모든 오류, 그 외 오류는 기록하지 않음)
+
+	// 지연 호출 비율 임계값 (0.0-1.0)
+	// 0이면 지연 호출을 실패로 기록하고, 지정하면 지연 호출 비율을 별도로 판단
+	SlowCallRateThreshold float64
+
+	// 슬라이딩 윈도우 설정 (오류율과 지연 호출 비율은 윈도우 내 호출로 계산)
+	WindowType     string        // count(기본) 또는 time
+	WindowSize     int           // count 윈도우의 호출 수
+	WindowDuration time.Duration // time 윈도우의 길이
+	WindowBuckets  int           // time 윈도우의 버킷 수
 }
 
 // CircuitBreaker는 서킷 브레이커 패턴을 구현하는 구조체입니다.
@@ -38,9 +53,8 @@ type CircuitBreaker struct {
 	mutex           sync.RWMutex  // 동시성 제어용 뮤텍스
 	
 	// 메트릭 관련 필드
-	successCount    int64         // 성공한 요청 수
-	failureCount    int64         // 실패한 요청 수
-	requestCount    int64         // 총 요청 수
+	window          slidingWindow // 최근 호출 결과 (오류율/지연 호출 비율 계산용)
+	requestCount    int64         // 반열림 상태에서 허용한 요청 수
 	consecutiveSuccesses int64    // 연속 성공 횟수
 	
 	// 시간 관련 필드
@@ -66,11 +80,24 @@ func New(config Config) *CircuitBreaker {
 	if config.SuccessThreshold <= 0 {
 		config.SuccessThreshold = 2 // 기본 2번 연속 성공
 	}
+	if config.WindowType != WindowTypeTime {
+		config.WindowType = WindowTypeCount
+	}
+	if config.WindowSize <= 0 {
+		config.WindowSize = 100 // 기본 최근 100개 호출
+	}
+	if config.WindowDuration <= 0 {
+		config.WindowDuration = 60 * time.Second // 기본 최근 1분
+	}
+	if config.WindowBuckets <= 0 {
+		config.WindowBuckets = 10 // 기본 10개 버킷
+	}
 
 	now := time.Now()
 	return &CircuitBreaker{
 		state:           StateClosed,
 		config:          config,
+		window:          newWindow(config),
 		lastStateChange: now,
 		timeoutStart:    now,
 	}
@@ -115,24 +142,28 @@ func (cb *CircuitBreaker) GetState() string {
 	}
 }
 
+// Config는 기본값이 적용된 서킷 브레이커 설정을 반환합니다.
+func (cb *CircuitBreaker) Config() Config {
+	return cb.config
+}
+
 // GetMetrics는 서킷 브레이커의 현재 메트릭을 반환합니다.
 func (cb *CircuitBreaker) GetMetrics() map[string]interface{} {
 	cb.mutex.RLock()
 	defer cb.mutex.RUnlock()
 	
-	// 에러율 계산
-	totalRequests := cb.successCount + cb.failureCount
-	errorRate := float64(0)
-	if totalRequests > 0 {
-		errorRate = float64(cb.failureCount) / float64(totalRequests)
-	}
+	// 슬라이딩 윈도우 기준 집계
+	stats := cb.window.stats(time.Now())
 	
 	return map[string]interface{}{
 		"state": cb.GetState(),
-		"total_requests": totalRequests,
-		"success_count": cb.successCount,
-		"failure_count": cb.failureCount,
-		"error_rate": errorRate,
+		"total_requests": stats.total,
+		"success_count": stats.total - stats.failures,
+		"failure_count": stats.failures,
+		"error_rate": stats.failureRate(),
+		"slow_call_count": stats.slow,
+		"slow_call_rate": stats.slowCallRate(),
+		"window_type": cb.config.WindowType,
 		"consecutive_successes": cb.consecutiveSuccesses,
 		"last_state_change": cb.lastStateChange,
 		"timeout_start": cb.timeoutStart,
@@ -145,8 +176,7 @@ func (cb *CircuitBreaker) Reset() {
 	defer cb.mutex.Unlock()
 	
 	atomic.StoreInt32(&cb.state, StateClosed)
-	cb.successCount = 0
-	cb.failureCount = 0
+	cb.window.reset()
 	cb.requestCount = 0
 	cb.consecutiveSuccesses = 0
 	cb.lastStateChange = time.Now()
@@ -154,16 +184,16 @@ func (cb *CircuitBreaker) Reset() {
 
 // executeClosed는 닫힘 상태에서 함수를 실행합니다.
 func (cb *CircuitBreaker) executeClosed(fn func() (interface{}, error)) (interface{}, error) {
+	start := time.Now()
 	result, err := fn()
 	
-	// 요청 결과 처리
-	if err != nil {
-		cb.recordFailure()
-		return result, err
+	// 요청 결과 처리 (상태 전이는 뮤텍스 해제 후 호출하여 다음 요청부터 즉시 반영)
+	outcome, slow := cb.classify(result, err, time.Since(start))
+	if outcome != outcomeIgnored && cb.record(outcome == outcomeFailure, slow) {
+		cb.transitionToOpen()
 	}
 	
-	cb.recordSuccess()
-	return result, nil
+	return result, err
 }
 
 // executeHalfOpen는 반열림 상태에서 함수를 실행합니다.
@@ -177,25 +207,31 @@ func (cb *CircuitBreaker) executeHalfOpen(fn func() (interface{}, error)) (inter
 	atomic.AddInt64(&cb.requestCount, 1)
 	
 	// 함수 실행
+	start := time.Now()
 	result, err := fn()
 	
 	// 결과 처리
-	if err != nil {
-		cb.recordFailure()
+	outcome, slow := cb.classify(result, err, time.Since(start))
+	switch outcome {
+	case outcomeFailure:
+		cb.record(true, slow)
 		// 실패시 다시 열림 상태로 전환
 		cb.transitionToOpen()
-		return result, err
-	}
-	
-	// 성공 처리
-	cb.recordSuccess()
-	
-	// 연속 성공 확인
-	if atomic.LoadInt64(&cb.consecutiveSuccesses) >= int64(cb.config.SuccessThreshold) {
-		cb.transitionToClosed()
+		
+	case outcomeSuccess:
+		cb.record(false, slow)
+		
+		// 연속 성공 확인
+		if atomic.LoadInt64(&cb.consecutiveSuccesses) >= int64(cb.config.SuccessThreshold) {
+			cb.transitionToClosed()
+		}
+
+	case outcomeIgnored:
+		// 판단에 사용하지 않는 결과 (예: 취소된 요청)는 시험 요청 슬롯을 반환하여 다른 요청이 시험할 수 있도록 함
+		atomic.AddInt64(&cb.requestCount, -1)
 	}
 	
-	return result, nil
+	return result, err
 }
 
 // executeOpen는 열림 상태에서 함수를 실행합니다.
@@ -261,56 +297,44 @@ func (cb *CircuitBreaker) transitionToClosed() {
 	cb.lastStateChange = time.Now()
 	cb.requestCount = 0
 	cb.consecutiveSuccesses = 0
-	cb.successCount = 0
-	cb.failureCount = 0
+	cb.window.reset()
 }
 
 // 실행 결과 기록 함수들
 
-// recordSuccess는 성공적인 요청을 기록합니다.
-func (cb *CircuitBreaker) recordSuccess() {
+// record는 요청 결과를 슬라이딩 윈도우에 기록합니다.
+// 열림 상태로 전환해야 하면 true를 반환합니다.
+func (cb *CircuitBreaker) record(failure, slow bool) bool {
 	cb.mutex.Lock()
 	defer cb.mutex.Unlock()
 	
-	atomic.AddInt64(&cb.successCount, 1)
-	atomic.StoreInt64(&cb.consecutiveSuccesses, atomic.LoadInt64(&cb.consecutiveSuccesses)+1)
-	
-	// 닫힘 상태에서 오류율 확인
-	if atomic.LoadInt32(&cb.state) == StateClosed {
-		totalRequests := cb.successCount + cb.failureCount
-		
-		// 최소 요청 수 충족 및 오류율 임계값 초과 시 열림 상태로 전환
-		if totalRequests >= int64(cb.config.MinRequests) {
-			errorRate := float64(cb.failureCount) / float64(totalRequests)
-			if errorRate >= cb.config.ErrorThreshold {
-				// 뮤텍스 외부에서 상태 전이 호출
-				go cb.transitionToOpen()
-			}
-		}
+	now := time.Now()
+	cb.window.record(failure, slow, now)
+	if failure {
+		atomic.StoreInt64(&cb.consecutiveSuccesses, 0)
+	} else {
+		atomic.AddInt64(&cb.consecutiveSuccesses, 1)
 	}
+	
+	return cb.shouldOpen(now)
 }
 
-// recordFailure는 실패한 요청을 기록합니다.
-func (cb *CircuitBreaker) recordFailure() {
-	cb.mutex.Lock()
-	defer cb.mutex.Unlock()
-	
-	atomic.AddInt64(&cb.failureCount, 1)
-	atomic.StoreInt64(&cb.consecutiveSuccesses, 0)
-	
-	// 닫힘 상태에서 오류율 확인
-	if atomic.LoadInt32(&cb.state) == StateClosed {
-		totalRequests := cb.successCount + cb.failureCount
-		
-		// 최소 요청 수 충족 및 오류율 임계값 초과 시 열림 상태로 전환
-		if totalRequests >= int64(cb.config.MinRequests) {
-			errorRate := float64(cb.failureCount) / float64(totalRequests)
-			if errorRate >= cb.config.ErrorThreshold {
-				// 뮤텍스 외부에서 상태 전이 호출
-				go cb.transitionToOpen()
-			}
-		}
+// shouldOpen은 닫힘 상태에서 윈도우 내 오류율 또는 지연 호출 비율이 임계값을 넘었는지 확인합니다.
+// 뮤텍스를 보유한 상태에서 호출해야 합니다.
+func (cb *CircuitBreaker) shouldOpen(now time.Time) bool {
+	if atomic.LoadInt32(&cb.state) != StateClosed {
+		return false
+	}
+
+	// 최소 요청 수 충족 시에만 판단
+	stats := cb.window.stats(now)
+	if stats.total < int64(cb.config.MinRequests) {
+		return false
+	}
+	if stats.failureRate() >= cb.config.ErrorThreshold {
+		return true
 	}
+	return cb.config.SlowCallRateThreshold > 0 && stats.slowCallRate() >= cb.config.SlowCallRateThreshold
 }
 
 // FormatState는 현재 서킷 브레이커 상태를 문자열로 반환합니다.
@@ -319,11 +343,12 @@ func (cb *CircuitBreaker) FormatState() string {
 	state := metrics["state"].(string)
 	
 	return fmt.Sprintf(
-		"CircuitBreaker state: %s, error rate: %.2f%% (%d/%d), consecutive successes: %d",
+		"CircuitBreaker state: %s, error rate: %.2f%% (%d/%d), slow call rate: %.2f%%, consecutive successes: %d",
 		state,
 		metrics["error_rate"].(float64) * 100.0,
 		metrics["failure_count"].(int64),
 		metrics["total_requests"].(int64),
+		metrics["slow_call_rate"].(float64) * 100.0,
 		metrics["consecutive_successes"].(int64),
 	)
 }
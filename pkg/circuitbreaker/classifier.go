@@ -0,0 +1,92 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// 오류 유형 상수 (Config.FailureErrorTypes에 사용)
+const (
+	ErrorTypeTimeout    = "timeout"    // 요청 타임아웃 (context.DeadlineExceeded 또는 net.Error 타임아웃)
+	ErrorTypeCanceled   = "canceled"   // 호출자가 요청을 취소함 (context.Canceled)
+	ErrorTypeConnection = "connection" // 연결 수립/전송 실패 (*net.OpError, 응답 전 연결 종료)
+	ErrorTypeOther      = "other"      // 그 외 모든 오류
+)
+
+// outcome은 실행 결과의 분류입니다.
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota // 성공으로 기록
+	outcomeFailure                // 실패로 기록
+	outcomeIgnored                // 기록하지 않음
+)
+
+// ErrorType은 오류를 유형별로 분류합니다.
+func ErrorType(err error) string {
+	var netErr net.Error
+	var opErr *net.OpError
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorTypeTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorTypeCanceled
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorTypeTimeout
+	case errors.As(err, &opErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorTypeConnection
+	default:
+		return ErrorTypeOther
+	}
+}
+
+// IsValidErrorType은 지원하는 오류 유형인지 확인합니다.
+func IsValidErrorType(errorType string) bool {
+	switch errorType {
+	case ErrorTypeTimeout, ErrorTypeCanceled, ErrorTypeConnection, ErrorTypeOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// classify는 설정에 따라 실행 결과를 성공, 실패, 무시 중 하나로 분류하고 지연 호출 여부를 함께 반환합니다.
+func (cb *CircuitBreaker) classify(result interface{}, err error, elapsed time.Duration) (outcome, bool) {
+	slow := cb.config.SlowCallDuration > 0 && elapsed >= cb.config.SlowCallDuration
+
+	if err != nil {
+		// 오류 유형이 지정되지 않으면 모든 오류를 실패로 기록
+		if len(cb.config.FailureErrorTypes) == 0 {
+			return outcomeFailure, slow
+		}
+
+		errorType := ErrorType(err)
+		for _, t := range cb.config.FailureErrorTypes {
+			if t == errorType {
+				return outcomeFailure, slow
+			}
+		}
+		return outcomeIgnored, false
+	}
+
+	// 실패로 간주할 응답 상태 코드
+	if resp, ok := result.(*http.Response); ok && resp != nil {
+		for _, code := range cb.config.FailureStatusCodes {
+			if resp.StatusCode == code {
+				return outcomeFailure, slow
+			}
+		}
+	}
+
+	// 지연 호출 비율 임계값이 없으면 지연 호출을 실패로 기록
+	if slow && cb.config.SlowCallRateThreshold <= 0 {
+		return outcomeFailure, slow
+	}
+
+	return outcomeSuccess, slow
+}
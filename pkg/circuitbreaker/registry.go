@@ -0,0 +1,93 @@
+package circuitbreaker
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Registry는 키(라우트 또는 업스트림 대상 서버)별 서킷 브레이커를 관리합니다.
+type Registry struct {
+	mutex    sync.RWMutex
+	defaults Config
+	breakers map[string]*registryEntry
+}
+
+// registryEntry는 레지스트리에 등록된 서킷 브레이커와 생성 시 사용한 설정입니다.
+type registryEntry struct {
+	breaker *CircuitBreaker
+	config  Config
+}
+
+// NewRegistry는 기본 설정을 가진 서킷 브레이커 레지스트리를 생성합니다.
+func NewRegistry(defaults Config) *Registry {
+	return &Registry{
+		defaults: defaults,
+		breakers: make(map[string]*registryEntry),
+	}
+}
+
+// Defaults는 라우트별 설정이 없을 때 사용할 기본 설정을 반환합니다.
+func (r *Registry) Defaults() Config {
+	return r.defaults
+}
+
+// Get은 키에 해당하는 서킷 브레이커를 반환합니다.
+// 등록되지 않았거나 설정이 변경된 경우 새 서킷 브레이커를 생성합니다.
+func (r *Registry) Get(key string, config Config) *CircuitBreaker {
+	r.mutex.RLock()
+	entry, ok := r.breakers[key]
+	r.mutex.RUnlock()
+	if ok && reflect.DeepEqual(entry.config, config) {
+		return entry.breaker
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// 잠금을 기다리는 동안 다른 요청이 생성했을 수 있음
+	if entry, ok := r.breakers[key]; ok && reflect.DeepEqual(entry.config, config) {
+		return entry.breaker
+	}
+
+	entry = &registryEntry{breaker: New(config), config: config}
+	r.breakers[key] = entry
+	return entry.breaker
+}
+
+// Lookup은 등록된 서킷 브레이커를 반환합니다.
+func (r *Registry) Lookup(key string) (*CircuitBreaker, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entry, ok := r.breakers[key]
+	if !ok {
+		return nil, false
+	}
+	return entry.breaker, true
+}
+
+// Retain은 keep이 true를 반환하는 키의 서킷 브레이커만 남기고 나머지를 제거합니다.
+func (r *Registry) Retain(keep func(key string) bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key := range r.breakers {
+		if !keep(key) {
+			delete(r.breakers, key)
+		}
+	}
+}
+
+// Keys는 등록된 서킷 브레이커 키를 정렬하여 반환합니다.
+func (r *Registry) Keys() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	keys := make([]string, 0, len(r.breakers))
+	for key := range r.breakers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,184 @@
+package circuitbreaker
+
+import "time"
+
+// 슬라이딩 윈도우 유형 (Config.WindowType에 사용)
+const (
+	WindowTypeCount = "count" // 최근 N개 호출
+	WindowTypeTime  = "time"  // 최근 T 시간 동안의 호출 (버킷 단위로 집계)
+)
+
+// SetWindow는 윈도우 유형과 크기를 설정합니다.
+// size는 count 윈도우면 호출 수, time 윈도우면 초 단위 길이이며 0이면 기존 값(또는 기본값)을 사용합니다.
+func (c *Config) SetWindow(windowType string, size int) {
+	c.WindowType = windowType
+	if size <= 0 {
+		return
+	}
+
+	if windowType == WindowTypeTime {
+		c.WindowDuration = time.Duration(size) * time.Second
+	} else {
+		c.WindowSize = size
+	}
+}
+
+// windowStats는 슬라이딩 윈도우에 집계된 호출 결과입니다.
+type windowStats struct {
+	total    int64 // 기록된 호출 수
+	failures int64 // 실패한 호출 수
+	slow     int64 // 지연 임계값을 넘은 호출 수
+}
+
+// slidingWindow는 최근 호출 결과를 집계하는 윈도우입니다. 서킷 브레이커 뮤텍스로 보호됩니다.
+type slidingWindow interface {
+	record(failure, slow bool, now time.Time)
+	stats(now time.Time) windowStats
+	reset()
+}
+
+// newWindow는 설정에 맞는 슬라이딩 윈도우를 생성합니다.
+func newWindow(config Config) slidingWindow {
+	if config.WindowType == WindowTypeTime {
+		return newTimeWindow(config.WindowDuration, config.WindowBuckets)
+	}
+	return newCountWindow(config.WindowSize)
+}
+
+// callResult는 카운트 기반 윈도우에 저장되는 단일 호출 결과입니다.
+type callResult struct {
+	failure bool
+	slow    bool
+}
+
+// countWindow는 최근 N개 호출 결과를 원형 버퍼로 유지합니다.
+type countWindow struct {
+	results []callResult
+	next    int // 다음에 기록할 위치
+	filled  int // 기록된 결과 수 (최대 len(results))
+	totals  windowStats
+}
+
+func newCountWindow(size int) *countWindow {
+	return &countWindow{results: make([]callResult, size)}
+}
+
+func (w *countWindow) record(failure, slow bool, now time.Time) {
+	// 윈도우가 가득 찬 경우 가장 오래된 결과를 집계에서 제외
+	if w.filled == len(w.results) {
+		w.totals.subtract(w.results[w.next])
+	} else {
+		w.filled++
+	}
+
+	result := callResult{failure: failure, slow: slow}
+	w.results[w.next] = result
+	w.totals.add(result)
+	w.next = (w.next + 1) % len(w.results)
+}
+
+func (w *countWindow) stats(now time.Time) windowStats {
+	return w.totals
+}
+
+func (w *countWindow) reset() {
+	*w = *newCountWindow(len(w.results))
+}
+
+// timeBucket은 시간 기반 윈도우의 단일 버킷입니다.
+type timeBucket struct {
+	epoch int64 // 버킷 번호 (시각 / 버킷 크기)
+	windowStats
+}
+
+// timeWindow는 최근 T 시간을 여러 버킷으로 나누어 호출 결과를 집계합니다.
+type timeWindow struct {
+	buckets    []timeBucket
+	bucketSize time.Duration
+}
+
+func newTimeWindow(duration time.Duration, buckets int) *timeWindow {
+	bucketSize := duration / time.Duration(buckets)
+	if bucketSize <= 0 {
+		bucketSize = time.Millisecond
+	}
+
+	w := &timeWindow{
+		buckets:    make([]timeBucket, buckets),
+		bucketSize: bucketSize,
+	}
+	w.reset()
+	return w
+}
+
+func (w *timeWindow) epoch(now time.Time) int64 {
+	return now.UnixNano() / int64(w.bucketSize)
+}
+
+func (w *timeWindow) record(failure, slow bool, now time.Time) {
+	epoch := w.epoch(now)
+	bucket := &w.buckets[epoch%int64(len(w.buckets))]
+
+	// 윈도우를 벗어난 이전 주기의 버킷은 재사용
+	if bucket.epoch != epoch {
+		*bucket = timeBucket{epoch: epoch}
+	}
+	bucket.add(callResult{failure: failure, slow: slow})
+}
+
+func (w *timeWindow) stats(now time.Time) windowStats {
+	current := w.epoch(now)
+	oldest := current - int64(len(w.buckets)) + 1
+
+	var stats windowStats
+	for _, bucket := range w.buckets {
+		if bucket.epoch >= oldest && bucket.epoch <= current {
+			stats.total += bucket.total
+			stats.failures += bucket.failures
+			stats.slow += bucket.slow
+		}
+	}
+	return stats
+}
+
+func (w *timeWindow) reset() {
+	for i := range w.buckets {
+		w.buckets[i] = timeBucket{epoch: -1}
+	}
+}
+
+func (s *windowStats) add(result callResult) {
+	s.total++
+	if result.failure {
+		s.failures++
+	}
+	if result.slow {
+		s.slow++
+	}
+}
+
+func (s *windowStats) subtract(result callResult) {
+	s.total--
+	if result.failure {
+		s.failures--
+	}
+	if result.slow {
+		s.slow--
+	}
+}
+
+// failureRate는 윈도우 내 실패율을 반환합니다.
+func (s windowStats) failureRate() float64 {
+	if s.total == 0 {
+		return 0
+	}
+	return float64(s.failures) / float64(s.total)
+}
+
+// slowCallRate는 윈도우 내 지연 호출 비율을 반환합니다.
+func (s windowStats) slowCallRate() float64 {
+	if s.total == 0 {
+		return 0
+	}
+	return float64(s.slow) / float64(s.total)
+}
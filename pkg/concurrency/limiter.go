@@ -0,0 +1,310 @@
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// 오류 정의
+var (
+	ErrLimitExceeded = errors.New("concurrency limit exceeded")
+	ErrQueueTimeout  = errors.New("timed out waiting in concurrency queue")
+)
+
+// Mode는 동시 요청 한도를 조정하는 방식입니다.
+type Mode string
+
+const (
+	ModeFixed    Mode = "fixed"    // 고정 한도
+	ModeAIMD     Mode = "aimd"     // 지연 시간이 임계값을 넘거나 요청이 실패하면 곱셈 감소, 그 외에는 덧셈 증가
+	ModeGradient Mode = "gradient" // 장기 평균 대비 최근 지연 시간 비율(gradient)로 한도 조정
+)
+
+// IsValidMode는 지원하는 한도 조정 방식인지 확인합니다.
+func IsValidMode(mode string) bool {
+	switch Mode(mode) {
+	case ModeFixed, ModeAIMD, ModeGradient:
+		return true
+	}
+	return false
+}
+
+// Outcome은 요청 처리 결과입니다.
+type Outcome int
+
+const (
+	OutcomeSuccess Outcome = iota // 처리 완료 (지연 시간을 한도 조정에 반영)
+	OutcomeDropped                // 과부하로 실패 (타임아웃, 503 등, 한도 감소)
+	OutcomeIgnore                 // 한도 조정에 반영하지 않음 (클라이언트 취소 등)
+)
+
+// Config는 동시성 제한기 설정입니다.
+type Config struct {
+	Mode         Mode          // fixed(기본), aimd, gradient
+	Limit        int           // 고정 한도 또는 적응형 모드의 초기 한도
+	MinLimit     int           // 적응형 모드의 최소 한도
+	MaxLimit     int           // 적응형 모드의 최대 한도
+	QueueSize    int           // 한도 초과 시 대기할 수 있는 최대 요청 수 (0이면 대기 없이 거부)
+	QueueTimeout time.Duration // 대기열에서 기다릴 최대 시간
+
+	// AIMD 설정
+	LatencyThreshold time.Duration // 이 시간보다 오래 걸린 요청은 한도를 감소시킴 (0이면 실패한 요청만 감소)
+	BackoffRatio     float64       // 감소 시 한도에 곱할 비율 (0-1)
+
+	// gradient 설정
+	Tolerance float64 // 장기 평균 대비 허용할 지연 시간 배수 (1 이상)
+}
+
+// Stats는 동시성 제한기 상태입니다.
+type Stats struct {
+	Limit    int `json:"limit"`    // 현재 동시 요청 한도
+	InFlight int `json:"inFlight"` // 처리 중인 요청 수
+	Queued   int `json:"queued"`   // 대기 중인 요청 수
+}
+
+// Limiter는 동시에 처리하는 요청 수를 제한하는 제한기입니다.
+// 한도를 넘는 요청은 대기열에서 순서대로 기다리며, 대기열이 가득 차거나 대기 시간이 지나면 거부됩니다.
+type Limiter struct {
+	mutex    sync.Mutex
+	config   Config
+	limit    float64 // 현재 한도 (적응형 모드는 실수로 조정)
+	inFlight int
+	waiters  *list.List // 대기 중인 요청 (*waiter)
+
+	longRTT float64 // gradient: 지연 시간 장기 지수 이동 평균 (초)
+}
+
+// waiter는 대기열의 요청입니다. 슬롯이 할당되면 ready가 닫힙니다.
+type waiter struct {
+	ready chan struct{}
+}
+
+// Token은 획득한 실행 슬롯입니다. 요청 처리가 끝나면 Release로 반환해야 합니다.
+type Token struct {
+	limiter  *Limiter
+	start    time.Time
+	inFlight int // 획득 시점의 처리 중인 요청 수 (획득한 요청 포함)
+	released bool
+}
+
+// 기본값
+const (
+	defaultLimit        = 20
+	defaultMaxLimit     = 1000
+	defaultQueueTimeout = time.Second
+	defaultBackoff      = 0.9
+	defaultTolerance    = 1.5
+)
+
+// gradient 모드의 평활 계수
+const (
+	longRTTSmoothing = 0.05 // 장기 평균에 반영할 새 샘플 비율
+	limitSmoothing   = 0.2  // 새 한도에 반영할 계산값 비율
+	minGradient      = 0.5  // 한 번에 줄일 수 있는 최대 비율
+)
+
+// New는 새로운 동시성 제한기를 생성합니다.
+func New(config Config) *Limiter {
+	// 기본값 설정
+	if config.Mode == "" {
+		config.Mode = ModeFixed
+	}
+	if config.Limit <= 0 {
+		config.Limit = defaultLimit
+	}
+	if config.MinLimit <= 0 {
+		config.MinLimit = 1
+	}
+	if config.MaxLimit <= 0 {
+		config.MaxLimit = int(math.Max(defaultMaxLimit, float64(config.Limit)))
+	}
+	if config.MinLimit > config.Limit {
+		config.MinLimit = config.Limit
+	}
+	if config.MaxLimit < config.Limit {
+		config.MaxLimit = config.Limit
+	}
+	if config.QueueTimeout <= 0 {
+		config.QueueTimeout = defaultQueueTimeout
+	}
+	if config.BackoffRatio <= 0 || config.BackoffRatio >= 1 {
+		config.BackoffRatio = defaultBackoff
+	}
+	if config.Tolerance < 1 {
+		config.Tolerance = defaultTolerance
+	}
+
+	return &Limiter{
+		config:  config,
+		limit:   float64(config.Limit),
+		waiters: list.New(),
+	}
+}
+
+// Config는 기본값이 적용된 설정을 반환합니다.
+func (l *Limiter) Config() Config {
+	return l.config
+}
+
+// Acquire는 실행 슬롯을 획득합니다.
+// 한도에 도달하면 대기열에서 기다리며, 대기열이 가득 차면 ErrLimitExceeded,
+// 대기 시간이 지나면 ErrQueueTimeout, 컨텍스트가 취소되면 컨텍스트 오류를 반환합니다.
+func (l *Limiter) Acquire(ctx context.Context) (*Token, error) {
+	l.mutex.Lock()
+	if l.inFlight < l.currentLimit() && l.waiters.Len() == 0 {
+		token := l.grant()
+		l.mutex.Unlock()
+		return token, nil
+	}
+	if l.waiters.Len() >= l.config.QueueSize {
+		l.mutex.Unlock()
+		return nil, ErrLimitExceeded
+	}
+
+	w := &waiter{ready: make(chan struct{})}
+	element := l.waiters.PushBack(w)
+	l.mutex.Unlock()
+
+	timer := time.NewTimer(l.config.QueueTimeout)
+	defer timer.Stop()
+
+	var err error
+	select {
+	case <-w.ready:
+		return l.newToken(), nil
+	case <-timer.C:
+		err = ErrQueueTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	// 대기를 포기하는 동안 슬롯이 할당되었을 수 있음
+	select {
+	case <-w.ready:
+		l.inFlight--
+		l.dispatch()
+	default:
+		l.waiters.Remove(element)
+	}
+	return nil, err
+}
+
+// Release는 슬롯을 반환하고 처리 결과와 지연 시간으로 한도를 조정합니다.
+func (t *Token) Release(outcome Outcome) {
+	if t == nil || t.released {
+		return
+	}
+	t.released = true
+
+	l := t.limiter
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.inFlight--
+	if outcome != OutcomeIgnore {
+		l.adjust(outcome, time.Since(t.start), t.inFlight)
+	}
+	l.dispatch()
+}
+
+// Stats는 현재 상태를 반환합니다.
+func (l *Limiter) Stats() Stats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return Stats{Limit: l.currentLimit(), InFlight: l.inFlight, Queued: l.waiters.Len()}
+}
+
+// currentLimit은 정수로 내림한 현재 한도를 반환합니다.
+func (l *Limiter) currentLimit() int {
+	return int(l.limit)
+}
+
+// grant는 슬롯 하나를 할당합니다. 호출자는 잠금을 보유해야 합니다.
+func (l *Limiter) grant() *Token {
+	l.inFlight++
+	return l.newTokenLocked()
+}
+
+func (l *Limiter) newToken() *Token {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.newTokenLocked()
+}
+
+func (l *Limiter) newTokenLocked() *Token {
+	return &Token{limiter: l, start: time.Now(), inFlight: l.inFlight}
+}
+
+// dispatch는 한도 내에서 대기 중인 요청에 순서대로 슬롯을 할당합니다. 호출자는 잠금을 보유해야 합니다.
+func (l *Limiter) dispatch() {
+	for l.inFlight < l.currentLimit() && l.waiters.Len() > 0 {
+		w := l.waiters.Remove(l.waiters.Front()).(*waiter)
+		l.inFlight++
+		close(w.ready)
+	}
+}
+
+// adjust는 처리 결과로 한도를 조정합니다. 호출자는 잠금을 보유해야 합니다.
+func (l *Limiter) adjust(outcome Outcome, latency time.Duration, inFlight int) {
+	switch l.config.Mode {
+	case ModeAIMD:
+		l.adjustAIMD(outcome, latency, inFlight)
+	case ModeGradient:
+		l.adjustGradient(outcome, latency, inFlight)
+	default:
+		return
+	}
+
+	l.limit = math.Max(float64(l.config.MinLimit), math.Min(float64(l.config.MaxLimit), l.limit))
+}
+
+// adjustAIMD는 실패하거나 느린 요청이면 한도를 곱셈 감소시키고,
+// 한도를 절반 이상 사용 중일 때 성공한 요청이면 한도를 1 증가시킵니다.
+func (l *Limiter) adjustAIMD(outcome Outcome, latency time.Duration, inFlight int) {
+	slow := l.config.LatencyThreshold > 0 && latency > l.config.LatencyThreshold
+	if outcome == OutcomeDropped || slow {
+		l.limit *= l.config.BackoffRatio
+		return
+	}
+	if float64(inFlight)*2 >= l.limit {
+		l.limit++
+	}
+}
+
+// adjustGradient는 장기 평균 지연 시간 대비 최근 지연 시간 비율로 한도를 조정합니다.
+// 지연 시간이 평균의 tolerance배 이내이면 한도를 늘리고, 이를 넘으면 비율만큼 줄입니다.
+func (l *Limiter) adjustGradient(outcome Outcome, latency time.Duration, inFlight int) {
+	if outcome == OutcomeDropped {
+		l.limit *= l.config.BackoffRatio
+		return
+	}
+
+	rtt := latency.Seconds()
+	if l.longRTT == 0 {
+		l.longRTT = rtt
+	} else {
+		l.longRTT = l.longRTT*(1-longRTTSmoothing) + rtt*longRTTSmoothing
+	}
+
+	// 한도를 절반도 사용하지 않으면 지연 시간이 한도와 무관하므로 조정하지 않음
+	if float64(inFlight)*2 < l.limit {
+		return
+	}
+
+	gradient := 1.0
+	if rtt > 0 {
+		gradient = math.Max(minGradient, math.Min(1, l.config.Tolerance*l.longRTT/rtt))
+	}
+
+	// 대기열 여유분으로 한도의 제곱근만큼 증가를 허용
+	next := l.limit*gradient + math.Sqrt(l.limit)
+	l.limit = l.limit*(1-limitSmoothing) + next*limitSmoothing
+}
@@ -0,0 +1,84 @@
+package concurrency
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Registry는 키(라우트 또는 업스트림)별 동시성 제한기를 관리합니다.
+type Registry struct {
+	mutex    sync.RWMutex
+	limiters map[string]*registryEntry
+}
+
+// registryEntry는 레지스트리에 등록된 동시성 제한기와 생성 시 사용한 설정입니다.
+type registryEntry struct {
+	limiter *Limiter
+	config  Config
+}
+
+// NewRegistry는 동시성 제한기 레지스트리를 생성합니다.
+func NewRegistry() *Registry {
+	return &Registry{limiters: make(map[string]*registryEntry)}
+}
+
+// Get은 키에 해당하는 동시성 제한기를 반환합니다.
+// 등록되지 않았거나 설정이 변경된 경우 새 제한기를 생성합니다.
+func (r *Registry) Get(key string, config Config) *Limiter {
+	r.mutex.RLock()
+	entry, ok := r.limiters[key]
+	r.mutex.RUnlock()
+	if ok && reflect.DeepEqual(entry.config, config) {
+		return entry.limiter
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// 잠금을 기다리는 동안 다른 요청이 생성했을 수 있음
+	if entry, ok := r.limiters[key]; ok && reflect.DeepEqual(entry.config, config) {
+		return entry.limiter
+	}
+
+	entry = &registryEntry{limiter: New(config), config: config}
+	r.limiters[key] = entry
+	return entry.limiter
+}
+
+// Lookup은 등록된 동시성 제한기를 반환합니다.
+func (r *Registry) Lookup(key string) (*Limiter, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	entry, ok := r.limiters[key]
+	if !ok {
+		return nil, false
+	}
+	return entry.limiter, true
+}
+
+// Retain은 keep이 true를 반환하는 키의 제한기만 남기고 나머지를 제거합니다.
+func (r *Registry) Retain(keep func(key string) bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key := range r.limiters {
+		if !keep(key) {
+			delete(r.limiters, key)
+		}
+	}
+}
+
+// Keys는 등록된 제한기 키를 정렬하여 반환합니다.
+func (r *Registry) Keys() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	keys := make([]string, 0, len(r.limiters))
+	for key := range r.limiters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
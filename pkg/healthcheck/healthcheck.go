@@ -0,0 +1,419 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/isinthesky/api-gateway/pkg/loadbalancer"
+)
+
+// Config는 능동 헬스 체크 설정입니다.
+type Config struct {
+	Path               string        // 헬스 체크 경로
+	Interval           time.Duration // 검사 주기
+	Timeout            time.Duration // 검사 요청 타임아웃
+	ExpectedStatus     int           // 기대 상태 코드 (0이면 2xx 모두 허용)
+	HealthyThreshold   int           // 정상 전환을 위한 연속 성공 횟수 (rise)
+	UnhealthyThreshold int           // 비정상 전환을 위한 연속 실패 횟수 (fall)
+}
+
+// PassiveConfig는 실제 프록시 요청 결과를 이용한 수동 헬스 체크 설정입니다.
+type PassiveConfig struct {
+	MaxFailures int           // 대상을 제외하기 위한 연속 실패 횟수 (5xx 또는 연결 오류)
+	Cooldown    time.Duration // 제외된 대상을 다시 사용하기까지의 대기 시간
+}
+
+// Group은 같은 업스트림에 속한 대상 서버와 이를 사용하는 로드 밸런서 모음입니다.
+type Group struct {
+	Name      string
+	Targets   []string
+	Balancers []loadbalancer.LoadBalancer
+	Active    *Config        // nil이면 능동 헬스 체크 비활성화
+	Passive   *PassiveConfig // nil이면 수동 헬스 체크 비활성화
+}
+
+// TargetStatus는 대상 서버의 헬스 체크 상태입니다.
+type TargetStatus struct {
+	URL                  string    `json:"url"`
+	Healthy              bool      `json:"healthy"`
+	Ejected              bool      `json:"ejected"`
+	EjectedUntil         time.Time `json:"ejected_until,omitempty"`
+	LastChecked          time.Time `json:"last_checked,omitempty"`
+	LastError            string    `json:"last_error,omitempty"`
+	ConsecutiveFailures  int       `json:"consecutive_failures"`
+	ConsecutiveSuccesses int       `json:"consecutive_successes"`
+}
+
+// targetState는 대상 서버의 내부 헬스 체크 상태입니다.
+type targetState struct {
+	url                  string
+	healthy              bool // 능동 헬스 체크 결과
+	lastChecked          time.Time
+	lastError            string
+	consecutiveFailures  int // 능동 헬스 체크 연속 실패
+	consecutiveSuccesses int // 능동 헬스 체크 연속 성공
+	passiveFailures      int // 프록시 요청 연속 실패
+	ejectedUntil         time.Time
+}
+
+// available은 대상 서버가 요청을 받을 수 있는지 확인합니다.
+func (t *targetState) available(now time.Time) bool {
+	return t.healthy && !now.Before(t.ejectedUntil)
+}
+
+// group은 업스트림별 헬스 체크 실행 상태입니다.
+type group struct {
+	spec    Group
+	targets map[string]*targetState
+	quit    chan struct{}
+}
+
+// Checker는 업스트림 대상 서버의 상태를 검사하여 로드 밸런서에 반영합니다.
+type Checker struct {
+	mu     sync.Mutex
+	groups map[string]*group
+	client *http.Client
+}
+
+// New는 새로운 헬스 체커를 생성합니다.
+func New() *Checker {
+	return &Checker{
+		groups: make(map[string]*group),
+		client: &http.Client{
+			// 리다이렉트는 따라가지 않고 응답 상태 코드 그대로 판단
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// SetGroups는 검사할 업스트림 그룹 목록을 교체합니다.
+// 같은 이름과 URL의 대상 서버 상태는 유지되며, 새 로드 밸런서에 즉시 반영됩니다.
+func (hc *Checker) SetGroups(specs []Group) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	now := time.Now()
+	next := make(map[string]*group, len(specs))
+
+	for _, spec := range specs {
+		if spec.Active != nil {
+			applyDefaults(spec.Active)
+		}
+		if spec.Passive != nil {
+			applyPassiveDefaults(spec.Passive)
+		}
+
+		g := &group{
+			spec:    spec,
+			targets: make(map[string]*targetState, len(spec.Targets)),
+			quit:    make(chan struct{}),
+		}
+
+		var old *group
+		if prev, ok := hc.groups[spec.Name]; ok {
+			old = prev
+		}
+
+		for _, url := range spec.Targets {
+			if old != nil {
+				if state, ok := old.targets[url]; ok {
+					g.targets[url] = state
+					continue
+				}
+			}
+			g.targets[url] = &targetState{url: url, healthy: true}
+		}
+
+		// 기존 상태를 새 로드 밸런서에 반영
+		for _, state := range g.targets {
+			if !state.available(now) {
+				g.markDown(state.url)
+			}
+		}
+
+		next[spec.Name] = g
+	}
+
+	// 이전 그룹의 검사 고루틴 중지
+	for _, g := range hc.groups {
+		close(g.quit)
+	}
+	hc.groups = next
+
+	// 새 그룹의 능동 검사 시작
+	for _, g := range hc.groups {
+		if g.spec.Active != nil {
+			go hc.run(g)
+		}
+	}
+}
+
+// Stop은 모든 헬스 체크를 중지합니다.
+func (hc *Checker) Stop() {
+	hc.SetGroups(nil)
+}
+
+// CheckNow는 모든 그룹의 능동 헬스 체크를 즉시 한 번 수행합니다.
+func (hc *Checker) CheckNow() {
+	hc.mu.Lock()
+	groups := make([]*group, 0, len(hc.groups))
+	for _, g := range hc.groups {
+		if g.spec.Active != nil {
+			groups = append(groups, g)
+		}
+	}
+	hc.mu.Unlock()
+
+	for _, g := range groups {
+		hc.checkGroup(g)
+	}
+}
+
+// ReportResult는 프록시 요청 결과를 기록합니다 (수동 헬스 체크).
+// 연속 실패가 기준을 넘으면 대상 서버를 쿨다운 기간 동안 제외합니다.
+func (hc *Checker) ReportResult(groupName, url string, success bool) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	g, ok := hc.groups[groupName]
+	if !ok || g.spec.Passive == nil {
+		return
+	}
+	state, ok := g.targets[url]
+	if !ok {
+		return
+	}
+
+	if success {
+		state.passiveFailures = 0
+		return
+	}
+
+	state.passiveFailures++
+	now := time.Now()
+	if state.passiveFailures < g.spec.Passive.MaxFailures || now.Before(state.ejectedUntil) {
+		return
+	}
+
+	// 대상 제외
+	wasAvailable := state.available(now)
+	state.ejectedUntil = now.Add(g.spec.Passive.Cooldown)
+	state.passiveFailures = 0
+	log.Printf("[HEALTH] 연속 실패로 대상 제외: %s/%s (쿨다운 %v)", groupName, url, g.spec.Passive.Cooldown)
+
+	if wasAvailable {
+		g.markDown(url)
+	}
+
+	// 쿨다운 후 복귀
+	time.AfterFunc(g.spec.Passive.Cooldown, func() {
+		hc.restore(groupName, url)
+	})
+}
+
+// Status는 업스트림별 대상 서버 상태를 반환합니다.
+func (hc *Checker) Status() map[string][]TargetStatus {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	now := time.Now()
+	result := make(map[string][]TargetStatus, len(hc.groups))
+	for name, g := range hc.groups {
+		statuses := make([]TargetStatus, 0, len(g.targets))
+		for _, url := range g.spec.Targets {
+			state := g.targets[url]
+			status := TargetStatus{
+				URL:                  url,
+				Healthy:              state.available(now),
+				Ejected:              now.Before(state.ejectedUntil),
+				LastChecked:          state.lastChecked,
+				LastError:            state.lastError,
+				ConsecutiveFailures:  state.consecutiveFailures,
+				ConsecutiveSuccesses: state.consecutiveSuccesses,
+			}
+			if status.Ejected {
+				status.EjectedUntil = state.ejectedUntil
+			}
+			statuses = append(statuses, status)
+		}
+		result[name] = statuses
+	}
+
+	return result
+}
+
+// run은 그룹의 능동 헬스 체크를 주기적으로 수행합니다.
+func (hc *Checker) run(g *group) {
+	ticker := time.NewTicker(g.spec.Active.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			hc.checkGroup(g)
+		case <-g.quit:
+			return
+		}
+	}
+}
+
+// checkGroup은 그룹의 모든 대상 서버를 검사합니다.
+func (hc *Checker) checkGroup(g *group) {
+	var wg sync.WaitGroup
+	for _, url := range g.spec.Targets {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			err := hc.probe(url, g.spec.Active)
+			hc.record(g, url, err)
+		}(url)
+	}
+	wg.Wait()
+}
+
+// probe는 대상 서버에 헬스 체크 요청을 보냅니다.
+func (hc *Checker) probe(target string, cfg *Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	url := strings.TrimSuffix(target, "/") + cfg.Path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := hc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if cfg.ExpectedStatus > 0 {
+		if resp.StatusCode != cfg.ExpectedStatus {
+			return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// record는 능동 헬스 체크 결과를 반영하고 임계값에 따라 상태를 전환합니다.
+func (hc *Checker) record(g *group, url string, probeErr error) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	// 교체된 그룹의 결과는 무시
+	if hc.groups[g.spec.Name] != g {
+		return
+	}
+	state, ok := g.targets[url]
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	wasAvailable := state.available(now)
+	state.lastChecked = now
+
+	if probeErr != nil {
+		state.lastError = probeErr.Error()
+		state.consecutiveFailures++
+		state.consecutiveSuccesses = 0
+		if state.healthy && state.consecutiveFailures >= g.spec.Active.UnhealthyThreshold {
+			state.healthy = false
+			log.Printf("[HEALTH] 대상 비정상 전환: %s/%s - %v", g.spec.Name, url, probeErr)
+		}
+	} else {
+		state.lastError = ""
+		state.consecutiveSuccesses++
+		state.consecutiveFailures = 0
+		if !state.healthy && state.consecutiveSuccesses >= g.spec.Active.HealthyThreshold {
+			state.healthy = true
+			log.Printf("[HEALTH] 대상 정상 전환: %s/%s", g.spec.Name, url)
+		}
+	}
+
+	if isAvailable := state.available(now); isAvailable != wasAvailable {
+		if isAvailable {
+			g.markUp(url)
+		} else {
+			g.markDown(url)
+		}
+	}
+}
+
+// restore는 쿨다운이 끝난 대상 서버를 다시 사용 가능하게 합니다.
+// 쿨다운 중 그룹이 교체되었을 수 있으므로 현재 그룹을 기준으로 반영합니다.
+func (hc *Checker) restore(groupName, url string) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+
+	g, ok := hc.groups[groupName]
+	if !ok {
+		return
+	}
+	state, ok := g.targets[url]
+	if !ok {
+		return
+	}
+
+	if state.available(time.Now()) {
+		log.Printf("[HEALTH] 쿨다운 종료, 대상 복귀: %s/%s", g.spec.Name, url)
+		g.markUp(url)
+	}
+}
+
+// markDown은 그룹의 모든 로드 밸런서에서 대상 서버를 비정상으로 표시합니다.
+func (g *group) markDown(url string) {
+	for _, lb := range g.spec.Balancers {
+		lb.MarkTargetDown(url)
+	}
+}
+
+// markUp은 그룹의 모든 로드 밸런서에서 대상 서버를 정상으로 표시합니다.
+func (g *group) markUp(url string) {
+	for _, lb := range g.spec.Balancers {
+		lb.MarkTargetUp(url)
+	}
+}
+
+// applyDefaults는 능동 헬스 체크 설정의 기본값을 채웁니다.
+func applyDefaults(cfg *Config) {
+	if cfg.Path == "" {
+		cfg.Path = "/health"
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		cfg.Path = "/" + cfg.Path
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second // 기본 10초 주기
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 2 * time.Second // 기본 2초 타임아웃
+	}
+	if cfg.HealthyThreshold <= 0 {
+		cfg.HealthyThreshold = 2 // 기본 2번 연속 성공
+	}
+	if cfg.UnhealthyThreshold <= 0 {
+		cfg.UnhealthyThreshold = 3 // 기본 3번 연속 실패
+	}
+}
+
+// applyPassiveDefaults는 수동 헬스 체크 설정의 기본값을 채웁니다.
+func applyPassiveDefaults(cfg *PassiveConfig) {
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = 5 // 기본 5번 연속 실패
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 30 * time.Second // 기본 30초 제외
+	}
+}
@@ -2,6 +2,7 @@ package loadbalancer
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"sync"
 	"sync/atomic"
@@ -14,6 +15,13 @@ var (
 	ErrTargetUnreachable = errors.New("target is unreachable")
 )
 
+// 부하 분산 전략 이름
+const (
+	StrategyRoundRobin      = "round-robin"
+	StrategyWeighted        = "weighted"
+	StrategyLeastConnection = "least-connection"
+)
+
 // Target은 로드 밸런서 대상 서버입니다.
 type Target struct {
 	URL           string
@@ -33,6 +41,22 @@ type LoadBalancer interface {
 	MarkTargetDown(url string) error
 	MarkTargetUp(url string) error
 	GetTargets() []*Target
+	Release(url string)
+}
+
+// NewWithStrategy는 지정한 전략의 빈 로드 밸런서를 생성합니다.
+// 대상 서버는 AddTarget으로 추가합니다.
+func NewWithStrategy(strategy string) (LoadBalancer, error) {
+	switch strategy {
+	case "", StrategyRoundRobin:
+		return NewRoundRobin(nil), nil
+	case StrategyWeighted:
+		return NewWeightedRoundRobin(nil), nil
+	case StrategyLeastConnection:
+		return NewLeastConnection(nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported load balancing strategy: %s", strategy)
+	}
 }
 
 // RoundRobinBalancer는 라운드 로빈 부하 분산 구현체입니다.
@@ -188,6 +212,19 @@ func (lb *RoundRobinBalancer) GetTargets() []*Target {
 	return targets
 }
 
+// Release는 대상 서버의 활성 연결 수를 감소시킵니다.
+func (lb *RoundRobinBalancer) Release(urlStr string) {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	for _, target := range lb.targets {
+		if target.URL == urlStr {
+			decrementConns(target)
+			return
+		}
+	}
+}
+
 // WeightedRoundRobinBalancer는 가중치 기반 라운드 로빈 로드 밸런서 구현체입니다.
 type WeightedRoundRobinBalancer struct {
 	RoundRobinBalancer
@@ -427,13 +464,30 @@ func (lb *SingleTarget) GetTargets() []*Target {
 	return []*Target{&target}
 }
 
+// Release는 대상 서버의 활성 연결 수를 감소시킵니다.
+func (lb *SingleTarget) Release(urlStr string) {
+	lb.mu.RLock()
+	defer lb.mu.RUnlock()
+
+	if lb.target != nil && lb.target.URL == urlStr {
+		decrementConns(lb.target)
+	}
+}
+
 // ReleaseConn은 활성 연결 수를 감소시킵니다.
 func ReleaseConn(lb LoadBalancer, urlStr string) {
-	targets := lb.GetTargets()
-	for _, target := range targets {
-		if target.URL == urlStr && target.ActiveConns > 0 {
-			atomic.AddInt64(&target.ActiveConns, -1)
-			break
+	lb.Release(urlStr)
+}
+
+// decrementConns는 활성 연결 수가 음수가 되지 않도록 원자적으로 감소시킵니다.
+func decrementConns(target *Target) {
+	for {
+		conns := atomic.LoadInt64(&target.ActiveConns)
+		if conns <= 0 {
+			return
+		}
+		if atomic.CompareAndSwapInt64(&target.ActiveConns, conns, conns-1) {
+			return
 		}
 	}
 }
@@ -1,8 +1,10 @@
 package ratelimiter
 
 import (
+	"context"
 	"errors"
-	"sync"
+	"log"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,39 +22,70 @@ type RateLimiter interface {
 	Stop()
 }
 
+// Status는 속도 제한 결정과 함께 키의 한도 상태를 나타냅니다.
+type Status struct {
+	Allowed    bool          // 요청 허용 여부
+	Limit      int           // 한도 (토큰 버킷 용량 또는 윈도우 당 최대 요청 수)
+	Remaining  int           // 남은 요청 수
+	RetryAfter time.Duration // 거부된 경우 요청이 허용될 때까지 남은 시간
+	ResetAfter time.Duration // 한도가 모두 회복될 때까지 남은 시간
+	Window     time.Duration // 한도가 적용되는 시간 단위
+}
+
+// StatusLimiter는 결정과 함께 한도 상태를 반환하는 속도 제한기입니다.
+// 응답 헤더에 남은 요청 수와 초기화 시간을 알려줄 때 사용합니다.
+type StatusLimiter interface {
+	RateLimiter
+	// AllowNStatus는 n개의 요청을 허용할지 결정하고 결정 후 상태를 반환합니다.
+	AllowNStatus(key string, n int) Status
+	// Status는 상태를 바꾸지 않고 키의 현재 상태를 반환합니다.
+	Status(key string) Status
+}
+
 // TokenBucket은 토큰 버킷 알고리즘 기반 속도 제한기입니다.
 type TokenBucket struct {
-	tokens     map[string]*bucket
-	mu         sync.RWMutex
-	rate       float64      // 초당 토큰 보충 속도
-	capacity   int          // 버킷 최대 용량
-	window     time.Duration // 속도 측정 시간 단위
-	quit       chan struct{}
+	limiter
+	rate     float64       // 초당 토큰 보충 속도
+	capacity int           // 버킷 최대 용량
+	window   time.Duration // 속도 측정 시간 단위
 }
 
-// bucket은 토큰 버킷 상태를 나타냅니다.
-type bucket struct {
-	tokens    float64     // 현재 토큰 수
-	lastCheck time.Time   // 마지막 확인 시간
+// limiter는 저장소 기반 속도 제한기의 공통 상태입니다.
+type limiter struct {
+	store       Store
+	ownsStore   bool        // Stop 시 저장소를 닫을지 여부 (New로 생성한 메모리 저장소)
+	failureMode FailureMode // 저장소 장애 시 처리 방식
+
+	lastErrorLog atomic.Int64 // 저장소 오류를 마지막으로 기록한 시각 (UnixNano)
 }
 
-// New는 새로운 속도 제한기를 생성합니다.
+// storeErrorLogInterval은 저장소 오류 로그의 최소 간격입니다.
+const storeErrorLogInterval = 10 * time.Second
+
+// New는 메모리 저장소를 사용하는 새로운 속도 제한기를 생성합니다.
 func New(window time.Duration, maxRequests int) *TokenBucket {
-	// 시간당 토큰 보충 속도 계산
-	rate := float64(maxRequests) / window.Seconds()
+	rl := NewWithStore(NewMemoryStore(window*2), window, maxRequests, FailOpen)
+	rl.ownsStore = true
+	return rl
+}
 
-	rl := &TokenBucket{
-		tokens:   make(map[string]*bucket),
-		rate:     rate,
+// NewWithStore는 주어진 저장소를 사용하는 토큰 버킷 속도 제한기를 생성합니다.
+// 저장소를 공유하는 모든 인스턴스가 같은 버킷을 사용하며, 저장소는 호출자가 닫아야 합니다.
+func NewWithStore(store Store, window time.Duration, maxRequests int, failureMode FailureMode) *TokenBucket {
+	return &TokenBucket{
+		limiter:  limiter{store: store, failureMode: failureMode},
+		rate:     float64(maxRequests) / window.Seconds(),
 		capacity: maxRequests,
 		window:   window,
-		quit:     make(chan struct{}),
 	}
+}
 
-	// 청소 고루틴 시작
-	go rl.startCleaner()
-
-	return rl
+// SetBurst는 평균 보충 속도는 유지한 채 버킷 용량(순간 허용량)을 burst로 변경합니다.
+// 속도 제한기를 사용하기 전에 호출해야 합니다.
+func (rl *TokenBucket) SetBurst(burst int) {
+	if burst > 0 {
+		rl.capacity = burst
+	}
 }
 
 // Allow는 주어진 키에 대한 요청을 허용할지 결정합니다 (1개의 토큰 사용).
@@ -62,132 +95,67 @@ func (rl *TokenBucket) Allow(key string) bool {
 
 // AllowN은 주어진 키에 대해 n개의 토큰을 사용할 수 있는지 확인합니다.
 func (rl *TokenBucket) AllowN(key string, n int) bool {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	now := time.Now()
-	
-	// 버킷이 존재하지 않으면 생성
-	b, exists := rl.tokens[key]
-	if !exists {
-		b = &bucket{
-			tokens:    float64(rl.capacity),
-			lastCheck: now,
-		}
-		rl.tokens[key] = b
-	}
+	return rl.AllowNStatus(key, n).Allowed
+}
 
-	// 토큰 보충 계산
-	elapsed := now.Sub(b.lastCheck).Seconds()
-	b.tokens = min(float64(rl.capacity), b.tokens+(elapsed*rl.rate))
-	b.lastCheck = now
+// AllowNStatus는 n개의 토큰을 사용하고 버킷 상태를 반환합니다.
+// ResetAfter는 버킷이 가득 찰 때까지, RetryAfter는 필요한 토큰이 보충될 때까지 남은 시간입니다.
+func (rl *TokenBucket) AllowNStatus(key string, n int) Status {
+	result, err := rl.store.TokenBucket(context.Background(), key, rl.capacity, rl.rate, n)
+	return rl.status(result, err, rl.capacity, rl.refillWindow())
+}
 
-	// 요청에 충분한 토큰이 있는지 확인
-	if b.tokens < float64(n) {
-		return false
-	}
+// Status는 토큰을 사용하지 않고 버킷 상태를 반환합니다.
+func (rl *TokenBucket) Status(key string) Status {
+	return rl.AllowNStatus(key, 0)
+}
 
-	// 토큰 사용
-	b.tokens -= float64(n)
-	return true
+// refillWindow는 빈 버킷이 가득 찰 때까지 걸리는 시간입니다.
+func (rl *TokenBucket) refillWindow() time.Duration {
+	return secondsToDuration(float64(rl.capacity) / rl.rate)
 }
 
 // Peek는 키에 대한 현재 토큰 상태를 반환합니다 (토큰 사용 없음).
 func (rl *TokenBucket) Peek(key string) (int, bool) {
-	rl.mu.RLock()
-	defer rl.mu.RUnlock()
-
-	b, exists := rl.tokens[key]
-	if !exists {
-		return rl.capacity, true
+	result, err := rl.store.TokenBucket(context.Background(), key, rl.capacity, rl.rate, 0)
+	if err != nil {
+		return rl.capacity, rl.failed(err)
 	}
-
-	// 현재 사용 가능한 토큰 수 계산
-	now := time.Now()
-	elapsed := now.Sub(b.lastCheck).Seconds()
-	tokens := min(float64(rl.capacity), b.tokens+(elapsed*rl.rate))
-
-	return int(tokens), tokens >= 1.0
+	return result.Remaining, result.Allowed
 }
 
 // Reset은 키에 대한 버킷을 초기화합니다.
 func (rl *TokenBucket) Reset(key string) {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	delete(rl.tokens, key)
+	rl.reset(key)
 }
 
-// Stop은 청소 고루틴을 중지합니다.
+// Stop은 속도 제한기가 소유한 저장소를 닫습니다.
 func (rl *TokenBucket) Stop() {
-	close(rl.quit)
-}
-
-// startCleaner는 오래된 버킷을 제거하는 백그라운드 작업을 시작합니다.
-func (rl *TokenBucket) startCleaner() {
-	ticker := time.NewTicker(rl.window * 2) // 윈도우의 2배 주기로 정리
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			rl.cleanExpired()
-		case <-rl.quit:
-			return
-		}
-	}
-}
-
-// cleanExpired는 토큰이 최대 용량인 버킷을 제거합니다.
-func (rl *TokenBucket) cleanExpired() {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	now := time.Now()
-	for key, b := range rl.tokens {
-		// 마지막 확인 후 경과 시간
-		elapsed := now.Sub(b.lastCheck).Seconds()
-		
-		// 현재 토큰 수 계산
-		currentTokens := min(float64(rl.capacity), b.tokens+(elapsed*rl.rate))
-		
-		// 토큰이 최대 용량에 도달한 버킷은 삭제 (일정 시간 동안 사용되지 않음)
-		if currentTokens >= float64(rl.capacity) && now.Sub(b.lastCheck) > rl.window*2 {
-			delete(rl.tokens, key)
-		}
-	}
-}
-
-// min은 두 float64 값 중 작은 값을 반환합니다.
-func min(a, b float64) float64 {
-	if a < b {
-		return a
-	}
-	return b
+	rl.stop()
 }
 
 // SlidingWindow는 슬라이딩 윈도우 알고리즘 기반 속도 제한기입니다.
 type SlidingWindow struct {
-	clients    map[string][]time.Time
-	mu         sync.RWMutex
-	window     time.Duration
+	limiter
+	window      time.Duration
 	maxRequests int
-	quit       chan struct{}
 }
 
-// NewSlidingWindow는 새로운 슬라이딩 윈도우 속도 제한기를 생성합니다.
+// NewSlidingWindow는 메모리 저장소를 사용하는 새로운 슬라이딩 윈도우 속도 제한기를 생성합니다.
 func NewSlidingWindow(window time.Duration, maxRequests int) *SlidingWindow {
-	rl := &SlidingWindow{
-		clients:     make(map[string][]time.Time),
+	rl := NewSlidingWindowWithStore(NewMemoryStore(window/2), window, maxRequests, FailOpen)
+	rl.ownsStore = true
+	return rl
+}
+
+// NewSlidingWindowWithStore는 주어진 저장소를 사용하는 슬라이딩 윈도우 속도 제한기를 생성합니다.
+// 저장소를 공유하는 모든 인스턴스가 같은 요청 기록을 사용하며, 저장소는 호출자가 닫아야 합니다.
+func NewSlidingWindowWithStore(store Store, window time.Duration, maxRequests int, failureMode FailureMode) *SlidingWindow {
+	return &SlidingWindow{
+		limiter:     limiter{store: store, failureMode: failureMode},
 		window:      window,
 		maxRequests: maxRequests,
-		quit:        make(chan struct{}),
 	}
-
-	// 청소 고루틴 시작
-	go rl.startCleaner()
-
-	return rl
 }
 
 // Allow는 주어진 키에 대한 요청을 허용할지 결정합니다.
@@ -197,103 +165,81 @@ func (rl *SlidingWindow) Allow(key string) bool {
 
 // AllowN은 주어진 키에 대해 n개의 요청을 허용할지 결정합니다.
 func (rl *SlidingWindow) AllowN(key string, n int) bool {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	now := time.Now()
-	windowStart := now.Add(-rl.window)
-	
-	// 윈도우 내의 유효한 타임스탬프만 유지
-	var validTimes []time.Time
-	for _, ts := range rl.clients[key] {
-		if ts.After(windowStart) {
-			validTimes = append(validTimes, ts)
-		}
-	}
+	return rl.AllowNStatus(key, n).Allowed
+}
 
-	// 요청 허용 여부 확인
-	if len(validTimes) + n > rl.maxRequests {
-		rl.clients[key] = validTimes
-		return false
-	}
+// AllowNStatus는 n개의 요청을 기록하고 윈도우 상태를 반환합니다.
+// ResetAfter는 마지막 요청이 윈도우를 벗어날 때까지, RetryAfter는 필요한 만큼의 기록이 윈도우를 벗어날 때까지 남은 시간입니다.
+func (rl *SlidingWindow) AllowNStatus(key string, n int) Status {
+	result, err := rl.store.SlidingLog(context.Background(), key, rl.maxRequests, rl.window, n)
+	return rl.status(result, err, rl.maxRequests, rl.window)
+}
 
-	// 새로운 타임스탬프 추가
-	for i := 0; i < n; i++ {
-		validTimes = append(validTimes, now)
-	}
-	rl.clients[key] = validTimes
-	
-	return true
+// Status는 요청을 기록하지 않고 윈도우 상태를 반환합니다.
+func (rl *SlidingWindow) Status(key string) Status {
+	return rl.AllowNStatus(key, 0)
 }
 
 // Peek는 키에 대한 현재 요청 수를 반환합니다.
 func (rl *SlidingWindow) Peek(key string) (int, bool) {
-	rl.mu.RLock()
-	defer rl.mu.RUnlock()
-
-	now := time.Now()
-	windowStart := now.Add(-rl.window)
-	
-	// 윈도우 내의 유효한 요청 수 계산
-	var count int
-	for _, ts := range rl.clients[key] {
-		if ts.After(windowStart) {
-			count++
-		}
+	result, err := rl.store.SlidingLog(context.Background(), key, rl.maxRequests, rl.window, 0)
+	if err != nil {
+		return 0, rl.failed(err)
 	}
-
-	// 요청 가능 여부 확인
-	return count, count < rl.maxRequests
+	return rl.maxRequests - result.Remaining, result.Allowed
 }
 
 // Reset은 키에 대한 요청 기록을 초기화합니다.
 func (rl *SlidingWindow) Reset(key string) {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	delete(rl.clients, key)
+	rl.reset(key)
 }
 
-// Stop은 청소 고루틴을 중지합니다.
+// Stop은 속도 제한기가 소유한 저장소를 닫습니다.
 func (rl *SlidingWindow) Stop() {
-	close(rl.quit)
+	rl.stop()
 }
 
-// startCleaner는 오래된 요청 기록을 제거하는 백그라운드 작업을 시작합니다.
-func (rl *SlidingWindow) startCleaner() {
-	ticker := time.NewTicker(rl.window / 2) // 윈도우의 절반 주기로 정리
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			rl.cleanExpired()
-		case <-rl.quit:
-			return
+// failed는 저장소 오류를 기록하고 장애 처리 방식에 따른 허용 여부를 반환합니다.
+func (l *limiter) failed(err error) bool {
+	now := time.Now().UnixNano()
+	last := l.lastErrorLog.Load()
+	if now-last >= int64(storeErrorLogInterval) && l.lastErrorLog.CompareAndSwap(last, now) {
+		log.Printf("[RATELIMIT] 저장소 오류 (장애 처리: %s): %v", l.failureMode, err)
+	}
+	return l.failureMode != FailClosed
+}
+
+// status는 저장소 연산 결과를 상태로 변환합니다.
+// 저장소 오류 시에는 장애 처리 방식에 따라 한도 전체가 남았거나(open) 남지 않은(closed) 것으로 간주합니다.
+func (l *limiter) status(result Result, err error, limit int, window time.Duration) Status {
+	if err != nil {
+		status := Status{Allowed: l.failed(err), Limit: limit, Remaining: limit, Window: window}
+		if !status.Allowed {
+			status.Remaining = 0
+			status.RetryAfter = window
+			status.ResetAfter = window
 		}
+		return status
+	}
+
+	return Status{
+		Allowed:    result.Allowed,
+		Limit:      limit,
+		Remaining:  result.Remaining,
+		RetryAfter: result.RetryAfter,
+		ResetAfter: result.ResetAfter,
+		Window:     window,
 	}
 }
 
-// cleanExpired는 만료된 요청 기록을 제거합니다.
-func (rl *SlidingWindow) cleanExpired() {
-	rl.mu.Lock()
-	defer rl.mu.Unlock()
-
-	now := time.Now()
-	windowStart := now.Add(-rl.window)
-	
-	for key, times := range rl.clients {
-		var validTimes []time.Time
-		for _, ts := range times {
-			if ts.After(windowStart) {
-				validTimes = append(validTimes, ts)
-			}
-		}
-		
-		if len(validTimes) > 0 {
-			rl.clients[key] = validTimes
-		} else {
-			delete(rl.clients, key)
-		}
+func (l *limiter) reset(key string) {
+	if err := l.store.Reset(context.Background(), key); err != nil {
+		l.failed(err)
+	}
+}
+
+func (l *limiter) stop() {
+	if l.ownsStore {
+		l.store.Close()
 	}
 }
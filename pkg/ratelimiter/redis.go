@@ -0,0 +1,238 @@
+package ratelimiter
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/isinthesky/api-gateway/pkg/redis"
+)
+
+// TokenBucketScript는 토큰 버킷 연산을 원자적으로 수행하는 Redis Lua 스크립트입니다.
+// KEYS[1]: 버킷 해시 키, ARGV: 용량, 초당 보충 속도, 사용할 토큰 수
+// 반환값: {허용 여부, 남은 토큰 수, 재시도 대기(ms), 회복 대기(ms)}
+const TokenBucketScript = `
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+local need = math.max(n, 1)
+local allowed = 0
+local retry = 0
+if tokens >= need then
+  allowed = 1
+  if n > 0 then
+    tokens = tokens - n
+  end
+else
+  retry = math.ceil((need - tokens) / rate * 1000)
+end
+local reset = math.ceil((capacity - tokens) / rate * 1000)
+if allowed == 1 and n > 0 then
+  redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+  redis.call('PEXPIRE', KEYS[1], reset + 1000)
+end
+return {allowed, math.floor(tokens), retry, reset}
+`
+
+// SlidingLogScript는 슬라이딩 로그 연산을 원자적으로 수행하는 Redis Lua 스크립트입니다.
+// KEYS[1]: 요청 시각을 저장하는 정렬 집합 키, ARGV: 한도, 윈도우(ms), 기록할 요청 수
+// 반환값: {허용 여부, 남은 요청 수, 재시도 대기(ms), 회복 대기(ms)}
+const SlidingLogScript = `
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local n = tonumber(ARGV[3])
+local t = redis.call('TIME')
+local now = tonumber(t[1]) * 1000 + math.floor(tonumber(t[2]) / 1000)
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local need = math.max(n, 1)
+local allowed = 0
+local retry = 0
+if count + need <= limit then
+  allowed = 1
+  if n > 0 then
+    for i = 1, n do
+      redis.call('ZADD', KEYS[1], now, now .. '-' .. (count + i))
+    end
+    count = count + n
+    redis.call('PEXPIRE', KEYS[1], window)
+  end
+else
+  local index = count + need - limit - 1
+  local oldest = redis.call('ZRANGE', KEYS[1], index, index, 'WITHSCORES')
+  if oldest[2] then
+    retry = tonumber(oldest[2]) + window - now
+  else
+    retry = window
+  end
+end
+local reset = 0
+local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+if newest[2] then
+  reset = tonumber(newest[2]) + window - now
+end
+return {allowed, math.max(0, limit - count), retry, reset}
+`
+
+// RedisConfig는 Redis 저장소 연결 설정입니다.
+type RedisConfig struct {
+	Addr        string        // host:port
+	Password    string        // AUTH 비밀번호 (비어 있으면 인증하지 않음)
+	DB          int           // SELECT할 데이터베이스 번호
+	KeyPrefix   string        // 모든 키 앞에 붙일 접두사 (기본 "ratelimit:")
+	DialTimeout time.Duration // 연결 수립 타임아웃 (기본 1초)
+	Timeout     time.Duration // 명령별 읽기/쓰기 타임아웃 (기본 100ms)
+	PoolSize    int           // 유지할 최대 유휴 연결 수 (기본 10)
+}
+
+// RedisStore는 Redis 프로토콜(RESP)을 사용하는 서버에 상태를 보관하는 저장소입니다.
+// 토큰 버킷과 슬라이딩 로그 연산은 Lua 스크립트로 원자적으로 수행됩니다.
+type RedisStore struct {
+	config      RedisConfig
+	client      *redis.Client
+	tokenBucket *redisScript
+	slidingLog  *redisScript
+}
+
+// NewRedisStore는 Redis 저장소를 생성합니다. 연결은 첫 요청 시 수립됩니다.
+func NewRedisStore(config RedisConfig) *RedisStore {
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "ratelimit:"
+	}
+
+	return &RedisStore{
+		config: config,
+		client: redis.NewClient(redis.Config{
+			Addr:        config.Addr,
+			Password:    config.Password,
+			DB:          config.DB,
+			DialTimeout: config.DialTimeout,
+			Timeout:     config.Timeout,
+			PoolSize:    config.PoolSize,
+		}),
+		tokenBucket: newRedisScript(TokenBucketScript),
+		slidingLog:  newRedisScript(SlidingLogScript),
+	}
+}
+
+// TokenBucket은 토큰 버킷에서 n개의 토큰을 사용합니다.
+func (s *RedisStore) TokenBucket(ctx context.Context, key string, capacity int, rate float64, n int) (Result, error) {
+	reply, err := s.eval(ctx, s.tokenBucket, s.tokenBucketKey(key),
+		strconv.Itoa(capacity), strconv.FormatFloat(rate, 'f', -1, 64), strconv.Itoa(n))
+	if err != nil {
+		return Result{}, err
+	}
+	return parseScriptResult(reply)
+}
+
+// SlidingLog는 슬라이딩 로그에 n개의 요청을 기록합니다.
+func (s *RedisStore) SlidingLog(ctx context.Context, key string, limit int, window time.Duration, n int) (Result, error) {
+	reply, err := s.eval(ctx, s.slidingLog, s.slidingLogKey(key),
+		strconv.Itoa(limit), strconv.FormatInt(window.Milliseconds(), 10), strconv.Itoa(n))
+	if err != nil {
+		return Result{}, err
+	}
+	return parseScriptResult(reply)
+}
+
+// Reset은 키의 토큰 버킷과 슬라이딩 로그 상태를 삭제합니다.
+func (s *RedisStore) Reset(ctx context.Context, key string) error {
+	_, err := s.do(ctx, "DEL", s.tokenBucketKey(key), s.slidingLogKey(key))
+	return err
+}
+
+// Ping은 서버 연결 상태를 확인합니다.
+func (s *RedisStore) Ping(ctx context.Context) error {
+	_, err := s.do(ctx, "PING")
+	return err
+}
+
+// Close는 유휴 연결을 모두 닫습니다.
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *RedisStore) tokenBucketKey(key string) string {
+	return s.config.KeyPrefix + "tb:" + key
+}
+
+func (s *RedisStore) slidingLogKey(key string) string {
+	return s.config.KeyPrefix + "sl:" + key
+}
+
+// eval은 EVALSHA로 스크립트를 실행하고, 서버에 스크립트가 없으면 EVAL로 다시 실행합니다.
+func (s *RedisStore) eval(ctx context.Context, script *redisScript, key string, args ...string) (interface{}, error) {
+	evalArgs := append([]string{script.sha, "1", key}, args...)
+	reply, err := s.do(ctx, append([]string{"EVALSHA"}, evalArgs...)...)
+
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "NOSCRIPT") {
+		evalArgs[0] = script.source
+		return s.do(ctx, append([]string{"EVAL"}, evalArgs...)...)
+	}
+	return reply, err
+}
+
+// do는 명령 하나를 실행합니다.
+func (s *RedisStore) do(ctx context.Context, args ...string) (interface{}, error) {
+	reply, err := s.client.Do(ctx, args...)
+	if errors.Is(err, redis.ErrClosed) {
+		return nil, ErrStoreClosed
+	}
+	return reply, err
+}
+
+// parseScriptResult는 {허용 여부, 남은 수, 재시도 대기(ms), 회복 대기(ms)} 형식의 스크립트 응답을 해석합니다.
+func parseScriptResult(reply interface{}) (Result, error) {
+	values, ok := reply.([]interface{})
+	if !ok || len(values) != 4 {
+		return Result{}, fmt.Errorf("unexpected script reply: %v", reply)
+	}
+
+	ints := make([]int64, len(values))
+	for i, v := range values {
+		n, ok := v.(int64)
+		if !ok {
+			return Result{}, fmt.Errorf("unexpected script reply: %v", reply)
+		}
+		ints[i] = n
+	}
+
+	return Result{
+		Allowed:    ints[0] == 1,
+		Remaining:  int(ints[1]),
+		RetryAfter: time.Duration(ints[2]) * time.Millisecond,
+		ResetAfter: time.Duration(ints[3]) * time.Millisecond,
+	}, nil
+}
+
+// redisScript는 Lua 스크립트 원문과 SHA1 해시입니다.
+type redisScript struct {
+	source string
+	sha    string
+}
+
+func newRedisScript(source string) *redisScript {
+	return &redisScript{source: source, sha: scriptSHA(source)}
+}
+
+// scriptSHA는 EVALSHA에 사용하는 스크립트의 SHA1 해시를 반환합니다.
+func scriptSHA(source string) string {
+	sum := sha1.Sum([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,254 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrStoreClosed는 닫힌 저장소를 사용하려 할 때 반환됩니다.
+var ErrStoreClosed = errors.New("rate limit store is closed")
+
+// FailureMode는 저장소에 접근할 수 없을 때 요청을 처리하는 방식입니다.
+type FailureMode string
+
+const (
+	FailOpen   FailureMode = "open"   // 저장소 장애 시 요청 허용
+	FailClosed FailureMode = "closed" // 저장소 장애 시 요청 거부
+)
+
+// Result는 저장소 연산 결과입니다.
+type Result struct {
+	Allowed    bool          // 요청 허용 여부 (n이 0이면 요청 1개를 허용할 수 있는지 여부)
+	Remaining  int           // 연산 후 남은 토큰 수 또는 윈도우 내 남은 요청 수
+	RetryAfter time.Duration // 거부된 경우 요청이 허용될 때까지 남은 시간
+	ResetAfter time.Duration // 한도가 모두 회복될 때까지 남은 시간
+}
+
+// Store는 속도 제한 상태를 보관하는 저장소입니다.
+// 여러 게이트웨이 인스턴스가 같은 저장소를 공유하면 한도가 인스턴스 전체에 적용됩니다.
+// 각 연산은 원자적으로 수행되어야 하며, n이 0이면 상태를 바꾸지 않고 조회만 합니다.
+type Store interface {
+	// TokenBucket은 용량 capacity, 초당 보충 속도 rate인 토큰 버킷에서 n개의 토큰을 사용합니다.
+	TokenBucket(ctx context.Context, key string, capacity int, rate float64, n int) (Result, error)
+	// SlidingLog는 window 동안 limit개까지 허용하는 슬라이딩 로그에 n개의 요청을 기록합니다.
+	SlidingLog(ctx context.Context, key string, limit int, window time.Duration, n int) (Result, error)
+	// Reset은 키의 토큰 버킷과 슬라이딩 로그 상태를 모두 삭제합니다.
+	Reset(ctx context.Context, key string) error
+	// Close는 저장소가 사용하는 자원을 해제합니다.
+	Close() error
+}
+
+// memoryBucket은 메모리 저장소의 토큰 버킷 상태입니다.
+type memoryBucket struct {
+	tokens    float64
+	lastCheck time.Time
+	expires   time.Time // 버킷이 가득 차 삭제해도 되는 시각
+}
+
+// memoryLog는 메모리 저장소의 슬라이딩 로그 상태입니다.
+type memoryLog struct {
+	times   []time.Time
+	expires time.Time // 모든 기록이 윈도우를 벗어나는 시각
+}
+
+// MemoryStore는 프로세스 메모리에 상태를 보관하는 저장소입니다. 인스턴스 간에 공유되지 않습니다.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	logs    map[string]*memoryLog
+	quit    chan struct{}
+	closed  bool
+}
+
+// NewMemoryStore는 cleanupInterval마다 만료된 상태를 정리하는 메모리 저장소를 생성합니다.
+func NewMemoryStore(cleanupInterval time.Duration) *MemoryStore {
+	s := &MemoryStore{
+		buckets: make(map[string]*memoryBucket),
+		logs:    make(map[string]*memoryLog),
+		quit:    make(chan struct{}),
+	}
+
+	if cleanupInterval > 0 {
+		go s.startCleaner(cleanupInterval)
+	}
+
+	return s
+}
+
+// TokenBucket은 토큰 버킷에서 n개의 토큰을 사용합니다.
+func (s *MemoryStore) TokenBucket(ctx context.Context, key string, capacity int, rate float64, n int) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return Result{}, ErrStoreClosed
+	}
+
+	now := time.Now()
+
+	// 버킷이 없으면 가득 찬 상태로 시작
+	tokens := float64(capacity)
+	if b, ok := s.buckets[key]; ok {
+		elapsed := now.Sub(b.lastCheck).Seconds()
+		tokens = math.Min(float64(capacity), b.tokens+elapsed*rate)
+	}
+
+	result := takeTokens(tokens, capacity, rate, n)
+	if result.Allowed && n > 0 {
+		tokens -= float64(n)
+		s.buckets[key] = &memoryBucket{
+			tokens:    tokens,
+			lastCheck: now,
+			expires:   now.Add(result.ResetAfter),
+		}
+	}
+
+	return result, nil
+}
+
+// takeTokens는 현재 토큰 수로 n개 토큰 사용 결과를 계산합니다 (Redis 스크립트와 같은 규칙).
+func takeTokens(tokens float64, capacity int, rate float64, n int) Result {
+	need := math.Max(float64(n), 1)
+	allowed := tokens >= need
+	if allowed && n > 0 {
+		tokens -= float64(n)
+	}
+
+	result := Result{
+		Allowed:    allowed,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: secondsToDuration((float64(capacity) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((need - tokens) / rate)
+	}
+	return result
+}
+
+// SlidingLog는 슬라이딩 로그에 n개의 요청을 기록합니다.
+func (s *MemoryStore) SlidingLog(ctx context.Context, key string, limit int, window time.Duration, n int) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return Result{}, ErrStoreClosed
+	}
+
+	now := time.Now()
+	windowStart := now.Add(-window)
+
+	// 윈도우 내의 유효한 기록만 유지
+	var valid []time.Time
+	if l, ok := s.logs[key]; ok {
+		for _, ts := range l.times {
+			if ts.After(windowStart) {
+				valid = append(valid, ts)
+			}
+		}
+	}
+
+	need := n
+	if need < 1 {
+		need = 1
+	}
+	allowed := len(valid)+need <= limit
+	if allowed && n > 0 {
+		for i := 0; i < n; i++ {
+			valid = append(valid, now)
+		}
+	}
+
+	if len(valid) > 0 {
+		s.logs[key] = &memoryLog{times: valid, expires: valid[len(valid)-1].Add(window)}
+	} else {
+		delete(s.logs, key)
+	}
+
+	result := Result{Allowed: allowed, Remaining: limit - len(valid)}
+	if result.Remaining < 0 {
+		result.Remaining = 0
+	}
+	if len(valid) > 0 {
+		result.ResetAfter = valid[len(valid)-1].Add(window).Sub(now)
+	}
+	if !allowed {
+		// 요청이 허용되려면 가장 오래된 기록부터 필요한 만큼 윈도우를 벗어나야 함
+		index := len(valid) + need - limit - 1
+		if index >= 0 && index < len(valid) {
+			result.RetryAfter = valid[index].Add(window).Sub(now)
+		} else {
+			result.RetryAfter = window
+		}
+	}
+
+	return result, nil
+}
+
+// Reset은 키의 상태를 삭제합니다.
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.buckets, key)
+	delete(s.logs, key)
+	return nil
+}
+
+// Close는 정리 고루틴을 중지합니다.
+func (s *MemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.quit)
+	}
+	return nil
+}
+
+// startCleaner는 만료된 상태를 제거하는 백그라운드 작업을 시작합니다.
+func (s *MemoryStore) startCleaner(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.cleanExpired()
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// cleanExpired는 가득 찬 토큰 버킷과 모든 기록이 만료된 슬라이딩 로그를 제거합니다.
+func (s *MemoryStore) cleanExpired() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for key, b := range s.buckets {
+		if !now.Before(b.expires) {
+			delete(s.buckets, key)
+		}
+	}
+	for key, l := range s.logs {
+		if !now.Before(l.expires) {
+			delete(s.logs, key)
+		}
+	}
+}
+
+// secondsToDuration은 초 단위 실수를 time.Duration으로 변환합니다 (음수는 0).
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 || math.IsNaN(seconds) {
+		return 0
+	}
+	if math.IsInf(seconds, 1) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,258 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrClosed는 닫힌 클라이언트를 사용하려 할 때 반환됩니다.
+var ErrClosed = errors.New("redis client is closed")
+
+// Config는 Redis 연결 설정입니다.
+type Config struct {
+	Addr        string        // host:port
+	Password    string        // AUTH 비밀번호 (비어 있으면 인증하지 않음)
+	DB          int           // SELECT할 데이터베이스 번호
+	DialTimeout time.Duration // 연결 수립 타임아웃 (기본 1초)
+	Timeout     time.Duration // 명령별 읽기/쓰기 타임아웃 (기본 100ms)
+	PoolSize    int           // 유지할 최대 유휴 연결 수 (기본 10)
+}
+
+// Client는 Redis 프로토콜(RESP)을 사용하는 서버의 연결 풀 클라이언트입니다.
+type Client struct {
+	config Config
+	pool   chan *conn
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewClient는 클라이언트를 생성합니다. 연결은 첫 명령 실행 시 수립됩니다.
+func NewClient(config Config) *Client {
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 100 * time.Millisecond
+	}
+	if config.PoolSize <= 0 {
+		config.PoolSize = 10
+	}
+
+	return &Client{
+		config: config,
+		pool:   make(chan *conn, config.PoolSize),
+	}
+}
+
+// Do는 풀에서 연결을 얻어 명령 하나를 실행합니다.
+// 단순 문자열과 벌크 문자열은 string, 정수는 int64, 배열은 []interface{}, 널은 nil로 반환되며,
+// 서버 오류 응답은 Error로 반환됩니다.
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := cn.do(ctx, c.config.Timeout, args...)
+
+	// 서버 오류 응답은 연결 상태와 무관하므로 연결을 재사용
+	var serverErr Error
+	if err != nil && !errors.As(err, &serverErr) {
+		cn.Close()
+		return nil, err
+	}
+
+	c.put(cn)
+	return reply, err
+}
+
+// Close는 유휴 연결을 모두 닫습니다.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	for {
+		select {
+		case cn := <-c.pool:
+			cn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// get은 유휴 연결을 반환하거나 새 연결을 수립합니다.
+func (c *Client) get(ctx context.Context) (*conn, error) {
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+
+	select {
+	case cn := <-c.pool:
+		return cn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: c.config.DialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.config.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("redis dial failed: %w", err)
+	}
+
+	cn := &conn{
+		conn:   netConn,
+		reader: bufio.NewReader(netConn),
+		writer: bufio.NewWriter(netConn),
+	}
+
+	if c.config.Password != "" {
+		if _, err := cn.do(ctx, c.config.Timeout, "AUTH", c.config.Password); err != nil {
+			cn.Close()
+			return nil, fmt.Errorf("redis auth failed: %w", err)
+		}
+	}
+	if c.config.DB != 0 {
+		if _, err := cn.do(ctx, c.config.Timeout, "SELECT", strconv.Itoa(c.config.DB)); err != nil {
+			cn.Close()
+			return nil, fmt.Errorf("redis select failed: %w", err)
+		}
+	}
+
+	return cn, nil
+}
+
+// put은 연결을 풀에 반환합니다. 풀이 가득 찼거나 클라이언트가 닫혔으면 연결을 닫습니다.
+func (c *Client) put(cn *conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		cn.Close()
+		return
+	}
+
+	select {
+	case c.pool <- cn:
+	default:
+		cn.Close()
+	}
+}
+
+// Error는 서버가 반환한 오류 응답입니다.
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// conn은 RESP 프로토콜로 통신하는 단일 연결입니다.
+type conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *bufio.Writer
+}
+
+// do는 명령을 전송하고 응답을 읽습니다.
+func (c *conn) do(ctx context.Context, timeout time.Duration, args ...string) (interface{}, error) {
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeCommand(c.writer, args...); err != nil {
+		return nil, err
+	}
+	if err := c.writer.Flush(); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.reader)
+}
+
+func (c *conn) Close() error {
+	return c.conn.Close()
+}
+
+// writeCommand는 명령을 RESP 배열로 기록합니다.
+func writeCommand(w *bufio.Writer, args ...string) error {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return nil
+}
+
+// readReply는 RESP 응답 하나를 읽습니다.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return string(buf[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, count)
+		for i := range values {
+			v, err := readReply(r)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply type %q", line[0])
+	}
+}
+
+// readLine은 CRLF로 끝나는 한 줄을 읽어 CRLF를 제외하고 반환합니다.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync/atomic"
 	"time"
 
@@ -45,6 +46,11 @@ func NewFaultServer() *FaultServer {
     router.GET("/api/circuit-test/failing-endpoint", fs.failingEndpointHandler)
     router.GET("/api/circuit-test/recovered-endpoint", fs.recoveredEndpointHandler)
 
+    // 실패 분류 테스트 엔드포인트
+    router.GET("/api/status/:code", fs.statusHandler)
+    router.GET("/api/delay/:ms", fs.delayHandler)
+    router.GET("/api/connection-reset", fs.connectionResetHandler)
+
     server := httptest.NewServer(router)
     fs.Server = server
 
@@ -148,3 +154,53 @@ func (fs *FaultServer) recoveredEndpointHandler(c *gin.Context) {
         "circuitState": "closed",
     })
 }
+
+// statusHandler는 경로에 지정된 상태 코드로 응답합니다 (예: /api/status/503)
+func (fs *FaultServer) statusHandler(c *gin.Context) {
+    code, err := strconv.Atoi(c.Param("code"))
+    if err != nil || code < 100 || code > 599 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "유효하지 않은 상태 코드"})
+        return
+    }
+
+    atomic.AddInt32(&fs.FailureCount, 1)
+    c.JSON(code, gin.H{"status": code})
+}
+
+// delayHandler는 경로에 지정된 시간(밀리초)만큼 지연 후 성공 응답합니다 (예: /api/delay/200)
+func (fs *FaultServer) delayHandler(c *gin.Context) {
+    ms, err := strconv.Atoi(c.Param("ms"))
+    if err != nil || ms < 0 {
+        c.JSON(http.StatusBadRequest, gin.H{"error": "유효하지 않은 지연 시간"})
+        return
+    }
+
+    atomic.AddInt32(&fs.SlowCount, 1)
+    select {
+    case <-time.After(time.Duration(ms) * time.Millisecond):
+    case <-c.Request.Context().Done():
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{
+        "message": "지연 후 성공적인 응답",
+        "delay":   ms,
+    })
+}
+
+// connectionResetHandler는 응답 없이 연결을 끊습니다 (전송 계층 오류 시뮬레이션)
+func (fs *FaultServer) connectionResetHandler(c *gin.Context) {
+    atomic.AddInt32(&fs.FailureCount, 1)
+
+    hijacker, ok := c.Writer.(http.Hijacker)
+    if !ok {
+        c.AbortWithStatus(http.StatusInternalServerError)
+        return
+    }
+
+    conn, _, err := hijacker.Hijack()
+    if err != nil {
+        return
+    }
+    conn.Close()
+}
@@ -0,0 +1,198 @@
+package mocks
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCServer는 브라우저 로그인 테스트용 OIDC IdP입니다.
+// 인가 요청은 로그인 화면 없이 바로 승인하여 콜백으로 리디렉션하며, 인가 코드 교환 시 PKCE(S256)를 확인합니다.
+// 액세스 토큰과 ID 토큰은 SigningKey로 서명한 HS256 JWT이고, 갱신 토큰은 사용할 때마다 교체됩니다.
+type OIDCServer struct {
+	Server *httptest.Server
+
+	ClientID       string        // 허용하는 클라이언트 ID
+	Subject        string        // 발급하는 토큰의 sub
+	TokenIssuer    string        // 발급하는 토큰의 iss
+	SigningKey     string        // 토큰 서명 키
+	AccessTokenTTL time.Duration // 액세스 토큰 유효 기간 (expires_in)
+
+	mu            sync.Mutex
+	codes         map[string]oidcAuthorization // 인가 코드 -> 인가 요청
+	refreshTokens map[string]bool              // 유효한 갱신 토큰
+	calls         map[string]int               // 엔드포인트별 호출 횟수
+}
+
+// oidcAuthorization은 인가 코드를 발급한 인가 요청입니다.
+type oidcAuthorization struct {
+	challenge   string
+	nonce       string
+	redirectURI string
+}
+
+// NewOIDCServer는 로컬 포트에서 대기하는 OIDC IdP를 생성합니다.
+func NewOIDCServer(clientID, tokenIssuer, signingKey string) *OIDCServer {
+	s := &OIDCServer{
+		ClientID:       clientID,
+		Subject:        "user-1",
+		TokenIssuer:    tokenIssuer,
+		SigningKey:     signingKey,
+		AccessTokenTTL: time.Hour,
+		codes:          make(map[string]oidcAuthorization),
+		refreshTokens:  make(map[string]bool),
+		calls:          make(map[string]int),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/revoke", s.revoke)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// URL은 IdP 발급자 URL을 반환합니다.
+func (s *OIDCServer) URL() string {
+	return s.Server.URL
+}
+
+// Calls는 엔드포인트(authorize, token, refresh, revoke)의 호출 횟수를 반환합니다.
+func (s *OIDCServer) Calls(endpoint string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[endpoint]
+}
+
+// Close는 서버를 종료합니다.
+func (s *OIDCServer) Close() {
+	s.Server.Close()
+}
+
+func (s *OIDCServer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 s.URL(),
+		"authorization_endpoint": s.URL() + "/authorize",
+		"token_endpoint":         s.URL() + "/token",
+		"revocation_endpoint":    s.URL() + "/revoke",
+		"end_session_endpoint":   s.URL() + "/logout",
+	})
+}
+
+func (s *OIDCServer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != s.ClientID || query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	code := randomValue()
+	s.mu.Lock()
+	s.calls["authorize"]++
+	s.codes[code] = oidcAuthorization{
+		challenge:   query.Get("code_challenge"),
+		nonce:       query.Get("nonce"),
+		redirectURI: query.Get("redirect_uri"),
+	}
+	s.mu.Unlock()
+
+	callback, _ := url.Parse(query.Get("redirect_uri"))
+	values := callback.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	callback.RawQuery = values.Encode()
+	http.Redirect(w, r, callback.String(), http.StatusFound)
+}
+
+func (s *OIDCServer) token(w http.ResponseWriter, r *http.Request) {
+	if r.PostFormValue("client_id") != s.ClientID {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nonce := ""
+	switch r.PostFormValue("grant_type") {
+	case "authorization_code":
+		s.calls["token"]++
+		authorization, ok := s.codes[r.PostFormValue("code")]
+		delete(s.codes, r.PostFormValue("code"))
+		sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if !ok || authorization.redirectURI != r.PostFormValue("redirect_uri") ||
+			base64.RawURLEncoding.EncodeToString(sum[:]) != authorization.challenge {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		nonce = authorization.nonce
+
+	case "refresh_token":
+		s.calls["refresh"]++
+		if !s.refreshTokens[r.PostFormValue("refresh_token")] {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+			return
+		}
+		delete(s.refreshTokens, r.PostFormValue("refresh_token"))
+
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	refreshToken := randomValue()
+	s.refreshTokens[refreshToken] = true
+	response := map[string]interface{}{
+		"access_token":  s.sign(jwt.MapClaims{"jti": randomValue(), "roles": []string{"user"}}, s.AccessTokenTTL),
+		"refresh_token": refreshToken,
+		"token_type":    "Bearer",
+		"expires_in":    int(s.AccessTokenTTL / time.Second),
+	}
+	if nonce != "" {
+		response["id_token"] = s.sign(jwt.MapClaims{"aud": s.ClientID, "nonce": nonce}, time.Hour)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *OIDCServer) revoke(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.calls["revoke"]++
+	delete(s.refreshTokens, r.PostFormValue("token"))
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+// sign은 IdP가 발급하는 토큰을 서명합니다.
+func (s *OIDCServer) sign(claims jwt.MapClaims, ttl time.Duration) string {
+	now := time.Now()
+	claims["sub"] = s.Subject
+	claims["iss"] = s.TokenIssuer
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(ttl).Unix()
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.SigningKey))
+	return token
+}
+
+// randomValue는 인가 코드와 토큰에 사용할 임의의 문자열을 생성합니다.
+func randomValue() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// writeJSON은 JSON 응답을 작성합니다.
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
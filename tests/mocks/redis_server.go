@@ -0,0 +1,484 @@
+package mocks
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
+)
+
+// RedisServer는 속도 제한 저장소와 캐시 테스트용 인프로세스 Redis 프로토콜 서버입니다.
+// 속도 제한과 토큰 폐기 Lua 스크립트는 같은 의미의 메모리 연산으로 실행하며,
+// 문자열 키는 GET, SET(PX/EX), DEL, SCAN, PTTL을 지원합니다.
+type RedisServer struct {
+	listener net.Listener
+	store    *ratelimiter.MemoryStore
+	password string
+
+	mu      sync.Mutex
+	scripts map[string]string // SHA1 -> 로드된 스크립트 원문
+	values  map[string]redisValue
+	conns   map[net.Conn]bool
+	calls   map[string]int // 명령별 호출 횟수
+	closed  bool
+}
+
+// NewRedisServer는 임의의 로컬 포트에서 대기하는 Redis 프로토콜 서버를 생성합니다.
+// password가 비어 있지 않으면 AUTH를 요구합니다.
+func NewRedisServer(password string) (*RedisServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &RedisServer{
+		listener: listener,
+		store:    ratelimiter.NewMemoryStore(0),
+		password: password,
+		scripts:  make(map[string]string),
+		values:   make(map[string]redisValue),
+		conns:    make(map[net.Conn]bool),
+		calls:    make(map[string]int),
+	}
+	go s.serve()
+
+	return s, nil
+}
+
+// redisValue는 만료 시각을 가진 문자열 값입니다.
+type redisValue struct {
+	value  string
+	expiry time.Time // 0이면 만료되지 않음
+}
+
+// Addr은 서버 주소(host:port)를 반환합니다.
+func (s *RedisServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+// Calls는 명령(대문자)의 호출 횟수를 반환합니다.
+func (s *RedisServer) Calls(command string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[command]
+}
+
+// FlushScripts는 로드된 스크립트를 모두 삭제합니다 (SCRIPT FLUSH).
+func (s *RedisServer) FlushScripts() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts = make(map[string]string)
+}
+
+// Close는 서버를 중지하고 열린 연결을 모두 닫습니다.
+func (s *RedisServer) Close() {
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.listener.Close()
+	s.store.Close()
+}
+
+func (s *RedisServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = true
+		s.mu.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+func (s *RedisServer) handle(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	authenticated := s.password == ""
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+
+		command := strings.ToUpper(args[0])
+		s.mu.Lock()
+		s.calls[command]++
+		s.mu.Unlock()
+
+		if !authenticated && command != "AUTH" {
+			writeError(writer, "NOAUTH Authentication required.")
+		} else if command == "AUTH" {
+			if len(args) == 2 && args[1] == s.password {
+				authenticated = true
+				writeSimple(writer, "OK")
+			} else {
+				writeError(writer, "WRONGPASS invalid username-password pair")
+			}
+		} else {
+			s.execute(writer, command, args[1:])
+		}
+
+		if err := writer.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// execute는 인증된 연결의 명령을 실행하고 응답을 기록합니다.
+func (s *RedisServer) execute(w *bufio.Writer, command string, args []string) {
+	switch command {
+	case "PING":
+		writeSimple(w, "PONG")
+	case "SELECT":
+		writeSimple(w, "OK")
+	case "SCRIPT":
+		if len(args) == 2 && strings.ToUpper(args[0]) == "LOAD" {
+			writeBulk(w, s.loadScript(args[1]))
+		} else if len(args) == 1 && strings.ToUpper(args[0]) == "FLUSH" {
+			s.FlushScripts()
+			writeSimple(w, "OK")
+		} else {
+			writeError(w, "ERR unsupported SCRIPT subcommand")
+		}
+	case "EVAL":
+		if len(args) < 2 {
+			writeError(w, "ERR wrong number of arguments for 'eval' command")
+			return
+		}
+		s.loadScript(args[0])
+		s.runScript(w, args[0], args[1:])
+	case "EVALSHA":
+		if len(args) < 2 {
+			writeError(w, "ERR wrong number of arguments for 'evalsha' command")
+			return
+		}
+		s.mu.Lock()
+		source, ok := s.scripts[strings.ToLower(args[0])]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, "NOSCRIPT No matching script. Please use EVAL.")
+			return
+		}
+		s.runScript(w, source, args[1:])
+	case "DEL":
+		s.mu.Lock()
+		for _, key := range args {
+			delete(s.values, key)
+		}
+		s.mu.Unlock()
+		for _, key := range args {
+			s.store.Reset(context.Background(), key)
+		}
+		fmt.Fprintf(w, ":%d\r\n", len(args))
+	case "GET":
+		if len(args) != 1 {
+			writeError(w, "ERR wrong number of arguments for 'get' command")
+			return
+		}
+		if value, ok := s.lookup(args[0]); ok {
+			writeBulk(w, value.value)
+		} else {
+			w.WriteString("$-1\r\n")
+		}
+	case "SET":
+		s.set(w, args)
+	case "PTTL":
+		if len(args) != 1 {
+			writeError(w, "ERR wrong number of arguments for 'pttl' command")
+			return
+		}
+		value, ok := s.lookup(args[0])
+		switch {
+		case !ok:
+			w.WriteString(":-2\r\n")
+		case value.expiry.IsZero():
+			w.WriteString(":-1\r\n")
+		default:
+			fmt.Fprintf(w, ":%d\r\n", ceilMillis(time.Until(value.expiry)))
+		}
+	case "SCAN":
+		s.scan(w, args)
+	default:
+		writeError(w, fmt.Sprintf("ERR unknown command '%s'", command))
+	}
+}
+
+// lookup은 만료되지 않은 문자열 값을 반환합니다.
+func (s *RedisServer) lookup(key string) (redisValue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if ok && !value.expiry.IsZero() && time.Now().After(value.expiry) {
+		delete(s.values, key)
+		return redisValue{}, false
+	}
+	return value, ok
+}
+
+// set은 SET key value [PX ms | EX s]를 실행합니다.
+func (s *RedisServer) set(w *bufio.Writer, args []string) {
+	if len(args) != 2 && len(args) != 4 {
+		writeError(w, "ERR syntax error")
+		return
+	}
+
+	value := redisValue{value: args[1]}
+	if len(args) == 4 {
+		n, err := strconv.ParseInt(args[3], 10, 64)
+		if err != nil || n <= 0 {
+			writeError(w, "ERR invalid expire time in 'set' command")
+			return
+		}
+		switch strings.ToUpper(args[2]) {
+		case "PX":
+			value.expiry = time.Now().Add(time.Duration(n) * time.Millisecond)
+		case "EX":
+			value.expiry = time.Now().Add(time.Duration(n) * time.Second)
+		default:
+			writeError(w, "ERR syntax error")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	s.values[args[0]] = value
+	s.mu.Unlock()
+	writeSimple(w, "OK")
+}
+
+// scan은 SCAN cursor [MATCH pattern] [COUNT n]을 실행합니다. 일치하는 모든 키를 한 번에 반환합니다.
+func (s *RedisServer) scan(w *bufio.Writer, args []string) {
+	pattern := "*"
+	for i := 1; i+1 < len(args); i += 2 {
+		if strings.ToUpper(args[i]) == "MATCH" {
+			pattern = args[i+1]
+		}
+	}
+
+	s.mu.Lock()
+	var keys []string
+	for key := range s.values {
+		if globMatch(pattern, key) {
+			keys = append(keys, key)
+		}
+	}
+	s.mu.Unlock()
+
+	w.WriteString("*2\r\n")
+	writeBulk(w, "0")
+	fmt.Fprintf(w, "*%d\r\n", len(keys))
+	for _, key := range keys {
+		writeBulk(w, key)
+	}
+}
+
+// globMatch는 Redis 패턴(*, ?, \ 이스케이프)과 문자열이 일치하는지 확인합니다.
+func globMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := 0; i <= len(s); i++ {
+				if globMatch(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return len(s) == 0
+}
+
+func (s *RedisServer) loadScript(source string) string {
+	sum := sha1.Sum([]byte(source))
+	sha := hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[sha] = source
+	return sha
+}
+
+// runScript는 속도 제한과 토큰 폐기 스크립트를 메모리 연산으로 실행합니다.
+// args: numkeys, KEYS..., ARGV...
+func (s *RedisServer) runScript(w *bufio.Writer, source string, args []string) {
+	numKeys, err := strconv.Atoi(args[0])
+	if err != nil || numKeys != 1 || len(args) < 2 {
+		writeError(w, "ERR unsupported script arguments")
+		return
+	}
+	key, argv := args[1], args[2:]
+
+	if source == auth.RevokeScript {
+		s.revoke(w, key, argv)
+		return
+	}
+	if len(argv) != 3 {
+		writeError(w, "ERR unsupported script arguments")
+		return
+	}
+
+	var result ratelimiter.Result
+	switch source {
+	case ratelimiter.TokenBucketScript:
+		capacity, _ := strconv.Atoi(argv[0])
+		rate, _ := strconv.ParseFloat(argv[1], 64)
+		n, _ := strconv.Atoi(argv[2])
+		result, err = s.store.TokenBucket(context.Background(), key, capacity, rate, n)
+	case ratelimiter.SlidingLogScript:
+		limit, _ := strconv.Atoi(argv[0])
+		window, _ := strconv.ParseInt(argv[1], 10, 64)
+		n, _ := strconv.Atoi(argv[2])
+		result, err = s.store.SlidingLog(context.Background(), key, limit, time.Duration(window)*time.Millisecond, n)
+	default:
+		writeError(w, "ERR unsupported script")
+		return
+	}
+	if err != nil {
+		writeError(w, "ERR "+err.Error())
+		return
+	}
+
+	allowed := 0
+	if result.Allowed {
+		allowed = 1
+	}
+	fmt.Fprintf(w, "*4\r\n:%d\r\n:%d\r\n:%d\r\n:%d\r\n",
+		allowed, result.Remaining, ceilMillis(result.RetryAfter), ceilMillis(result.ResetAfter))
+}
+
+// revoke는 토큰 폐기 스크립트를 실행합니다. 기존 항목의 더 늦은 폐기 시각과 만료 시각을 유지합니다.
+// argv: 폐기 시각(유닉스 나노초), 남은 유효 시간(ms)
+func (s *RedisServer) revoke(w *bufio.Writer, key string, argv []string) {
+	if len(argv) != 2 {
+		writeError(w, "ERR unsupported script arguments")
+		return
+	}
+	revokedAt, err := strconv.ParseInt(argv[0], 10, 64)
+	if err != nil {
+		writeError(w, "ERR invalid revocation time")
+		return
+	}
+	ttl, err := strconv.ParseInt(argv[1], 10, 64)
+	if err != nil || ttl <= 0 {
+		writeError(w, "ERR invalid expire time in 'set' command")
+		return
+	}
+
+	now := time.Now()
+	expiry := now.Add(time.Duration(ttl) * time.Millisecond)
+
+	s.mu.Lock()
+	if current, ok := s.values[key]; ok && (current.expiry.IsZero() || now.Before(current.expiry)) {
+		if existing, err := strconv.ParseInt(current.value, 10, 64); err == nil && existing > revokedAt {
+			revokedAt = existing
+		}
+		if current.expiry.After(expiry) {
+			expiry = current.expiry
+		}
+	}
+	s.values[key] = redisValue{value: strconv.FormatInt(revokedAt, 10), expiry: expiry}
+	s.mu.Unlock()
+	writeSimple(w, "OK")
+}
+
+// ceilMillis는 시간을 밀리초 단위로 올림합니다.
+func ceilMillis(d time.Duration) int64 {
+	return int64(math.Ceil(float64(d) / float64(time.Millisecond)))
+}
+
+// readCommand는 RESP 배열로 된 명령 하나를 읽습니다.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil // 인라인 명령
+	}
+
+	count, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimRight(header, "\r\n")[1:])
+		if err != nil {
+			return nil, err
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func writeSimple(w *bufio.Writer, value string) {
+	fmt.Fprintf(w, "+%s\r\n", value)
+}
+
+func writeError(w *bufio.Writer, message string) {
+	fmt.Fprintf(w, "-%s\r\n", message)
+}
+
+func writeBulk(w *bufio.Writer, value string) {
+	fmt.Fprintf(w, "$%d\r\n%s\r\n", len(value), value)
+}
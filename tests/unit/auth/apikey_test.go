@@ -0,0 +1,87 @@
+// +build unit
+
+package auth_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+)
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	key, hash, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+	assert.Equal(t, auth.HashAPIKey(key), hash)
+
+	expired := time.Now().Add(-time.Hour)
+	keysPath := filepath.Join(t.TempDir(), "api-keys.json")
+	content := fmt.Sprintf(`{"keys":[
+		{"id":"reports","hash":"%s","consumer":"report-generator","roles":["reports"],"rateLimit":{"limit":10,"window":60}},
+		{"id":"disabled","hash":"%s","consumer":"old-job","disabled":true},
+		{"id":"expired","hash":"%s","consumer":"cron","expiresAt":"%s"}
+	]}`, hash, auth.HashAPIKey("disabled-key"), auth.HashAPIKey("expired-key"), expired.Format(time.RFC3339))
+	require.NoError(t, os.WriteFile(keysPath, []byte(content), 0600))
+
+	store, err := auth.LoadAPIKeys(keysPath)
+	require.NoError(t, err)
+	authenticator := auth.NewAPIKeyAuthenticator(store)
+
+	t.Run("VerifyReturnsConsumerClaims", func(t *testing.T) {
+		claims, err := authenticator.VerifyToken(key)
+		require.NoError(t, err)
+		assert.Equal(t, "report-generator", claims.Subject)
+		assert.Equal(t, []string{"reports"}, claims.Roles)
+		assert.Equal(t, "reports", claims.ID)
+
+		keyID, found := claims.Lookup("key_id")
+		assert.True(t, found)
+		assert.Equal(t, "reports", keyID)
+
+		apiKey, err := authenticator.Lookup(key)
+		require.NoError(t, err)
+		require.NotNil(t, apiKey.RateLimit)
+		assert.Equal(t, 10, apiKey.RateLimit.Limit)
+	})
+
+	t.Run("RejectsUnknownDisabledAndExpiredKeys", func(t *testing.T) {
+		_, err := authenticator.VerifyToken("gw_unknown")
+		assert.True(t, errors.Is(err, auth.ErrAPIKeyNotFound))
+
+		_, err = authenticator.VerifyToken("disabled-key")
+		assert.Error(t, err, "비활성화된 키는 거부해야 함")
+
+		_, err = authenticator.VerifyToken("expired-key")
+		assert.Error(t, err, "만료된 키는 거부해야 함")
+
+		_, err = authenticator.VerifyToken("")
+		assert.Error(t, err)
+	})
+
+	t.Run("GenerateTokenUnsupported", func(t *testing.T) {
+		_, err := authenticator.GenerateToken("user-1", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidKeyFile", func(t *testing.T) {
+		for name, keys := range map[string]string{
+			"PlainTextKey":    `[{"id":"a","hash":"not-a-hash","consumer":"c"}]`,
+			"MissingID":       fmt.Sprintf(`[{"hash":"%s","consumer":"c"}]`, hash),
+			"MissingConsumer": fmt.Sprintf(`[{"id":"a","hash":"%s"}]`, hash),
+			"DuplicateID":     fmt.Sprintf(`[{"id":"a","hash":"%s","consumer":"c"},{"id":"a","hash":"%s","consumer":"c"}]`, hash, auth.HashAPIKey("other")),
+			"InvalidLimit":    fmt.Sprintf(`[{"id":"a","hash":"%s","consumer":"c","rateLimit":{"limit":0,"window":60}}]`, hash),
+		} {
+			path := filepath.Join(t.TempDir(), name+".json")
+			require.NoError(t, os.WriteFile(path, []byte(`{"keys":`+keys+`}`), 0600))
+			_, err := auth.LoadAPIKeys(path)
+			assert.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,274 @@
+// +build unit
+
+package auth_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+)
+
+const (
+	testIssuer     = "test-issuer"
+	externalIssuer = "https://idp.example.com"
+)
+
+// signToken은 지정한 서명 방식과 키로 토큰을 서명합니다. kid가 비어 있으면 헤더에 넣지 않습니다.
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid, issuer string) string {
+	now := time.Now()
+	token := jwt.NewWithClaims(method, &auth.Claims{
+		Roles: []string{"user"},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// writePublicKeyPEM은 공개 키를 PKIX PEM 파일로 저장합니다.
+func writePublicKeyPEM(t *testing.T, dir, name string, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0644))
+	return path
+}
+
+// jwkFor는 공개 키를 JWK로 변환합니다.
+func jwkFor(kid string, key crypto.PublicKey) map[string]string {
+	encode := base64.RawURLEncoding.EncodeToString
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "n": encode(k.N.Bytes()), "e": encode(big.NewInt(int64(k.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		return map[string]string{"kty": "EC", "kid": kid, "crv": k.Curve.Params().Name, "x": encode(k.X.FillBytes(make([]byte, size))), "y": encode(k.Y.FillBytes(make([]byte, size)))}
+	case ed25519.PublicKey:
+		return map[string]string{"kty": "OKP", "kid": kid, "crv": "Ed25519", "x": encode(k)}
+	}
+	return nil
+}
+
+// jwksServer는 키 목록을 교체할 수 있는 테스트용 JWKS 서버입니다.
+type jwksServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	keys    []map[string]string
+	fetches int32
+}
+
+func newJWKSServer(t *testing.T, keys ...map[string]string) *jwksServer {
+	s := &jwksServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&s.fetches, 1)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": s.keys})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) setKeys(keys ...map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("HMAC", func(t *testing.T) {
+		authenticator := auth.New("test-secret", testIssuer, time.Hour)
+		token, err := authenticator.GenerateToken("user-1", []string{"admin"})
+		require.NoError(t, err)
+
+		claims, err := authenticator.VerifyToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, []string{"admin"}, claims.Roles)
+
+		_, err = auth.New("other-secret", testIssuer, time.Hour).VerifyToken(token)
+		assert.Error(t, err)
+
+		_, err = authenticator.VerifyToken(signToken(t, jwt.SigningMethodRS256, rsaKey, "", testIssuer))
+		assert.Error(t, err, "공개 키가 없으면 비대칭 서명 토큰을 받지 않아야 함")
+	})
+
+	t.Run("PublicKeyFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		authenticator, err := auth.NewWithConfig(auth.Config{
+			Issuer: testIssuer,
+			PublicKeyFiles: []string{
+				writePublicKeyPEM(t, dir, "rsa-1.pem", &rsaKey.PublicKey),
+				writePublicKeyPEM(t, dir, "ec-1.pem", &ecKey.PublicKey),
+				writePublicKeyPEM(t, dir, "ed-1.pem", edPublic),
+			},
+		})
+		require.NoError(t, err)
+
+		for name, token := range map[string]string{
+			"RS256":        signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", testIssuer),
+			"RS256 no kid": signToken(t, jwt.SigningMethodRS256, rsaKey, "", testIssuer),
+			"PS256":        signToken(t, jwt.SigningMethodPS256, rsaKey, "rsa-1", testIssuer),
+			"ES256":        signToken(t, jwt.SigningMethodES256, ecKey, "ec-1", testIssuer),
+			"EdDSA":        signToken(t, jwt.SigningMethodEdDSA, edPrivate, "ed-1", testIssuer),
+		} {
+			claims, err := authenticator.VerifyToken(token)
+			if assert.NoError(t, err, name) {
+				assert.Equal(t, "user-1", claims.Subject, name)
+			}
+		}
+
+		_, err = authenticator.VerifyToken(signToken(t, jwt.SigningMethodRS256, rsaKey, "ec-1", testIssuer))
+		assert.Error(t, err, "kid의 키 종류와 서명 방식이 다르면 거부해야 함")
+
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		_, err = authenticator.VerifyToken(signToken(t, jwt.SigningMethodRS256, otherKey, "rsa-1", testIssuer))
+		assert.Error(t, err, "다른 키로 서명한 토큰은 거부해야 함")
+
+		_, err = authenticator.VerifyToken(signToken(t, jwt.SigningMethodHS256, []byte("anything"), "", testIssuer))
+		assert.Error(t, err, "비밀 키가 없으면 HMAC 토큰을 받지 않아야 함")
+
+		_, err = auth.NewWithConfig(auth.Config{PublicKeyFiles: []string{filepath.Join(dir, "missing.pem")}})
+		assert.Error(t, err)
+	})
+
+	t.Run("AlgorithmConfusion", func(t *testing.T) {
+		dir := t.TempDir()
+		path := writePublicKeyPEM(t, dir, "rsa-1.pem", &rsaKey.PublicKey)
+		pemBytes, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		authenticator, err := auth.NewWithConfig(auth.Config{SecretKey: "test-secret", Issuer: testIssuer, PublicKeyFiles: []string{path}})
+		require.NoError(t, err)
+
+		_, err = authenticator.VerifyToken(signToken(t, jwt.SigningMethodHS256, pemBytes, "rsa-1", testIssuer))
+		assert.Error(t, err, "공개 키를 HMAC 비밀 키로 사용한 토큰은 거부해야 함")
+
+		_, err = authenticator.VerifyToken(signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", testIssuer))
+		assert.Error(t, err, "서명 없는 토큰은 거부해야 함")
+	})
+
+	t.Run("AcceptedIssuers", func(t *testing.T) {
+		dir := t.TempDir()
+		files := []string{writePublicKeyPEM(t, dir, "rsa-1.pem", &rsaKey.PublicKey)}
+		token := signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", externalIssuer)
+
+		strict, err := auth.NewWithConfig(auth.Config{Issuer: testIssuer, PublicKeyFiles: files})
+		require.NoError(t, err)
+		_, err = strict.VerifyToken(token)
+		assert.Error(t, err)
+
+		accepting, err := auth.NewWithConfig(auth.Config{Issuer: testIssuer, AcceptedIssuers: []string{externalIssuer}, PublicKeyFiles: files})
+		require.NoError(t, err)
+		_, err = accepting.VerifyToken(token)
+		assert.NoError(t, err)
+	})
+
+	t.Run("JWKS", func(t *testing.T) {
+		server := newJWKSServer(t,
+			jwkFor("rsa-1", &rsaKey.PublicKey),
+			jwkFor("ec-1", &ecKey.PublicKey),
+			jwkFor("ed-1", edPublic),
+			map[string]string{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		)
+		authenticator, err := auth.NewWithConfig(auth.Config{Issuer: testIssuer, JWKSURL: server.URL})
+		require.NoError(t, err)
+
+		for name, token := range map[string]string{
+			"RS256": signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", testIssuer),
+			"ES256": signToken(t, jwt.SigningMethodES256, ecKey, "ec-1", testIssuer),
+			"EdDSA": signToken(t, jwt.SigningMethodEdDSA, edPrivate, "ed-1", testIssuer),
+		} {
+			_, err := authenticator.VerifyToken(token)
+			assert.NoError(t, err, name)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&server.fetches), "키 목록은 캐시해야 함")
+	})
+
+	t.Run("JWKSKeyRotation", func(t *testing.T) {
+		newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		server := newJWKSServer(t, jwkFor("key-1", &ecKey.PublicKey))
+		authenticator, err := auth.NewWithConfig(auth.Config{
+			Issuer:                 testIssuer,
+			JWKSURL:                server.URL,
+			JWKSMinRefreshInterval: 50 * time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		_, err = authenticator.VerifyToken(signToken(t, jwt.SigningMethodES256, ecKey, "key-1", testIssuer))
+		require.NoError(t, err)
+
+		// 발급자가 새 키를 게시하기 전: 최소 간격 안에서는 다시 가져오지 않음
+		rotated := signToken(t, jwt.SigningMethodES256, newKey, "key-2", testIssuer)
+		for i := 0; i < 5; i++ {
+			_, err = authenticator.VerifyToken(rotated)
+			assert.Error(t, err)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&server.fetches), "모르는 kid로 키 목록을 반복해서 가져오지 않아야 함")
+
+		server.setKeys(jwkFor("key-2", &newKey.PublicKey))
+		time.Sleep(60 * time.Millisecond)
+
+		_, err = authenticator.VerifyToken(rotated)
+		assert.NoError(t, err, "재시작 없이 교체된 키로 검증해야 함")
+		assert.Equal(t, int32(2), atomic.LoadInt32(&server.fetches))
+	})
+
+	t.Run("JWKSUnavailableKeepsKeys", func(t *testing.T) {
+		server := newJWKSServer(t, jwkFor("rsa-1", &rsaKey.PublicKey))
+		jwks := auth.NewJWKS(auth.JWKSConfig{URL: server.URL, RefreshInterval: 10 * time.Millisecond, MinRefreshInterval: 10 * time.Millisecond})
+		authenticator, err := auth.NewWithConfig(auth.Config{Issuer: testIssuer})
+		require.NoError(t, err)
+		authenticator.AddKeySource(jwks)
+
+		token := signToken(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", testIssuer)
+		_, err = authenticator.VerifyToken(token)
+		require.NoError(t, err)
+
+		server.Close()
+		time.Sleep(20 * time.Millisecond)
+		_, err = authenticator.VerifyToken(token)
+		assert.NoError(t, err, "JWKS를 가져올 수 없으면 이전 키 목록을 사용해야 함")
+	})
+}
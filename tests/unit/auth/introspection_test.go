@@ -0,0 +1,151 @@
+// +build unit
+
+package auth_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+)
+
+// newIntrospectionServer는 토큰별 응답을 반환하는 테스트용 introspection 엔드포인트를 시작합니다.
+// 등록되지 않은 토큰은 active=false로, "fail"은 500으로 응답합니다.
+func newIntrospectionServer(t *testing.T, calls *int32, responses map[string]map[string]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+
+		clientID, secret, ok := r.BasicAuth()
+		if !ok || clientID != "gateway" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodPost || r.PostFormValue("token_type_hint") != "access_token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		token := r.PostFormValue("token")
+		if token == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		response, ok := responses[token]
+		if !ok {
+			response = map[string]interface{}{"active": false}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestIntrospectionAuthenticator(t *testing.T) {
+	var calls int32
+	exp := time.Now().Add(time.Hour).Unix()
+	shortExp := time.Now().Unix() + 1
+	server := newIntrospectionServer(t, &calls, map[string]map[string]interface{}{
+		"opaque-1": {
+			"active": true, "sub": "user-1", "scope": "receipts:read receipts:write",
+			"exp": exp, "client_id": "mobile", "roles": []string{"user"},
+		},
+		"short-lived": {"active": true, "sub": "user-2", "exp": shortExp},
+		"expired":     {"active": true, "sub": "user-3", "exp": time.Now().Add(-time.Minute).Unix()},
+	})
+
+	newAuthenticator := func(breaker *circuitbreaker.CircuitBreaker) *auth.IntrospectionAuthenticator {
+		return auth.NewIntrospectionAuthenticator(auth.IntrospectionConfig{
+			URL:              server.URL,
+			ClientID:         "gateway",
+			ClientSecret:     "s3cret",
+			CacheTTL:         time.Minute,
+			NegativeCacheTTL: time.Minute,
+			Breaker:          breaker,
+		})
+	}
+
+	t.Run("MapsResponseToClaims", func(t *testing.T) {
+		claims, err := newAuthenticator(nil).VerifyToken("opaque-1")
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+		assert.Equal(t, []string{"user"}, claims.Roles)
+		assert.Equal(t, []string{"receipts:read", "receipts:write"}, claims.Scopes())
+		assert.Equal(t, exp, claims.ExpiresAt.Unix())
+
+		clientID, found := claims.Lookup("client_id")
+		assert.True(t, found)
+		assert.Equal(t, "mobile", clientID)
+		_, found = claims.Lookup("active")
+		assert.False(t, found)
+	})
+
+	t.Run("CachesPositiveAndNegativeResults", func(t *testing.T) {
+		authenticator := newAuthenticator(nil)
+		atomic.StoreInt32(&calls, 0)
+
+		for i := 0; i < 3; i++ {
+			_, err := authenticator.VerifyToken("opaque-1")
+			require.NoError(t, err)
+			_, err = authenticator.VerifyToken("unknown")
+			assert.True(t, errors.Is(err, auth.ErrInactiveToken))
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "결과를 캐시해야 함")
+	})
+
+	t.Run("PositiveCacheBoundedByExp", func(t *testing.T) {
+		authenticator := newAuthenticator(nil)
+		_, err := authenticator.VerifyToken("short-lived")
+		require.NoError(t, err)
+
+		time.Sleep(time.Until(time.Unix(shortExp, 0)) + 50*time.Millisecond)
+		atomic.StoreInt32(&calls, 0)
+		_, err = authenticator.VerifyToken("short-lived")
+		assert.Error(t, err, "토큰 만료 후에는 캐시된 결과를 사용하지 않아야 함")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("RejectsExpiredToken", func(t *testing.T) {
+		_, err := newAuthenticator(nil).VerifyToken("expired")
+		assert.Error(t, err)
+	})
+
+	t.Run("CircuitBreakerProtectsEndpoint", func(t *testing.T) {
+		authenticator := newAuthenticator(circuitbreaker.New(circuitbreaker.Config{MinRequests: 2, ErrorThreshold: 0.5, TimeoutDuration: time.Minute}))
+		atomic.StoreInt32(&calls, 0)
+
+		for i := 0; i < 2; i++ {
+			_, err := authenticator.VerifyToken("fail")
+			assert.True(t, errors.Is(err, auth.ErrIntrospectionUnavailable))
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "실패 결과는 캐시하지 않아야 함")
+
+		_, err := authenticator.VerifyToken("opaque-2")
+		assert.True(t, errors.Is(err, auth.ErrIntrospectionUnavailable))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "서킷이 열리면 엔드포인트를 호출하지 않아야 함")
+	})
+
+	t.Run("RoutesOpaqueTokensToIntrospection", func(t *testing.T) {
+		jwtAuthenticator := auth.New("secret", "issuer", time.Hour)
+		authenticator := auth.NewOpaqueTokenAuthenticator(jwtAuthenticator, newAuthenticator(nil))
+
+		token, err := authenticator.GenerateToken("user-9", []string{"user"})
+		require.NoError(t, err)
+		claims, err := authenticator.VerifyToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, "user-9", claims.Subject)
+
+		claims, err = authenticator.VerifyToken("opaque-1")
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.Subject)
+	})
+}
@@ -0,0 +1,139 @@
+// +build unit
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/tests/mocks"
+)
+
+func TestPKCEChallenge(t *testing.T) {
+	// RFC 7636 Appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", auth.PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestOIDCProvider(t *testing.T) {
+	idp := mocks.NewOIDCServer("web", "test-issuer", "test-secret")
+	t.Cleanup(idp.Close)
+
+	provider := auth.NewOIDCProvider(auth.OIDCConfig{
+		Issuer:      idp.URL(),
+		ClientID:    "web",
+		RedirectURL: "https://gateway.example.com/auth/callback",
+	})
+	ctx := context.Background()
+
+	t.Run("인가 코드 교환", func(t *testing.T) {
+		verifier, err := auth.RandomToken()
+		require.NoError(t, err)
+
+		authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", verifier)
+		require.NoError(t, err)
+		parsed, err := url.Parse(authURL)
+		require.NoError(t, err)
+		assert.Equal(t, auth.PKCEChallenge(verifier), parsed.Query().Get("code_challenge"))
+		assert.Equal(t, "openid profile email offline_access", parsed.Query().Get("scope"), "기본 스코프를 요청해야 함")
+
+		code := authorize(t, authURL)
+		_, err = provider.Exchange(ctx, code, "wrong-verifier")
+		assert.Error(t, err, "코드 검증자가 다르면 교환에 실패해야 함")
+
+		tokens, err := provider.Exchange(ctx, authorize(t, authURL), verifier)
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		assert.NotEmpty(t, tokens.IDToken)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), tokens.ExpiresAt, 5*time.Second)
+
+		refreshed, err := provider.Refresh(ctx, tokens.RefreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, tokens.AccessToken, refreshed.AccessToken)
+		assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken, "IdP가 교체한 갱신 토큰을 사용해야 함")
+
+		_, err = provider.Refresh(ctx, tokens.RefreshToken)
+		assert.Error(t, err, "교체된 갱신 토큰은 사용할 수 없어야 함")
+
+		require.NoError(t, provider.Revoke(ctx, refreshed.RefreshToken, "refresh_token"))
+		_, err = provider.Refresh(ctx, refreshed.RefreshToken)
+		assert.Error(t, err, "폐기한 갱신 토큰은 사용할 수 없어야 함")
+	})
+
+	t.Run("IdP 로그아웃 URL", func(t *testing.T) {
+		endSession, ok := provider.EndSessionURL(ctx, "https://app.example.com/")
+		require.True(t, ok)
+		assert.Contains(t, endSession, idp.URL()+"/logout?")
+		assert.Contains(t, endSession, "post_logout_redirect_uri="+url.QueryEscape("https://app.example.com/"))
+	})
+
+	t.Run("발급자 불일치", func(t *testing.T) {
+		other := auth.NewOIDCProvider(auth.OIDCConfig{
+			Issuer:      strings.Replace(idp.URL(), "127.0.0.1", "localhost", 1),
+			ClientID:    "web",
+			RedirectURL: "https://gateway.example.com/auth/callback",
+		})
+		_, err := other.AuthCodeURL(ctx, "state", "nonce", "verifier")
+		assert.Error(t, err, "discovery 문서의 issuer가 다르면 거부해야 함")
+	})
+}
+
+// authorize는 인가 URL로 이동하여 IdP가 콜백으로 돌려준 인가 코드를 반환합니다.
+func authorize(t *testing.T, authURL string) string {
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return callback.Query().Get("code")
+}
+
+func TestSessionCodec(t *testing.T) {
+	_, err := auth.NewSessionCodec("too-short")
+	assert.Error(t, err, "짧은 비밀은 거부해야 함")
+
+	codec, err := auth.NewSessionCodec("0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+
+	session := auth.Session{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ExpiresAt:    time.Now().Add(time.Hour).Truncate(time.Second),
+		EndsAt:       time.Now().Add(8 * time.Hour).Truncate(time.Second),
+	}
+	encoded, err := codec.Encode("gw_session", session)
+	require.NoError(t, err)
+	assert.NotContains(t, encoded, "access", "쿠키 값은 암호화되어야 함")
+
+	var decoded auth.Session
+	require.NoError(t, codec.Decode("gw_session", encoded, &decoded))
+	assert.Equal(t, session.AccessToken, decoded.AccessToken)
+	assert.Equal(t, session.RefreshToken, decoded.RefreshToken)
+	assert.True(t, session.ExpiresAt.Equal(decoded.ExpiresAt))
+	assert.True(t, session.EndsAt.Equal(decoded.EndsAt))
+
+	assert.Error(t, codec.Decode("gw_oidc_state", encoded, &decoded), "다른 용도의 쿠키 값은 거부해야 함")
+
+	tampered := []byte(encoded)
+	if tampered[len(tampered)/2] == 'A' {
+		tampered[len(tampered)/2] = 'B'
+	} else {
+		tampered[len(tampered)/2] = 'A'
+	}
+	assert.Error(t, codec.Decode("gw_session", string(tampered), &decoded), "수정된 쿠키 값은 거부해야 함")
+
+	other, err := auth.NewSessionCodec("fedcba9876543210fedcba9876543210")
+	require.NoError(t, err)
+	assert.Error(t, other.Decode("gw_session", encoded, &decoded), "다른 비밀로 암호화한 값은 거부해야 함")
+}
@@ -0,0 +1,143 @@
+// +build unit
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/tests/mocks"
+)
+
+// revocationClaims는 폐기 확인에 사용할 클레임을 만듭니다.
+func revocationClaims(id, subject string, issuedAt time.Time) *auth.Claims {
+	return &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{
+		ID:       id,
+		Subject:  subject,
+		IssuedAt: jwt.NewNumericDate(issuedAt),
+	}}
+}
+
+func TestDenylist(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := mocks.NewRedisServer("")
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	stores := map[string]func() auth.RevocationStore{
+		"Memory": func() auth.RevocationStore { return auth.NewMemoryRevocationStore() },
+		"Redis": func() auth.RevocationStore {
+			return auth.NewRedisRevocationStore(auth.RedisRevocationConfig{Addr: server.Addr(), KeyPrefix: t.Name() + ":"})
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			denylist := auth.NewDenylist(newStore())
+			defer denylist.Close()
+
+			issuedAt := time.Now().Add(-time.Minute)
+
+			t.Run("RevokeToken", func(t *testing.T) {
+				claims := revocationClaims("token-1", "user-1", issuedAt)
+				revoked, err := denylist.IsRevoked(ctx, claims, "raw")
+				require.NoError(t, err)
+				assert.False(t, revoked)
+
+				require.NoError(t, denylist.RevokeToken(ctx, "token-1", time.Now().Add(time.Hour)))
+				revoked, err = denylist.IsRevoked(ctx, claims, "raw")
+				require.NoError(t, err)
+				assert.True(t, revoked)
+
+				revoked, err = denylist.IsRevoked(ctx, revocationClaims("token-2", "user-1", issuedAt), "raw")
+				require.NoError(t, err)
+				assert.False(t, revoked, "다른 토큰에는 영향이 없어야 함")
+			})
+
+			t.Run("TokenWithoutID", func(t *testing.T) {
+				claims := revocationClaims("", "user-2", issuedAt)
+				require.NoError(t, denylist.RevokeToken(ctx, auth.TokenID(claims, "raw-a"), time.Now().Add(time.Hour)))
+
+				revoked, err := denylist.IsRevoked(ctx, claims, "raw-a")
+				require.NoError(t, err)
+				assert.True(t, revoked, "jti가 없는 토큰은 토큰 해시로 폐기해야 함")
+
+				revoked, err = denylist.IsRevoked(ctx, claims, "raw-b")
+				require.NoError(t, err)
+				assert.False(t, revoked)
+			})
+
+			t.Run("RevokeSubject", func(t *testing.T) {
+				revokedAt, err := denylist.RevokeSubject(ctx, "user-3", time.Now().Add(time.Hour))
+				require.NoError(t, err)
+
+				revoked, err := denylist.IsRevoked(ctx, revocationClaims("old", "user-3", issuedAt), "raw")
+				require.NoError(t, err)
+				assert.True(t, revoked, "폐기 전에 발급된 토큰은 거부해야 함")
+
+				revoked, err = denylist.IsRevoked(ctx, revocationClaims("new", "user-3", revokedAt.Add(time.Second)), "raw")
+				require.NoError(t, err)
+				assert.False(t, revoked, "폐기 후에 발급된 토큰은 허용해야 함")
+
+				revoked, err = denylist.IsRevoked(ctx, revocationClaims("same-second", "user-3", revokedAt.Add(time.Millisecond)), "raw")
+				require.NoError(t, err)
+				assert.False(t, revoked, "폐기와 같은 초에 발급된 토큰은 허용해야 함")
+
+				revoked, err = denylist.IsRevoked(ctx, &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-3"}}, "raw")
+				require.NoError(t, err)
+				assert.True(t, revoked, "iat가 없는 토큰은 거부해야 함")
+			})
+
+			t.Run("RevokeKeepsLatest", func(t *testing.T) {
+				store := newStore()
+				defer store.Close()
+
+				later := time.Now()
+				require.NoError(t, store.Revoke(ctx, "sub:user-4", later, time.Now().Add(time.Hour)))
+				require.NoError(t, store.Revoke(ctx, "sub:user-4", later.Add(-time.Minute), time.Now().Add(50*time.Millisecond)))
+
+				revokedAt, err := store.RevokedAt(ctx, "sub:user-4")
+				require.NoError(t, err)
+				assert.True(t, revokedAt.Equal(later), "같은 키를 다시 폐기하면 더 늦은 폐기 시각을 유지해야 함")
+
+				time.Sleep(100 * time.Millisecond)
+				revokedAt, err = store.RevokedAt(ctx, "sub:user-4")
+				require.NoError(t, err)
+				assert.False(t, revokedAt.IsZero(), "같은 키를 다시 폐기하면 더 늦은 만료 시각을 유지해야 함")
+			})
+
+			t.Run("EntriesExpire", func(t *testing.T) {
+				require.NoError(t, denylist.RevokeToken(ctx, "short", time.Now().Add(50*time.Millisecond)))
+				require.NoError(t, denylist.RevokeToken(ctx, "past", time.Now().Add(-time.Second)))
+
+				revoked, err := denylist.IsRevoked(ctx, revocationClaims("short", "", issuedAt), "raw")
+				require.NoError(t, err)
+				assert.True(t, revoked)
+
+				revoked, err = denylist.IsRevoked(ctx, revocationClaims("past", "", issuedAt), "raw")
+				require.NoError(t, err)
+				assert.False(t, revoked, "이미 만료된 토큰은 폐기 목록에 추가하지 않아야 함")
+
+				time.Sleep(100 * time.Millisecond)
+				revoked, err = denylist.IsRevoked(ctx, revocationClaims("short", "", issuedAt), "raw")
+				require.NoError(t, err)
+				assert.False(t, revoked, "토큰 만료 시각이 지나면 폐기 항목을 삭제해야 함")
+			})
+		})
+	}
+
+	t.Run("RedisUnavailable", func(t *testing.T) {
+		denylist := auth.NewDenylist(auth.NewRedisRevocationStore(auth.RedisRevocationConfig{Addr: "127.0.0.1:1", Timeout: 50 * time.Millisecond}))
+		defer denylist.Close()
+
+		_, err := denylist.IsRevoked(ctx, revocationClaims("token-1", "user-1", time.Now()), "raw")
+		assert.Error(t, err)
+	})
+}
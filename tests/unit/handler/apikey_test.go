@@ -0,0 +1,126 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/handler"
+	"github.com/isinthesky/api-gateway/pkg/cache"
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+	"github.com/isinthesky/api-gateway/pkg/loadbalancer"
+)
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var mu sync.Mutex
+	var received *http.Request
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = r.Clone(r.Context())
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(backend.Close)
+	lastRequest := func() *http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/keys/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true,"authMode":"apiKey"},
+		{"path":"/either/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true,"authMode":"any",
+		 "authorization":{"roles":["reports","user"]}},
+		{"path":"/jwt/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true}
+	]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+	store, err := auth.NewMemoryAPIKeyStore([]auth.APIKey{
+		{ID: "reports", Hash: auth.HashAPIKey("reports-key"), Consumer: "report-generator", Roles: []string{"reports"}},
+		{ID: "cron", Hash: auth.HashAPIKey("cron-key"), Consumer: "cron", Roles: []string{"reports"},
+			RateLimit: &auth.APIKeyRateLimit{Limit: 2, Window: 60}},
+	})
+	require.NoError(t, err)
+
+	reloader := newTestReloaderWith(t, routesPath, func(h *handler.RouteHandler) {
+		h.SetAPIKeyAuthenticator(auth.NewAPIKeyAuthenticator(store))
+	}, func(cfg *config.Config) {
+		cfg.ForwardIdentity = true
+		cfg.IdentityHeaders = []config.IdentityHeader{{Header: "X-User-Id", Claim: "sub"}}
+		cfg.APIKeyQueryParam = "api_key"
+	})
+	userToken := signClaims(t, jwt.MapClaims{"roles": []string{"user"}})
+
+	t.Run("HeaderKey", func(t *testing.T) {
+		w := sendWith(reloader, http.MethodGet, "/keys/x", map[string]string{"X-API-Key": "reports-key"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		req := lastRequest()
+		assert.Empty(t, req.Header.Get("X-API-Key"), "업스트림에 키 원문을 전달하지 않아야 함")
+		assert.Equal(t, "report-generator", req.Header.Get("X-User-Id"), "소비자 ID를 사용자 ID로 전달해야 함")
+	})
+
+	t.Run("QueryParamKey", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, get(reloader, "/keys/x?page=2&api_key=reports-key").Code)
+		assert.Equal(t, "page=2", lastRequest().URL.RawQuery, "키 쿼리 파라미터만 제거해야 함")
+	})
+
+	t.Run("ModeRestrictsCredentialType", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/keys/x", userToken).Code, "apiKey 라우트는 JWT를 받지 않아야 함")
+		assert.Equal(t, http.StatusUnauthorized, sendWith(reloader, http.MethodGet, "/keys/x", map[string]string{"X-API-Key": "unknown"}).Code)
+		assert.Equal(t, http.StatusUnauthorized, sendWith(reloader, http.MethodGet, "/jwt/x", map[string]string{"X-API-Key": "reports-key"}).Code, "jwt 라우트는 API 키를 받지 않아야 함")
+	})
+
+	t.Run("AnyModeAcceptsEither", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/either/x", userToken).Code)
+		assert.Equal(t, http.StatusOK, sendWith(reloader, http.MethodGet, "/either/x", map[string]string{"X-API-Key": "reports-key"}).Code, "API 키 역할로 권한을 확인해야 함")
+		assert.Equal(t, http.StatusUnauthorized, get(reloader, "/either/x").Code)
+	})
+
+	t.Run("PerKeyRateLimit", func(t *testing.T) {
+		cron := map[string]string{"X-API-Key": "cron-key"}
+		for i := 0; i < 2; i++ {
+			w := sendWith(reloader, http.MethodGet, "/keys/x", cron)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+		}
+
+		w := sendWith(reloader, http.MethodGet, "/either/x", cron)
+		assert.Equal(t, http.StatusTooManyRequests, w.Code, "키별 한도는 라우트 간에 공유해야 함")
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		assert.Equal(t, http.StatusOK, sendWith(reloader, http.MethodGet, "/keys/x", map[string]string{"X-API-Key": "reports-key"}).Code, "다른 키에는 영향이 없어야 함")
+	})
+
+	t.Run("RequiresAuthenticator", func(t *testing.T) {
+		cacheProvider := cache.New(time.Minute)
+		defer cacheProvider.Close()
+
+		routeHandler := handler.NewRouteHandler(
+			loadbalancer.NewSingle("http://localhost:1"),
+			circuitbreaker.NewRegistry(circuitbreaker.Config{}),
+			cacheProvider,
+			&config.Config{AllowedOrigins: []string{"*"}, RoutesConfigPath: routesPath},
+		)
+		defer routeHandler.Close()
+
+		assert.Error(t, routeHandler.RegisterRoutes(gin.New()), "API 키 인증기 없이 apiKey 라우트를 등록하면 실패해야 함")
+	})
+}
@@ -0,0 +1,136 @@
+// +build unit
+
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signClaims는 테스트 비밀 키로 지정한 클레임을 가진 토큰을 서명합니다.
+func signClaims(t *testing.T, claims jwt.MapClaims) string {
+	now := time.Now()
+	base := jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "test-issuer",
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		base[name] = value
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, base).SignedString([]byte("test-secret"))
+	require.NoError(t, err)
+	return token
+}
+
+func TestRouteAuthorization(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	backend := newBackend(t, "ok")
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/any/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true,
+		 "authorization":{"roles":["admin","operator"]}},
+		{"path":"/all/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true,
+		 "authorization":{"roles":["admin","auditor"],"rolesMode":"all"}},
+		{"path":"/scoped/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true,
+		 "authorization":{"scopes":["receipts:read","receipts:write"],"scopesMode":"all","audience":["receipt-service"]}},
+		{"path":"/claims/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true,
+		 "authorization":{"claims":[
+			{"claim":"provider","value":"google"},
+			{"claim":"org.tier","op":"in","values":["gold","platinum"]},
+			{"claim":"email_verified","value":"true"},
+			{"claim":"suspended","op":"ne","value":"true"}
+		 ]}}
+	]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+	reloader := newTestReloader(t, routesPath)
+
+	forbidden := func(t *testing.T, path, token string) map[string]interface{} {
+		w := getWithToken(reloader, path, token)
+		require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("AuthenticationFailureIs401", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/any/x", "").Code)
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/any/x", "invalid").Code)
+	})
+
+	t.Run("RolesAnyOf", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/any/x", signClaims(t, jwt.MapClaims{"roles": []string{"operator"}})).Code)
+
+		body := forbidden(t, "/any/x", signClaims(t, jwt.MapClaims{"roles": []string{"user"}}))
+		assert.Equal(t, "insufficient_role", body["reason"])
+		assert.Equal(t, []interface{}{"admin", "operator"}, body["required"])
+		assert.Equal(t, "any", body["mode"])
+	})
+
+	t.Run("RolesAllOf", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/all/x", signClaims(t, jwt.MapClaims{"roles": []string{"auditor", "admin"}})).Code)
+
+		body := forbidden(t, "/all/x", signClaims(t, jwt.MapClaims{"roles": []string{"admin"}}))
+		assert.Equal(t, "insufficient_role", body["reason"])
+		assert.Equal(t, "all", body["mode"])
+	})
+
+	t.Run("ScopesAndAudience", func(t *testing.T) {
+		valid := jwt.MapClaims{"aud": "receipt-service", "scope": "receipts:read receipts:write profile"}
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/scoped/x", signClaims(t, valid)).Code)
+
+		scp := jwt.MapClaims{"aud": []string{"other", "receipt-service"}, "scp": []string{"receipts:read", "receipts:write"}}
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/scoped/x", signClaims(t, scp)).Code, "scp 배열과 aud 배열도 지원해야 함")
+
+		body := forbidden(t, "/scoped/x", signClaims(t, jwt.MapClaims{"aud": "other", "scope": "receipts:read receipts:write"}))
+		assert.Equal(t, "invalid_audience", body["reason"])
+
+		w := getWithToken(reloader, "/scoped/x", signClaims(t, jwt.MapClaims{"aud": "receipt-service", "scope": "receipts:read"}))
+		require.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), `"reason":"insufficient_scope"`)
+		assert.Equal(t, `Bearer error="insufficient_scope", scope="receipts:read receipts:write"`, w.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("ClaimMatchers", func(t *testing.T) {
+		valid := jwt.MapClaims{
+			"provider":       "google",
+			"org":            map[string]interface{}{"tier": "gold"},
+			"email_verified": true,
+		}
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/claims/x", signClaims(t, valid)).Code)
+
+		for claim, override := range map[string]jwt.MapClaims{
+			"provider":       {"provider": "github"},
+			"org.tier":       {"org": map[string]interface{}{"tier": "silver"}},
+			"email_verified": {"email_verified": false},
+			"suspended":      {"suspended": true},
+		} {
+			claims := jwt.MapClaims{}
+			for name, value := range valid {
+				claims[name] = value
+			}
+			for name, value := range override {
+				claims[name] = value
+			}
+
+			body := forbidden(t, "/claims/x", signClaims(t, claims))
+			assert.Equal(t, "claim_mismatch", body["reason"], claim)
+			assert.Equal(t, claim, body["claim"])
+		}
+
+		missing := jwt.MapClaims{"org": map[string]interface{}{"tier": "gold"}, "email_verified": true}
+		assert.Equal(t, "provider", forbidden(t, "/claims/x", signClaims(t, missing))["claim"], "클레임이 없으면 거부해야 함")
+	})
+}
@@ -0,0 +1,664 @@
+// +build unit
+
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/handler"
+)
+
+// newCachingReloader는 캐시가 적용된 라우트를 가진 테스트용 리로더를 생성합니다.
+func newCachingReloader(t *testing.T, targetURL string, configure ...func(*config.Config)) *handler.RouteReloader {
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/c/*path","targetURL":"%s","methods":["GET","POST"],"stripPrefix":"/c","cacheable":true,"timeout":5}
+	]}`, targetURL)
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+	return newTestReloader(t, routesPath, append([]func(*config.Config){func(cfg *config.Config) {
+		cfg.EnableCaching = true
+	}}, configure...)...)
+}
+
+// newCacheBackend는 요청마다 handle을 호출하고 호출 횟수를 기록하는 테스트 백엔드를 생성합니다.
+func newCacheBackend(t *testing.T, calls *int32, handle func(w http.ResponseWriter, r *http.Request, call int32)) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, atomic.AddInt32(calls, 1))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// sendWith는 지정한 메서드와 헤더로 요청을 보냅니다.
+func sendWith(handler http.Handler, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestResponseCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("FreshHitWithAge", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Age", "10")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		w := get(reloader, "/c/a")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+
+		w = get(reloader, "/c/a")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+		assert.Equal(t, "v1", w.Body.String())
+		assert.Equal(t, "10", w.Header().Get("Age"), "업스트림 Age에 캐시 보관 시간을 더해야 함")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("VarySecondaryKeys", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept-Encoding")
+			fmt.Fprintf(w, "encoding=%s", r.Header.Get("Accept-Encoding"))
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		gzip := map[string]string{"Accept-Encoding": "gzip"}
+		identity := map[string]string{"Accept-Encoding": "identity"}
+
+		assert.Equal(t, "encoding=gzip", sendWith(reloader, http.MethodGet, "/c/a", gzip).Body.String())
+		w := sendWith(reloader, http.MethodGet, "/c/a", identity)
+		assert.Equal(t, "encoding=identity", w.Body.String(), "다른 Accept-Encoding에 캐시된 응답을 사용하면 안 됨")
+		assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+
+		w = sendWith(reloader, http.MethodGet, "/c/a", gzip)
+		assert.Equal(t, "encoding=gzip", w.Body.String())
+		assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+		w = sendWith(reloader, http.MethodGet, "/c/a", identity)
+		assert.Equal(t, "encoding=identity", w.Body.String())
+		assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("AuthorizedResponsesNotShared", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			fmt.Fprintf(w, "user=%s", r.Header.Get("Authorization"))
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		assert.Equal(t, "user=Bearer a", sendWith(reloader, http.MethodGet, "/c/me", map[string]string{"Authorization": "Bearer a"}).Body.String())
+		assert.Equal(t, "user=Bearer b", sendWith(reloader, http.MethodGet, "/c/me", map[string]string{"Authorization": "Bearer b"}).Body.String())
+		assert.Equal(t, "user=", get(reloader, "/c/me").Body.String(), "인증된 응답이 다른 클라이언트에 전달되면 안 됨")
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("APIKeyResponsesNotShared", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			if r.URL.Path == "/public" {
+				w.Header().Set("Cache-Control", "public, max-age=60")
+			} else {
+				w.Header().Set("Cache-Control", "max-age=60")
+			}
+			fmt.Fprintf(w, "user=%s", r.Header.Get("X-User-Id"))
+		})
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/c/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/c","cacheable":true,"timeout":5,
+				"requireAuth":true,"authMode":"apiKey"}
+		]}`, backend.URL)
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+		store, err := auth.NewMemoryAPIKeyStore([]auth.APIKey{
+			{ID: "reports", Hash: auth.HashAPIKey("reports-key"), Consumer: "report-generator"},
+			{ID: "cron", Hash: auth.HashAPIKey("cron-key"), Consumer: "cron"},
+		})
+		require.NoError(t, err)
+		reloader := newTestReloaderWith(t, routesPath, func(h *handler.RouteHandler) {
+			h.SetAPIKeyAuthenticator(auth.NewAPIKeyAuthenticator(store))
+		}, func(cfg *config.Config) {
+			cfg.EnableCaching = true
+			cfg.ForwardIdentity = true
+			cfg.IdentityHeaders = []config.IdentityHeader{{Header: "X-User-Id", Claim: "sub"}}
+			cfg.APIKeyQueryParam = "api_key"
+		})
+
+		// 키 헤더와 쿼리 파라미터는 캐시 키를 만들기 전에 제거되므로 두 키의 요청은 같은 캐시 키를 가짐
+		assert.Equal(t, "user=report-generator", sendWith(reloader, http.MethodGet, "/c/me", map[string]string{"X-API-Key": "reports-key"}).Body.String())
+		assert.Equal(t, "user=cron", get(reloader, "/c/me?api_key=cron-key").Body.String(), "다른 API 키의 응답이 전달되면 안 됨")
+		assert.Equal(t, "user=report-generator", sendWith(reloader, http.MethodGet, "/c/me", map[string]string{"X-API-Key": "reports-key"}).Body.String())
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+		// 공유 캐시 사용을 명시한 응답은 다른 키와 공유
+		assert.Equal(t, "MISS", sendWith(reloader, http.MethodGet, "/c/public", map[string]string{"X-API-Key": "reports-key"}).Header().Get("X-Cache"))
+		w := get(reloader, "/c/public?api_key=cron-key")
+		assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+		assert.Equal(t, "user=report-generator", w.Body.String())
+		assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("StreamsWhileCapturing", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		chunk := strings.Repeat("a", 64*1024)
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			io.WriteString(w, chunk)
+			w.(http.Flusher).Flush()
+			if call == 1 {
+				<-release
+			}
+			io.WriteString(w, "end")
+		})
+		gateway := httptest.NewServer(newCachingReloader(t, backend.URL))
+		t.Cleanup(gateway.Close)
+
+		received := make(chan *http.Response, 1)
+		go func() {
+			resp, err := http.Get(gateway.URL + "/c/stream")
+			if err != nil {
+				close(received)
+				return
+			}
+			io.ReadFull(resp.Body, make([]byte, len(chunk)))
+			received <- resp
+		}()
+
+		var resp *http.Response
+		select {
+		case resp = <-received:
+			require.NotNil(t, resp)
+		case <-time.After(2 * time.Second):
+			t.Fatal("업스트림 응답이 끝나기 전에 본문을 클라이언트에 전달해야 함")
+		}
+		defer resp.Body.Close()
+		assert.Equal(t, "MISS", resp.Header.Get("X-Cache"))
+
+		release <- struct{}{}
+		rest, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "end", string(rest))
+
+		w := get(gateway.Config.Handler, "/c/stream")
+		assert.Equal(t, "HIT", w.Header().Get("X-Cache"), "전달하면서 캡처한 응답을 저장해야 함")
+		assert.Equal(t, chunk+"end", w.Body.String())
+	})
+
+	t.Run("OversizedResponseNotStored", func(t *testing.T) {
+		body := strings.Repeat("b", 4096)
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			io.WriteString(w, body)
+		})
+		reloader := newCachingReloader(t, backend.URL, func(cfg *config.Config) {
+			cfg.CacheMaxItemBytes = 1024
+		})
+
+		for i := 0; i < 2; i++ {
+			w := get(reloader, "/c/large")
+			assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+			assert.Equal(t, body, w.Body.String(), "크기 제한을 넘어도 응답 본문은 모두 전달해야 함")
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "크기 제한을 넘는 응답은 저장하지 않아야 함")
+	})
+
+	t.Run("ConditionalRequests", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			assert.Empty(t, r.Header.Get("If-None-Match"), "클라이언트의 조건부 요청은 게이트웨이에서 처리해야 함")
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.Write([]byte("body"))
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		// 캐시 미스에서도 조건부 요청을 평가
+		w := sendWith(reloader, http.MethodGet, "/c/a", map[string]string{"If-None-Match": `W/"v1"`})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.Equal(t, `"v1"`, w.Header().Get("ETag"))
+
+		w = sendWith(reloader, http.MethodGet, "/c/a", map[string]string{"If-None-Match": `"v0", "v1"`})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Equal(t, "HIT", w.Header().Get("X-Cache"))
+		assert.NotEmpty(t, w.Header().Get("Age"))
+
+		w = sendWith(reloader, http.MethodGet, "/c/a", map[string]string{"If-Modified-Since": "Tue, 03 Jan 2006 15:04:05 GMT"})
+		assert.Equal(t, http.StatusNotModified, w.Code)
+
+		w = sendWith(reloader, http.MethodGet, "/c/a", map[string]string{"If-None-Match": `"v2"`})
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "body", w.Body.String())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("NoCacheRevalidates", func(t *testing.T) {
+		var calls, notModified int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "no-cache")
+			w.Header().Set("ETag", `"v1"`)
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Write([]byte("body"))
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		assert.Equal(t, "MISS", get(reloader, "/c/a").Header().Get("X-Cache"))
+		w := get(reloader, "/c/a")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "REVALIDATED", w.Header().Get("X-Cache"))
+		assert.Equal(t, "body", w.Body.String())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&notModified), "캐시된 ETag로 재검증해야 함")
+	})
+
+	t.Run("ExpiredExpiresNotServed", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		get(reloader, "/c/a")
+		assert.Equal(t, "v2", get(reloader, "/c/a").Body.String())
+	})
+
+	t.Run("StaleWhileRevalidate", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "max-age=1, stale-while-revalidate=30")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		get(reloader, "/c/a")
+		time.Sleep(1100 * time.Millisecond)
+
+		w := get(reloader, "/c/a")
+		assert.Equal(t, "STALE", w.Header().Get("X-Cache"))
+		assert.Equal(t, "v1", w.Body.String(), "재검증을 기다리지 않고 만료된 응답으로 응답해야 함")
+
+		require.Eventually(t, func() bool {
+			return get(reloader, "/c/a").Body.String() == "v2"
+		}, 2*time.Second, 10*time.Millisecond, "백그라운드 재검증으로 캐시가 갱신되어야 함")
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("StaleIfError", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			if call > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Cache-Control", "max-age=1, stale-if-error=60")
+			w.Write([]byte("v1"))
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		get(reloader, "/c/a")
+		time.Sleep(1100 * time.Millisecond)
+
+		w := get(reloader, "/c/a")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "STALE", w.Header().Get("X-Cache"))
+		assert.Equal(t, "v1", w.Body.String())
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("MustRevalidateIgnoresStaleDirectives", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "s-maxage=1, stale-while-revalidate=30, stale-if-error=30")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		get(reloader, "/c/a")
+		time.Sleep(1100 * time.Millisecond)
+
+		w := get(reloader, "/c/a")
+		assert.Equal(t, "MISS", w.Header().Get("X-Cache"), "s-maxage 응답은 만료 후 재검증 없이 사용할 수 없음")
+		assert.Equal(t, "v2", w.Body.String())
+	})
+
+	t.Run("UnsafeMethodInvalidates", func(t *testing.T) {
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			w.Header().Set("Cache-Control", "max-age=60")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		get(reloader, "/c/a")
+		assert.Equal(t, "HIT", get(reloader, "/c/a").Header().Get("X-Cache"))
+		require.Equal(t, http.StatusOK, sendWith(reloader, http.MethodPost, "/c/a", nil).Code)
+
+		w := get(reloader, "/c/a")
+		assert.Equal(t, "MISS", w.Header().Get("X-Cache"))
+		assert.Equal(t, "v3", w.Body.String())
+	})
+}
+
+// withCoalesceTimeout은 캐시 미스 합치기 대기 시간을 설정합니다.
+func withCoalesceTimeout(timeout time.Duration) func(*config.Config) {
+	return func(cfg *config.Config) {
+		cfg.CacheCoalesceTimeout = timeout
+	}
+}
+
+// getConcurrently는 같은 경로로 n개의 요청을 동시에 보내고 응답을 반환합니다.
+func getConcurrently(handler http.Handler, path string, n int) chan *httptest.ResponseRecorder {
+	results := make(chan *httptest.ResponseRecorder, n)
+	for i := 0; i < n; i++ {
+		go func() { results <- get(handler, path) }()
+	}
+	return results
+}
+
+func TestCacheCoalescing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("CollapsesConcurrentMisses", func(t *testing.T) {
+		release := make(chan struct{})
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			<-release
+			w.Header().Set("Cache-Control", "max-age=60")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL, withCoalesceTimeout(5*time.Second))
+
+		results := getConcurrently(reloader, "/c/a", 10)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, 5*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		for i := 0; i < 10; i++ {
+			w := <-results
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "v1", w.Body.String(), "모든 요청이 같은 업스트림 응답을 받아야 함")
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "동시 캐시 미스는 업스트림 요청 하나로 합쳐야 함")
+	})
+
+	t.Run("WaitTimeout", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			if call == 1 {
+				<-release
+			}
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL, withCoalesceTimeout(50*time.Millisecond))
+
+		getConcurrently(reloader, "/c/a", 1)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, 5*time.Millisecond)
+
+		start := time.Now()
+		w := get(reloader, "/c/a")
+		assert.Equal(t, "v2", w.Body.String(), "대기 시간이 지나면 직접 업스트림에 요청해야 함")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("UnshareableResponseNotFannedOut", func(t *testing.T) {
+		release := make(chan struct{})
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			<-release
+			w.Header().Set("Cache-Control", "private")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL, withCoalesceTimeout(5*time.Second))
+
+		results := getConcurrently(reloader, "/c/a", 3)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, 5*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		bodies := map[string]bool{}
+		for i := 0; i < 3; i++ {
+			bodies[(<-results).Body.String()] = true
+		}
+		assert.Len(t, bodies, 3, "저장할 수 없는 응답은 다른 요청과 공유하지 않아야 함")
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("VaryMismatchNotShared", func(t *testing.T) {
+		release := make(chan struct{})
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			<-release
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept-Language")
+			w.Write([]byte(r.Header.Get("Accept-Language")))
+		})
+		reloader := newCachingReloader(t, backend.URL, withCoalesceTimeout(5*time.Second))
+
+		results := make(chan *httptest.ResponseRecorder, 1)
+		go func() {
+			results <- sendWith(reloader, http.MethodGet, "/c/a", map[string]string{"Accept-Language": "ko"})
+		}()
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, 5*time.Millisecond)
+		go func() {
+			results <- sendWith(reloader, http.MethodGet, "/c/a", map[string]string{"Accept-Language": "en"})
+		}()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		bodies := map[string]bool{(<-results).Body.String(): true, (<-results).Body.String(): true}
+		assert.Equal(t, map[string]bool{"ko": true, "en": true}, bodies, "Vary 헤더 값이 다른 요청은 각자 응답을 받아야 함")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		release := make(chan struct{})
+		var calls int32
+		backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+			<-release
+			w.Header().Set("Cache-Control", "max-age=60")
+			fmt.Fprintf(w, "v%d", call)
+		})
+		reloader := newCachingReloader(t, backend.URL)
+
+		results := getConcurrently(reloader, "/c/a", 3)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 3 }, time.Second, 5*time.Millisecond,
+			"CACHE_COALESCE_TIMEOUT이 0이면 요청을 합치지 않아야 함")
+		close(release)
+		for i := 0; i < 3; i++ {
+			<-results
+		}
+	})
+}
+
+// adminCacheRequest는 관리자 토큰으로 캐시 관리 API를 호출합니다.
+func adminCacheRequest(handler http.Handler, method, path string, query url.Values, token string) *httptest.ResponseRecorder {
+	return sendWith(handler, method, path+"?"+query.Encode(), map[string]string{"Authorization": "Bearer " + token})
+}
+
+func TestCacheAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var calls int32
+	backend := newCacheBackend(t, &calls, func(w http.ResponseWriter, r *http.Request, call int32) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		switch r.URL.Path {
+		case "/receipts/1":
+			w.Header().Set("Surrogate-Key", "receipts receipt-1")
+		case "/receipts/2":
+			w.Header().Set("Surrogate-Key", "receipts receipt-2")
+		case "/users/1":
+			w.Header().Set("Surrogate-Key", "users")
+		}
+		fmt.Fprintf(w, "v%d", call)
+	})
+	reloader := newCachingReloader(t, backend.URL)
+
+	authenticator := auth.New("test-secret", "test-issuer", time.Hour)
+	adminToken, err := authenticator.GenerateToken("admin-1", []string{"admin"})
+	require.NoError(t, err)
+	userToken, err := authenticator.GenerateToken("user-1", []string{"user"})
+	require.NoError(t, err)
+
+	fill := func() {
+		for _, path := range []string{"/c/receipts/1", "/c/receipts/2", "/c/users/1", "/c/users/1?page=2"} {
+			get(reloader, path)
+		}
+	}
+	cacheStatus := func(path string) string {
+		return get(reloader, path).Header().Get("X-Cache")
+	}
+
+	t.Run("RequiresAdminRole", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/admin/cache", "").Code)
+		assert.Equal(t, http.StatusForbidden, getWithToken(reloader, "/admin/cache", userToken).Code)
+		assert.Equal(t, http.StatusForbidden, adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"all": {"true"}}, userToken).Code)
+	})
+
+	t.Run("ListAndInspect", func(t *testing.T) {
+		fill()
+
+		w := adminCacheRequest(reloader, http.MethodGet, "/admin/cache", url.Values{"prefix": {"/c/users"}}, adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var list struct {
+			Entries []struct {
+				Key   string   `json:"key"`
+				Path  string   `json:"path"`
+				Size  int64    `json:"size"`
+				TTL   int      `json:"ttl"`
+				Fresh bool     `json:"fresh"`
+				Tags  []string `json:"tags"`
+			} `json:"entries"`
+			Total int `json:"total"`
+			Stats struct {
+				Entries int `json:"entries"`
+			} `json:"stats"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		require.Equal(t, 2, list.Total)
+		assert.Equal(t, "GET:/c/users/1", list.Entries[0].Key)
+		assert.Equal(t, "GET:/c/users/1?page=2", list.Entries[1].Key)
+		assert.Equal(t, "/c/users/1", list.Entries[1].Path)
+		assert.Positive(t, list.Entries[0].Size)
+		assert.InDelta(t, 60, list.Entries[0].TTL, 1, "남은 TTL을 초 단위로 반환해야 함")
+		assert.True(t, list.Entries[0].Fresh)
+		assert.Equal(t, []string{"users"}, list.Entries[0].Tags)
+		assert.Equal(t, 4, list.Stats.Entries)
+
+		w = adminCacheRequest(reloader, http.MethodGet, "/admin/cache", url.Values{"limit": {"1"}}, adminToken)
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		assert.Len(t, list.Entries, 1)
+		assert.Equal(t, 4, list.Total)
+
+		w = adminCacheRequest(reloader, http.MethodGet, "/admin/cache/entry", url.Values{"key": {"GET:/c/receipts/1"}}, adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+		var entry struct {
+			Status   int                 `json:"status"`
+			BodySize int                 `json:"bodySize"`
+			Lifetime int                 `json:"lifetime"`
+			Headers  map[string][]string `json:"headers"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entry))
+		assert.Equal(t, http.StatusOK, entry.Status)
+		assert.Equal(t, 2, entry.BodySize)
+		assert.Equal(t, 60, entry.Lifetime)
+		assert.Equal(t, []string{"receipts receipt-1"}, entry.Headers["Surrogate-Key"])
+
+		w = adminCacheRequest(reloader, http.MethodGet, "/admin/cache/entry", url.Values{"key": {"GET:/c/none"}}, adminToken)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("PurgeByTag", func(t *testing.T) {
+		fill()
+
+		w := adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"tag": {"receipt-1"}}, adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"purged":1,"keys":["GET:/c/receipts/1"]}`, w.Body.String())
+
+		assert.Equal(t, "MISS", cacheStatus("/c/receipts/1"))
+		assert.Equal(t, "HIT", cacheStatus("/c/receipts/2"))
+
+		w = adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"tag": {"receipts"}}, adminToken)
+		assert.Contains(t, w.Body.String(), `"purged":2`)
+		assert.Equal(t, "HIT", cacheStatus("/c/users/1"))
+	})
+
+	t.Run("PurgeByPath", func(t *testing.T) {
+		fill()
+
+		w := adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"pattern": {"/c/*/1"}}, adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"purged":3`, "glob 패턴은 쿼리가 다른 항목까지 삭제해야 함")
+		assert.Equal(t, "HIT", cacheStatus("/c/receipts/2"))
+
+		fill()
+		w = adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"prefix": {"/c/receipts/"}}, adminToken)
+		assert.Contains(t, w.Body.String(), `"purged":2`)
+		assert.Equal(t, "MISS", cacheStatus("/c/receipts/2"))
+		assert.Equal(t, "HIT", cacheStatus("/c/users/1"))
+
+		w = adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"pattern": {"[/c"}}, adminToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("PurgeByKey", func(t *testing.T) {
+		fill()
+
+		w := adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"key": {"GET:/c/users/1"}}, adminToken)
+		assert.JSONEq(t, `{"purged":1,"keys":["GET:/c/users/1"]}`, w.Body.String())
+		assert.Equal(t, "MISS", cacheStatus("/c/users/1"))
+		assert.Equal(t, "HIT", cacheStatus("/c/users/1?page=2"))
+	})
+
+	t.Run("PurgeAll", func(t *testing.T) {
+		fill()
+
+		w := adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{}, adminToken)
+		assert.Equal(t, http.StatusBadRequest, w.Code, "조건 없이 삭제할 수 없어야 함")
+
+		w = adminCacheRequest(reloader, http.MethodDelete, "/admin/cache", url.Values{"all": {"true"}}, adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+		for _, path := range []string{"/c/receipts/1", "/c/users/1"} {
+			assert.Equal(t, "MISS", cacheStatus(path))
+		}
+	})
+}
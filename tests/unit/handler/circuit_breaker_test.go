@@ -0,0 +1,172 @@
+// +build unit
+
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/tests/mocks"
+)
+
+// closedBackendURL은 연결이 거부되는 대상 서버 URL을 반환합니다.
+func closedBackendURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+// writeBreakerRoutes는 서킷 브레이커 설정을 가진 두 라우트 구성을 작성합니다.
+func writeBreakerRoutes(t *testing.T, path, scope, targetA, targetB string) {
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/a/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/a","timeout":5,
+			"circuitBreaker":{"scope":"%s","minRequests":2,"errorThreshold":0.5}},
+		{"path":"/b/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/b","timeout":5,
+			"circuitBreaker":{"scope":"%s","minRequests":2,"errorThreshold":0.5}}
+	]}`, targetA, scope, targetB, scope)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// getWithToken은 Bearer 토큰을 포함하여 GET 요청을 보냅니다.
+func getWithToken(handler http.Handler, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestCircuitBreakerRegistry(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("RouteScopeIsolatesRoutes", func(t *testing.T) {
+		backend := newBackend(t, "backend-ok")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeBreakerRoutes(t, routesPath, "route", closedBackendURL(), backend.URL)
+
+		reloader := newTestReloader(t, routesPath)
+
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, http.StatusBadGateway, get(reloader, "/a/hello").Code)
+		}
+		assert.Equal(t, http.StatusServiceUnavailable, get(reloader, "/a/hello").Code, "실패한 라우트의 서킷은 열려야 함")
+
+		w := get(reloader, "/b/hello")
+		assert.Equal(t, http.StatusOK, w.Code, "다른 라우트는 서킷 열림의 영향을 받지 않아야 함")
+		assert.Equal(t, "backend-ok", w.Body.String())
+	})
+
+	t.Run("TargetScopeSharedAcrossRoutes", func(t *testing.T) {
+		target := closedBackendURL()
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeBreakerRoutes(t, routesPath, "target", target, target)
+
+		reloader := newTestReloader(t, routesPath)
+
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, http.StatusBadGateway, get(reloader, "/a/hello").Code)
+		}
+		assert.Equal(t, http.StatusServiceUnavailable, get(reloader, "/b/hello").Code, "같은 대상 서버를 사용하는 라우트는 서킷을 공유해야 함")
+	})
+
+	t.Run("ReloadKeepsState", func(t *testing.T) {
+		backend := newBackend(t, "backend-ok")
+		failing := closedBackendURL()
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeBreakerRoutes(t, routesPath, "route", failing, backend.URL)
+
+		reloader := newTestReloader(t, routesPath)
+		for i := 0; i < 2; i++ {
+			get(reloader, "/a/hello")
+		}
+
+		// 설정이 같으면 리로드 후에도 열린 서킷이 유지됨
+		writeBreakerRoutes(t, routesPath, "route", failing, newBackend(t, "backend-new").URL)
+		require.NoError(t, reloader.Reload())
+
+		assert.Equal(t, http.StatusServiceUnavailable, get(reloader, "/a/hello").Code, "리로드 후에도 서킷 상태가 유지되어야 함")
+		assert.Equal(t, "backend-new", get(reloader, "/b/hello").Body.String())
+	})
+
+	t.Run("RouteFailureClassifier", func(t *testing.T) {
+		fs := mocks.NewFaultServer()
+		t.Cleanup(fs.Close)
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/f/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/f","timeout":5,
+				"circuitBreaker":{"minRequests":2,"errorThreshold":0.5,"failureStatusCodes":[503]}}
+		]}`, fs.URL())
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+		reloader := newTestReloader(t, routesPath)
+
+		// 백엔드의 5xx 응답은 그대로 전달되면서 실패로 기록됨
+		for i := 0; i < 2; i++ {
+			w := get(reloader, "/f/api/status/503")
+			assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+			assert.Contains(t, w.Body.String(), `"status":503`, "백엔드 응답이 전달되어야 함")
+		}
+
+		w := get(reloader, "/f/api/status/200")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Contains(t, w.Body.String(), "circuit breaker is open", "반복된 5xx 응답으로 서킷이 열려야 함")
+	})
+
+	t.Run("AdminEndpoint", func(t *testing.T) {
+		backend := newBackend(t, "backend-ok")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeBreakerRoutes(t, routesPath, "route", closedBackendURL(), backend.URL)
+
+		reloader := newTestReloader(t, routesPath)
+		for i := 0; i < 2; i++ {
+			get(reloader, "/a/hello")
+		}
+		get(reloader, "/b/hello")
+
+		authenticator := auth.New("test-secret", "test-issuer", time.Hour)
+		userToken, err := authenticator.GenerateToken("user-1", []string{"user"})
+		require.NoError(t, err)
+		adminToken, err := authenticator.GenerateToken("admin-1", []string{"admin"})
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/admin/circuit-breakers", "").Code, "인증 없이 접근할 수 없어야 함")
+		assert.Equal(t, http.StatusForbidden, getWithToken(reloader, "/admin/circuit-breakers", userToken).Code, "관리자 역할이 필요해야 함")
+
+		w := getWithToken(reloader, "/admin/circuit-breakers", adminToken)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body struct {
+			CircuitBreakers []struct {
+				Key     string                 `json:"key"`
+				State   string                 `json:"state"`
+				Metrics map[string]interface{} `json:"metrics"`
+			} `json:"circuit_breakers"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.CircuitBreakers, 2)
+
+		assert.Equal(t, "route:GET /a/*path", body.CircuitBreakers[0].Key)
+		assert.Equal(t, "open", body.CircuitBreakers[0].Metrics["state"])
+		assert.Contains(t, body.CircuitBreakers[0].State, "state: open")
+		assert.Equal(t, "route:GET /b/*path", body.CircuitBreakers[1].Key)
+		assert.Equal(t, "closed", body.CircuitBreakers[1].Metrics["state"])
+	})
+}
@@ -0,0 +1,226 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/metrics"
+	"github.com/isinthesky/api-gateway/internal/middleware"
+	"github.com/isinthesky/api-gateway/pkg/concurrency"
+)
+
+// newBlockingBackend는 release가 닫힐 때까지 응답을 보류하는 백엔드를 생성합니다.
+func newBlockingBackend(t *testing.T, started chan<- struct{}, release <-chan struct{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ShedsWhenSaturated", func(t *testing.T) {
+		started := make(chan struct{}, 4)
+		release := make(chan struct{})
+		backend := newBlockingBackend(t, started, release)
+
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/c/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/c","timeout":5,
+				"concurrency":{"limit":1,"queueSize":1,"queueTimeout":50,"retryAfter":3}}
+		]}`, backend.URL)
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+		reloader := newTestReloader(t, routesPath)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		var first *httptest.ResponseRecorder
+		go func() {
+			defer wg.Done()
+			first = get(reloader, "/c/slow")
+		}()
+		<-started
+
+		// 대기열에서 기다리다 시간이 지나면 거부
+		start := time.Now()
+		w := get(reloader, "/c/slow")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "3", w.Header().Get("Retry-After"))
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "대기열 시간만큼 기다려야 함")
+
+		close(release)
+		wg.Wait()
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusOK, get(reloader, "/c/fast").Code, "처리가 끝나면 다시 허용해야 함")
+	})
+
+	t.Run("QueuedRequestProceeds", func(t *testing.T) {
+		started := make(chan struct{}, 4)
+		release := make(chan struct{})
+		backend := newBlockingBackend(t, started, release)
+
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/c/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/c","timeout":5,
+				"concurrency":{"limit":1,"queueSize":1,"queueTimeout":2000}}
+		]}`, backend.URL)
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+		reloader := newTestReloader(t, routesPath)
+
+		codes := make([]int, 2)
+		var wg sync.WaitGroup
+		for i := range codes {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				codes[i] = get(reloader, "/c/slow").Code
+			}(i)
+		}
+		<-started
+
+		// 세 번째 요청은 대기열이 가득 차 바로 거부
+		assert.Eventually(t, func() bool {
+			return get(reloader, "/c/slow").Code == http.StatusServiceUnavailable
+		}, time.Second, 10*time.Millisecond)
+
+		close(release)
+		wg.Wait()
+		assert.Equal(t, []int{http.StatusOK, http.StatusOK}, codes, "대기한 요청은 슬롯이 반환되면 처리되어야 함")
+	})
+
+	t.Run("UpstreamLimitSharedAcrossRoutes", func(t *testing.T) {
+		started := make(chan struct{}, 4)
+		release := make(chan struct{})
+		backend := newBlockingBackend(t, started, release)
+
+		content := fmt.Sprintf(`{
+			"upstreams":[{"name":"receipt","strategy":"round-robin","targets":[{"url":"%s","weight":1}],
+				"concurrency":{"limit":1}}],
+			"routes":[
+				{"path":"/a/*path","upstream":"receipt","targetURL":"/","methods":["GET"],"stripPrefix":"/a","timeout":5},
+				{"path":"/b/*path","upstream":"receipt","targetURL":"/","methods":["GET"],"stripPrefix":"/b","timeout":5}
+			]
+		}`, backend.URL)
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+		reloader := newTestReloader(t, routesPath)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			get(reloader, "/a/slow")
+		}()
+		<-started
+
+		assert.Equal(t, http.StatusServiceUnavailable, get(reloader, "/b/slow").Code, "업스트림 한도는 라우트 간에 공유되어야 함")
+
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("InvalidConfig", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRoutes(t, routesPath, backend.URL)
+		reloader := newTestReloader(t, routesPath)
+
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/c/*path","targetURL":"%s","methods":["GET"],"concurrency":{"mode":"vegas"}}
+		]}`, backend.URL)
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+		assert.Error(t, reloader.Reload(), "지원하지 않는 동시 요청 제한 방식은 거부해야 함")
+	})
+}
+
+func TestConcurrencyLimitInFlightMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	collector := metrics.NewCollector()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	router := gin.New()
+	router.GET("/c", middleware.ConcurrencyLimit([]middleware.ConcurrencyScope{
+		{Name: "route=/c", Limiter: concurrency.New(concurrency.Config{Limit: 2})},
+		{Name: "upstream=receipt", Limiter: concurrency.New(concurrency.Config{Limit: 2})},
+	}, collector), func(c *gin.Context) {
+		started <- struct{}{}
+		<-release
+		c.String(http.StatusOK, "ok")
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		get(router, "/c")
+	}()
+	<-started
+
+	assert.Equal(t, 1.0, inFlightGauge(t, "route=/c"), "처리 중인 요청을 범위별로 기록해야 함")
+	assert.Equal(t, 1.0, inFlightGauge(t, "upstream=receipt"))
+
+	close(release)
+	<-done
+	assert.Equal(t, 0.0, inFlightGauge(t, "route=/c"), "요청이 끝나면 감소해야 함")
+	assert.Equal(t, 0.0, inFlightGauge(t, "upstream=receipt"))
+}
+
+// inFlightGauge는 범위의 api_gateway_concurrency_in_flight 게이지 값을 반환합니다.
+func inFlightGauge(t *testing.T, scope string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != "api_gateway_concurrency_in_flight" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "scope" && label.GetValue() == scope {
+					return metric.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestConcurrencyLimitReleasesOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := concurrency.New(concurrency.Config{Limit: 1})
+
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.GET("/c", middleware.ConcurrencyLimit([]middleware.ConcurrencyScope{
+		{Name: "route=/c", Limiter: limiter},
+	}, nil), func(c *gin.Context) {
+		if c.Query("panic") != "" {
+			panic("handler failure")
+		}
+		c.String(http.StatusOK, "ok")
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, get(router, "/c?panic=1").Code)
+	assert.Equal(t, 0, limiter.Stats().InFlight, "패닉이 발생해도 슬롯을 반환해야 함")
+	assert.Equal(t, 1, limiter.Stats().Limit)
+	assert.Equal(t, http.StatusOK, get(router, "/c").Code)
+}
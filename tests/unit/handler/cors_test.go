@@ -0,0 +1,58 @@
+// +build unit
+
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/isinthesky/api-gateway/internal/middleware"
+)
+
+func TestCORSAllowExtraHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware.CORS([]string{"http://example.com/"}, "X-CSRF-Token"))
+	router.GET("/test", func(c *gin.Context) {
+		c.String(http.StatusOK, "test")
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+	req.Header.Set("Origin", "http://example.com")
+	req.Header.Set("Access-Control-Request-Headers", "X-CSRF-Token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "http://example.com", w.Header().Get("Access-Control-Allow-Origin"), "끝의 /는 무시해야 함")
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "X-CSRF-Token")
+}
+
+func TestIsTrustedOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		origin  string
+		want    bool
+	}{
+		{"일치", []string{"https://app.example.com"}, "https://app.example.com", true},
+		{"대소문자 무시", []string{"https://App.Example.com"}, "https://app.example.com", true},
+		{"끝의 / 무시", []string{"https://app.example.com/"}, "https://app.example.com", true},
+		{"목록에 없음", []string{"https://app.example.com"}, "https://evil.example.com", false},
+		{"스킴 불일치", []string{"https://app.example.com"}, "http://app.example.com", false},
+		{"포트 불일치", []string{"https://app.example.com"}, "https://app.example.com:8443", false},
+		{"와일드카드는 명시된 오리진이 아님", []string{"*"}, "https://evil.example.com", false},
+		{"불투명 오리진", []string{"null"}, "null", false},
+		{"빈 오리진", []string{""}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, middleware.IsTrustedOrigin(tt.allowed, tt.origin))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/handler"
+)
+
+// newCSRFReloader는 라우트별 CSRF 설정을 사용하는 게이트웨이를 생성합니다.
+func newCSRFReloader(t *testing.T, configure ...func(*config.Config)) *handler.RouteReloader {
+	backend := newBackend(t, "ok")
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/api/*path","targetURL":"%[1]s","methods":["GET","POST","DELETE"],"requireAuth":true},
+		{"path":"/forms/*path","targetURL":"%[1]s","methods":["GET","POST"],"requireAuth":true,"csrf":{"mode":"token"}},
+		{"path":"/strict/*path","targetURL":"%[1]s","methods":["POST"],"requireAuth":true,"csrf":{"mode":"both"}},
+		{"path":"/partner/*path","targetURL":"%[1]s","methods":["POST"],"requireAuth":true,"csrf":{"trustedOrigins":["https://partner.example.com","null"]}},
+		{"path":"/webhooks/*path","targetURL":"%[1]s","methods":["POST"],"requireAuth":true,"csrf":{"mode":"off"}}]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+	return newTestReloader(t, routesPath, append([]func(*config.Config){func(cfg *config.Config) {
+		cfg.AllowedOrigins = []string{"https://app.example.com"}
+		cfg.CSRFTrustedOrigins = []string{"https://Admin.Example.com/"}
+	}}, configure...)...)
+}
+
+func TestCSRFOriginCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gateway := newCSRFReloader(t)
+	token := signClaims(t, jwt.MapClaims{})
+	cookie := "access_token=" + token
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		headers map[string]string
+		want    int
+	}{
+		{"출처 헤더 없음 (브라우저가 아닌 클라이언트)", http.MethodPost, "/api/x", map[string]string{}, http.StatusOK},
+		{"같은 출처", http.MethodPost, "/api/x", map[string]string{"Origin": "http://example.com"}, http.StatusOK},
+		{"Sec-Fetch-Site same-origin", http.MethodPost, "/api/x", map[string]string{"Sec-Fetch-Site": "same-origin", "Origin": "https://other.example.com"}, http.StatusOK},
+		{"CORS 허용 오리진", http.MethodPost, "/api/x", map[string]string{"Sec-Fetch-Site": "same-site", "Origin": "https://app.example.com"}, http.StatusOK},
+		{"CSRF 허용 오리진 (대소문자, 끝의 / 무시)", http.MethodPost, "/api/x", map[string]string{"Origin": "https://admin.example.com"}, http.StatusOK},
+		{"CSRF 허용 오리진 (Referer)", http.MethodPost, "/api/x", map[string]string{"Referer": "https://admin.example.com/settings"}, http.StatusOK},
+		{"허용 오리진과 스킴이 다름", http.MethodPost, "/api/x", map[string]string{"Origin": "http://app.example.com"}, http.StatusForbidden},
+		{"다른 사이트", http.MethodPost, "/api/x", map[string]string{"Sec-Fetch-Site": "cross-site", "Origin": "https://evil.example.com"}, http.StatusForbidden},
+		{"다른 사이트 (Origin만)", http.MethodDelete, "/api/x", map[string]string{"Origin": "https://evil.example.com"}, http.StatusForbidden},
+		{"다른 사이트 (Referer만)", http.MethodPost, "/api/x", map[string]string{"Referer": "https://evil.example.com/form"}, http.StatusForbidden},
+		{"같은 출처 (Referer만)", http.MethodPost, "/api/x", map[string]string{"Referer": "http://example.com/form"}, http.StatusOK},
+		{"불투명 오리진", http.MethodPost, "/api/x", map[string]string{"Origin": "null"}, http.StatusForbidden},
+		{"출처 헤더 없는 교차 사이트 요청", http.MethodPost, "/api/x", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"안전한 메서드", http.MethodGet, "/api/x", map[string]string{"Origin": "https://evil.example.com"}, http.StatusOK},
+		{"라우트 허용 오리진", http.MethodPost, "/partner/x", map[string]string{"Origin": "https://partner.example.com"}, http.StatusOK},
+		{"불투명 오리진은 허용 목록에 있어도 거부", http.MethodPost, "/partner/x", map[string]string{"Origin": "null"}, http.StatusForbidden},
+		{"라우트 허용 오리진은 다른 라우트에 적용하지 않음", http.MethodPost, "/api/x", map[string]string{"Origin": "https://partner.example.com"}, http.StatusForbidden},
+		{"검사하지 않는 라우트", http.MethodPost, "/webhooks/x", map[string]string{"Origin": "https://evil.example.com"}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.headers["Cookie"] = cookie
+			assert.Equal(t, tt.want, sendWith(gateway, tt.method, tt.path, tt.headers).Code)
+		})
+	}
+
+	t.Run("헤더로 인증한 요청은 검사하지 않음", func(t *testing.T) {
+		w := sendWith(gateway, http.MethodPost, "/api/x", map[string]string{
+			"Authorization": "Bearer " + token,
+			"Origin":        "https://evil.example.com",
+		})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("관리자 API", func(t *testing.T) {
+		admin := signClaims(t, jwt.MapClaims{"roles": []string{"admin"}})
+		w := sendWith(gateway, http.MethodDelete, "/admin/cache?all=true", map[string]string{
+			"Cookie": "access_token=" + admin,
+			"Origin": "https://evil.example.com",
+		})
+		assert.Equal(t, http.StatusForbidden, w.Code, "쿠키로 인증한 관리자 요청도 검사해야 함")
+
+		w = sendWith(gateway, http.MethodDelete, "/admin/cache?all=true", map[string]string{"Cookie": "access_token=" + admin})
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+func TestCSRFWildcardOriginNotTrusted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gateway := newCSRFReloader(t, func(cfg *config.Config) {
+		cfg.AllowedOrigins = []string{"*"}
+	})
+
+	w := sendWith(gateway, http.MethodPost, "/api/x", map[string]string{
+		"Cookie": "access_token=" + signClaims(t, jwt.MapClaims{}),
+		"Origin": "https://evil.example.com",
+	})
+	assert.Equal(t, http.StatusForbidden, w.Code, "ALLOWED_ORIGINS=*는 CSRF 검사에서 모든 오리진을 허용하지 않아야 함")
+}
+
+func TestCSRFDoubleSubmitToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	gateway := newCSRFReloader(t)
+	auth := "access_token=" + signClaims(t, jwt.MapClaims{})
+
+	w := sendWith(gateway, http.MethodGet, "/forms/x", map[string]string{"Cookie": auth})
+	require.Equal(t, http.StatusOK, w.Code)
+	csrf := responseCookie(w, config.DefaultCSRFCookie)
+	require.NotNil(t, csrf, "쿠키로 인증한 요청에 CSRF 토큰 쿠키를 발급해야 함")
+	assert.False(t, csrf.HttpOnly, "스크립트가 토큰을 읽을 수 있어야 함")
+
+	cookies := auth + "; " + config.DefaultCSRFCookie + "=" + csrf.Value
+	w = sendWith(gateway, http.MethodGet, "/forms/x", map[string]string{"Cookie": cookies})
+	assert.Nil(t, responseCookie(w, config.DefaultCSRFCookie), "토큰 쿠키가 있으면 다시 발급하지 않아야 함")
+
+	assert.Equal(t, http.StatusOK, sendWith(gateway, http.MethodPost, "/forms/x", map[string]string{
+		"Cookie": cookies, config.DefaultCSRFHeader: csrf.Value,
+	}).Code)
+	assert.Equal(t, http.StatusOK, sendWith(gateway, http.MethodPost, "/forms/x", map[string]string{
+		"Cookie": cookies, config.DefaultCSRFHeader: csrf.Value, "Origin": "https://evil.example.com",
+	}).Code, "token 방식은 출처를 확인하지 않음")
+	assert.Equal(t, http.StatusForbidden, sendWith(gateway, http.MethodPost, "/forms/x", map[string]string{
+		"Cookie": cookies, config.DefaultCSRFHeader: "forged",
+	}).Code)
+	assert.Equal(t, http.StatusForbidden, sendWith(gateway, http.MethodPost, "/forms/x", map[string]string{
+		"Cookie": cookies,
+	}).Code, "토큰 헤더가 없으면 거부해야 함")
+
+	w = sendWith(gateway, http.MethodPost, "/forms/x", map[string]string{"Cookie": auth, config.DefaultCSRFHeader: "guess"})
+	assert.Equal(t, http.StatusForbidden, w.Code, "토큰 쿠키가 없으면 거부해야 함")
+	assert.NotNil(t, responseCookie(w, config.DefaultCSRFCookie), "거부할 때 토큰 쿠키를 발급하여 다시 시도할 수 있어야 함")
+
+	assert.Equal(t, http.StatusForbidden, sendWith(gateway, http.MethodPost, "/strict/x", map[string]string{
+		"Cookie": cookies, config.DefaultCSRFHeader: csrf.Value, "Origin": "https://evil.example.com",
+	}).Code, "both 방식은 토큰이 맞아도 출처를 확인해야 함")
+	assert.Equal(t, http.StatusOK, sendWith(gateway, http.MethodPost, "/strict/x", map[string]string{
+		"Cookie": cookies, config.DefaultCSRFHeader: csrf.Value, "Origin": "https://app.example.com",
+	}).Code)
+}
@@ -0,0 +1,139 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+)
+
+// newHeaderBackend는 마지막으로 받은 요청 헤더를 기록하는 테스트 백엔드를 생성합니다.
+func newHeaderBackend(t *testing.T) (*httptest.Server, func() http.Header) {
+	var mu sync.Mutex
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = r.Header.Clone()
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+		return received
+	}
+}
+
+func TestIdentityPropagation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	backend, received := newHeaderBackend(t)
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/public/*path","targetURL":"%[1]s","methods":["GET"]},
+		{"path":"/private/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true}
+	]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+	identityHeaders, err := config.ParseIdentityHeaders(append(config.DefaultIdentityHeaders, "X-Tenant=org.id"))
+	require.NoError(t, err)
+
+	spoofed := map[string]string{
+		"X-User-Id":        "admin",
+		"X-User-Roles":     "admin",
+		"X-Tenant":         "other-tenant",
+		"X-Internal-Token": "forged",
+	}
+	token := signClaims(t, jwt.MapClaims{
+		"email": "user@example.com",
+		"roles": []string{"user", "editor"},
+		"scope": "receipts:read receipts:write",
+		"org":   map[string]interface{}{"id": "tenant-1"},
+	})
+
+	t.Run("StripsSpoofedHeadersOnPublicRoutes", func(t *testing.T) {
+		reloader := newTestReloader(t, routesPath, func(cfg *config.Config) {
+			cfg.ForwardIdentity = true
+			cfg.IdentityHeaders = identityHeaders
+		})
+
+		require.Equal(t, http.StatusOK, sendWith(reloader, http.MethodGet, "/public/x", spoofed).Code)
+		for name := range spoofed {
+			assert.Empty(t, received().Get(name), "클라이언트가 보낸 %s 헤더는 제거해야 함", name)
+		}
+	})
+
+	t.Run("ForwardsVerifiedClaims", func(t *testing.T) {
+		reloader := newTestReloader(t, routesPath, func(cfg *config.Config) {
+			cfg.ForwardIdentity = true
+			cfg.IdentityHeaders = identityHeaders
+		})
+
+		headers := map[string]string{"Authorization": "Bearer " + token}
+		for name, value := range spoofed {
+			headers[name] = value
+		}
+		require.Equal(t, http.StatusOK, sendWith(reloader, http.MethodGet, "/private/x", headers).Code)
+
+		got := received()
+		assert.Equal(t, []string{"user-1"}, got.Values("X-User-Id"))
+		assert.Equal(t, "user@example.com", got.Get("X-User-Email"))
+		assert.Equal(t, "user,editor", got.Get("X-User-Roles"))
+		assert.Equal(t, "receipts:read receipts:write", got.Get("X-User-Scopes"))
+		assert.Equal(t, []string{"tenant-1"}, got.Values("X-Tenant"), "중첩 클레임도 전달해야 함")
+		assert.Empty(t, got.Get("X-User-Name"), "없는 클레임은 전달하지 않아야 함")
+		assert.Empty(t, got.Get("X-Internal-Token"), "내부 JWT를 설정하지 않으면 제거만 해야 함")
+	})
+
+	t.Run("InternalToken", func(t *testing.T) {
+		reloader := newTestReloader(t, routesPath, func(cfg *config.Config) {
+			cfg.ForwardIdentity = false
+			cfg.InternalJWTSecret = "internal-secret"
+			cfg.InternalJWTIssuer = "gateway"
+			cfg.InternalJWTAudience = "receipt-service"
+			cfg.InternalJWTTTL = 30 * time.Second
+		})
+
+		require.Equal(t, http.StatusOK, sendWith(reloader, http.MethodGet, "/private/x", map[string]string{
+			"Authorization":    "Bearer " + token,
+			"X-Internal-Token": "forged",
+		}).Code)
+
+		got := received()
+		assert.Empty(t, got.Get("X-User-Id"), "FORWARD_IDENTITY=false이면 사용자 정보 헤더를 전달하지 않아야 함")
+		require.Len(t, got.Values("X-Internal-Token"), 1)
+
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(got.Get("X-Internal-Token"), claims, func(*jwt.Token) (interface{}, error) {
+			return []byte("internal-secret"), nil
+		}, jwt.WithIssuer("gateway"), jwt.WithAudience("receipt-service"), jwt.WithValidMethods([]string{"HS256"}))
+		require.NoError(t, err, "내부 JWT는 공유 비밀 키로 검증할 수 있어야 함")
+
+		assert.Equal(t, "user-1", claims["sub"])
+		assert.Equal(t, "test-issuer", claims["orig_iss"])
+		assert.Equal(t, []interface{}{"user", "editor"}, claims["roles"])
+		assert.Equal(t, "receipts:read receipts:write", claims["scope"])
+
+		exp, err := claims.GetExpirationTime()
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(30*time.Second), exp.Time, 2*time.Second, "짧은 유효 기간을 사용해야 함")
+
+		require.Equal(t, http.StatusOK, sendWith(reloader, http.MethodGet, "/public/x", map[string]string{"X-Internal-Token": "forged"}).Code)
+		assert.Empty(t, received().Get("X-Internal-Token"), "인증하지 않는 라우트에는 내부 JWT를 전달하지 않아야 함")
+	})
+}
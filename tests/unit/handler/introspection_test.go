@@ -0,0 +1,55 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/handler"
+)
+
+func TestOpaqueTokenAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	introspection := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.PostFormValue("token") {
+		case "opaque-user":
+			fmt.Fprintf(w, `{"active":true,"sub":"user-1","scope":"receipts:read","exp":%d}`, time.Now().Add(time.Hour).Unix())
+		case "opaque-down":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			fmt.Fprint(w, `{"active":false}`)
+		}
+	}))
+	t.Cleanup(introspection.Close)
+
+	backend := newBackend(t, "ok")
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[{"path":"/api/*path","targetURL":"%s","methods":["GET"],"requireAuth":true,
+		"authorization":{"scopes":["receipts:read"]}}]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+	reloader := newTestReloaderWith(t, routesPath, func(h *handler.RouteHandler) {
+		h.SetAuthenticator(auth.NewOpaqueTokenAuthenticator(
+			auth.New("test-secret", "test-issuer", time.Hour),
+			auth.NewIntrospectionAuthenticator(auth.IntrospectionConfig{URL: introspection.URL}),
+		))
+	})
+
+	assert.Equal(t, http.StatusOK, getWithToken(reloader, "/api/x", "opaque-user").Code, "introspection 응답의 scope로 권한을 확인해야 함")
+	assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/api/x", "opaque-revoked").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, getWithToken(reloader, "/api/x", "opaque-down").Code, "인증 서비스 장애는 503으로 응답해야 함")
+	assert.Equal(t, http.StatusOK, getWithToken(reloader, "/api/x", signClaims(t, jwt.MapClaims{"scope": "receipts:read"})).Code, "JWT 토큰은 그대로 검증해야 함")
+}
@@ -0,0 +1,196 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/handler"
+	"github.com/isinthesky/api-gateway/tests/mocks"
+)
+
+const testRedirectURL = "http://gateway.test/auth/callback"
+
+// newLoginReloader는 모의 IdP로 브라우저 로그인을 하는 게이트웨이를 생성합니다.
+// /api/* 라우트는 인증이 필요하며 업스트림이 받은 헤더를 반환된 함수로 확인할 수 있습니다.
+func newLoginReloader(t *testing.T, idp *mocks.OIDCServer) (*handler.RouteReloader, func() http.Header) {
+	backend, received := newHeaderBackend(t)
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[{"path":"/api/*path","targetURL":"%s","methods":["GET"],"requireAuth":true}]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+	sessions, err := auth.NewSessionCodec("0123456789abcdef0123456789abcdef")
+	require.NoError(t, err)
+
+	reloader := newTestReloaderWith(t, routesPath, func(h *handler.RouteHandler) {
+		h.SetOIDCLogin(auth.NewOIDCProvider(auth.OIDCConfig{
+			Issuer:      idp.URL(),
+			ClientID:    "web",
+			RedirectURL: testRedirectURL,
+		}), sessions)
+	}, func(cfg *config.Config) {
+		cfg.OIDCPostLogoutRedirectURL = "http://gateway.test/"
+	})
+	return reloader, received
+}
+
+// login은 로그인 시작부터 IdP 승인, 콜백까지 브라우저 로그인을 진행하고 콜백 응답을 반환합니다.
+func login(t *testing.T, gateway http.Handler, redirect string) *httptest.ResponseRecorder {
+	start := get(gateway, "/auth/login?redirect="+url.QueryEscape(redirect))
+	require.Equal(t, http.StatusFound, start.Code)
+	stateCookie := responseCookie(start, "gw_oidc_state")
+	require.NotNil(t, stateCookie, "로그인 요청 쿠키를 설정해야 함")
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(start.Header().Get("Location"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return withCookies(gateway, http.MethodGet, callback.RequestURI(), stateCookie)
+}
+
+// withCookies는 쿠키를 포함한 요청을 보냅니다.
+func withCookies(handler http.Handler, method, path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// responseCookie는 응답이 설정한 쿠키를 반환합니다.
+func responseCookie(w *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestOIDCLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	idp := mocks.NewOIDCServer("web", "test-issuer", "test-secret")
+	t.Cleanup(idp.Close)
+	gateway, received := newLoginReloader(t, idp)
+
+	assert.Equal(t, http.StatusUnauthorized, get(gateway, "/api/x").Code)
+
+	callback := login(t, gateway, "/app/receipts?page=2")
+	require.Equal(t, http.StatusFound, callback.Code, callback.Body.String())
+	assert.Equal(t, "/app/receipts?page=2", callback.Header().Get("Location"), "로그인 전 경로로 돌아가야 함")
+
+	session := responseCookie(callback, config.DefaultSessionCookie)
+	require.NotNil(t, session, "세션 쿠키를 설정해야 함")
+	assert.True(t, session.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, session.SameSite)
+	assert.Equal(t, "/", session.Path)
+	if state := responseCookie(callback, "gw_oidc_state"); assert.NotNil(t, state) {
+		assert.Negative(t, state.MaxAge, "로그인 요청 쿠키는 삭제해야 함")
+	}
+
+	w := withCookies(gateway, http.MethodGet, "/api/x", session, &http.Cookie{Name: "theme", Value: "dark"})
+	assert.Equal(t, http.StatusOK, w.Code, "세션 쿠키로 인증 라우트에 접근할 수 있어야 함")
+	assert.True(t, strings.HasPrefix(received().Get("Authorization"), "Bearer "), "세션의 액세스 토큰을 전달해야 함")
+	assert.Equal(t, "theme=dark", received().Get("Cookie"), "세션 쿠키는 업스트림에 전달하지 않아야 함")
+	assert.Nil(t, responseCookie(w, config.DefaultSessionCookie), "만료가 멀면 갱신하지 않아야 함")
+	assert.Equal(t, 0, idp.Calls("refresh"))
+}
+
+func TestOIDCLoginRejectsInvalidCallback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	idp := mocks.NewOIDCServer("web", "test-issuer", "test-secret")
+	t.Cleanup(idp.Close)
+	gateway, _ := newLoginReloader(t, idp)
+
+	start := get(gateway, "/auth/login")
+	require.Equal(t, http.StatusFound, start.Code)
+	stateCookie := responseCookie(start, "gw_oidc_state")
+	require.NotNil(t, stateCookie)
+
+	assert.Equal(t, http.StatusBadRequest, get(gateway, "/auth/callback?code=abc&state=x").Code, "로그인 요청 쿠키가 없으면 거부해야 함")
+	assert.Equal(t, http.StatusBadRequest, withCookies(gateway, http.MethodGet, "/auth/callback?code=abc&state=other", stateCookie).Code, "state가 다르면 거부해야 함")
+	assert.Equal(t, http.StatusUnauthorized, get(gateway, "/auth/callback?error=access_denied").Code)
+	assert.Equal(t, 0, idp.Calls("token"), "state를 확인하기 전에는 코드를 교환하지 않아야 함")
+
+	for _, redirect := range []string{"https://evil.example.com/", "//evil.example.com/", "/\\evil.example.com", "/\t/evil.example.com", "/\n/evil.example.com", "/\x7f/evil.example.com"} {
+		callback := login(t, gateway, redirect)
+		require.Equal(t, http.StatusFound, callback.Code)
+		assert.Equal(t, "/", callback.Header().Get("Location"), "다른 사이트로 리디렉션하지 않아야 함: %s", redirect)
+	}
+}
+
+func TestOIDCSessionRefresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	idp := mocks.NewOIDCServer("web", "test-issuer", "test-secret")
+	idp.AccessTokenTTL = 10 * time.Second // 갱신 기준(만료 30초 전)보다 짧음
+	t.Cleanup(idp.Close)
+	gateway, received := newLoginReloader(t, idp)
+
+	session := responseCookie(login(t, gateway, "/"), config.DefaultSessionCookie)
+	require.NotNil(t, session)
+
+	w := withCookies(gateway, http.MethodGet, "/api/x", session)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, idp.Calls("refresh"), "만료가 가까우면 갱신 토큰으로 갱신해야 함")
+	refreshed := responseCookie(w, config.DefaultSessionCookie)
+	require.NotNil(t, refreshed, "갱신한 세션 쿠키를 설정해야 함")
+	assert.NotEqual(t, session.Value, refreshed.Value)
+	firstToken := received().Get("Authorization")
+
+	// 새 쿠키를 받기 전에 보낸 요청은 교체된 갱신 토큰으로 다시 갱신하지 않고 갱신 결과를 재사용
+	w = withCookies(gateway, http.MethodGet, "/api/x", session)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, idp.Calls("refresh"))
+	assert.Equal(t, firstToken, received().Get("Authorization"))
+
+	idp.AccessTokenTTL = time.Hour
+	w = withCookies(gateway, http.MethodGet, "/api/x", refreshed)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 2, idp.Calls("refresh"), "교체된 갱신 토큰으로 갱신해야 함")
+	assert.NotEqual(t, firstToken, received().Get("Authorization"))
+}
+
+func TestOIDCLogout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	idp := mocks.NewOIDCServer("web", "test-issuer", "test-secret")
+	t.Cleanup(idp.Close)
+	gateway, received := newLoginReloader(t, idp)
+
+	session := responseCookie(login(t, gateway, "/"), config.DefaultSessionCookie)
+	require.NotNil(t, session)
+	require.Equal(t, http.StatusOK, withCookies(gateway, http.MethodGet, "/api/x", session).Code)
+	accessToken := strings.TrimPrefix(received().Get("Authorization"), "Bearer ")
+
+	w := withCookies(gateway, http.MethodPost, "/auth/logout", session)
+	require.Equal(t, http.StatusFound, w.Code)
+	location := w.Header().Get("Location")
+	assert.True(t, strings.HasPrefix(location, idp.URL()+"/logout?"), "IdP 세션도 종료해야 함: %s", location)
+	assert.Contains(t, location, "post_logout_redirect_uri="+url.QueryEscape("http://gateway.test/"))
+	if cleared := responseCookie(w, config.DefaultSessionCookie); assert.NotNil(t, cleared) {
+		assert.Negative(t, cleared.MaxAge, "세션 쿠키를 삭제해야 함")
+	}
+	assert.Equal(t, 1, idp.Calls("revoke"), "갱신 토큰을 IdP에서 폐기해야 함")
+
+	assert.Equal(t, http.StatusUnauthorized, getWithToken(gateway, "/api/x", accessToken).Code, "로그아웃한 액세스 토큰은 거부해야 함")
+	assert.Equal(t, http.StatusUnauthorized, withCookies(gateway, http.MethodGet, "/api/x", session).Code, "로그아웃한 세션 쿠키는 거부해야 함")
+}
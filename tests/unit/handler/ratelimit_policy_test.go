@@ -0,0 +1,244 @@
+// +build unit
+
+package handler_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/handler"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
+)
+
+// writeRateLimitRoutes는 속도 제한 정책을 가진 라우트 구성을 작성합니다.
+func writeRateLimitRoutes(t *testing.T, path, targetURL string, requireAuth bool, policies string) {
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/rl/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/rl","timeout":5,"requireAuth":%v,
+			"rateLimits":%s}
+	]}`, targetURL, requireAuth, policies)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// sendAs는 지정한 클라이언트 IP와 헤더로 GET 요청을 보냅니다.
+func sendAs(handler http.Handler, path, clientIP string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = clientIP + ":12345"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// allowedCount는 n번 요청 중 허용된 요청 수를 반환합니다.
+func allowedCount(t *testing.T, n int, send func() *httptest.ResponseRecorder) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		w := send()
+		if w.Code == http.StatusOK {
+			allowed++
+		} else {
+			require.Equal(t, http.StatusTooManyRequests, w.Code)
+		}
+	}
+	return allowed
+}
+
+func TestRateLimitPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("PerIP", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRateLimitRoutes(t, routesPath, backend.URL, false, `[{"key":"ip","window":60,"limit":2}]`)
+		reloader := newTestReloader(t, routesPath)
+
+		assert.Equal(t, 2, allowedCount(t, 4, func() *httptest.ResponseRecorder {
+			return sendAs(reloader, "/rl/a", "10.0.0.1", nil)
+		}))
+		assert.Equal(t, 2, allowedCount(t, 4, func() *httptest.ResponseRecorder {
+			return sendAs(reloader, "/rl/a", "10.0.0.2", nil)
+		}), "IP마다 독립된 한도를 가져야 함")
+	})
+
+	t.Run("PerHeaderWithBurst", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRateLimitRoutes(t, routesPath, backend.URL, false,
+			`[{"key":"header","header":"X-Tenant","window":60,"limit":1,"burst":3}]`)
+		reloader := newTestReloader(t, routesPath)
+
+		tenant := func(name, ip string) func() *httptest.ResponseRecorder {
+			return func() *httptest.ResponseRecorder {
+				return sendAs(reloader, "/rl/a", ip, map[string]string{"X-Tenant": name})
+			}
+		}
+		// 같은 테넌트는 IP가 달라도 한도를 공유하며 burst만큼 순간 허용
+		assert.Equal(t, 3, allowedCount(t, 2, tenant("acme", "10.0.0.1"))+allowedCount(t, 3, tenant("acme", "10.0.0.2")))
+		assert.Equal(t, 3, allowedCount(t, 5, tenant("globex", "10.0.0.1")))
+	})
+
+	t.Run("PerUserWithRoleTier", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRateLimitRoutes(t, routesPath, backend.URL, true,
+			`[{"key":"user","window":60,"limit":2,"tiers":[{"role":"admin","multiplier":10}]}]`)
+		reloader := newTestReloader(t, routesPath)
+
+		authenticator := auth.New("test-secret", "test-issuer", time.Hour)
+		userToken, err := authenticator.GenerateToken("user-1", []string{"user"})
+		require.NoError(t, err)
+		otherToken, err := authenticator.GenerateToken("user-2", []string{"user"})
+		require.NoError(t, err)
+		adminToken, err := authenticator.GenerateToken("admin-1", []string{"admin"})
+		require.NoError(t, err)
+
+		as := func(token string) func() *httptest.ResponseRecorder {
+			return func() *httptest.ResponseRecorder {
+				return sendAs(reloader, "/rl/a", "10.0.0.1", map[string]string{"Authorization": "Bearer " + token})
+			}
+		}
+		assert.Equal(t, 2, allowedCount(t, 4, as(userToken)))
+		assert.Equal(t, 2, allowedCount(t, 4, as(otherToken)), "같은 IP라도 사용자마다 독립된 한도를 가져야 함")
+		assert.Equal(t, 20, allowedCount(t, 25, as(adminToken)), "관리자 등급은 10배 한도를 가져야 함")
+	})
+
+	t.Run("PerAPIKey", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/keys/*path","targetURL":"%[1]s","methods":["GET"],"requireAuth":true,"authMode":"apiKey",
+				"rateLimits":[{"key":"apiKey","window":60,"limit":2}]},
+			{"path":"/public/*path","targetURL":"%[1]s","methods":["GET"],
+				"rateLimits":[{"key":"apiKey","window":60,"limit":2}]}
+		]}`, backend.URL)
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+		keyStore, err := auth.NewMemoryAPIKeyStore([]auth.APIKey{
+			{ID: "reports", Hash: auth.HashAPIKey("reports-key"), Consumer: "report-generator"},
+			{ID: "cron", Hash: auth.HashAPIKey("cron-key"), Consumer: "cron"},
+		})
+		require.NoError(t, err)
+		store := &keyRecordingStore{MemoryStore: ratelimiter.NewMemoryStore(time.Minute)}
+		t.Cleanup(func() { store.Close() })
+
+		reloader := newTestReloaderWith(t, routesPath, func(h *handler.RouteHandler) {
+			h.SetAPIKeyAuthenticator(auth.NewAPIKeyAuthenticator(keyStore))
+			h.SetRateLimitStore(store, ratelimiter.FailOpen)
+		}, func(cfg *config.Config) {
+			cfg.APIKeyHeader = "X-Client-Key"
+			cfg.APIKeyQueryParam = "api_key"
+		})
+
+		assert.Equal(t, 2, allowedCount(t, 4, func() *httptest.ResponseRecorder {
+			return sendAs(reloader, "/keys/a", "10.0.0.1", map[string]string{"X-Client-Key": "reports-key"})
+		}))
+		assert.Equal(t, 2, allowedCount(t, 4, func() *httptest.ResponseRecorder {
+			return sendAs(reloader, "/keys/a?api_key=cron-key", "10.0.0.1", nil)
+		}), "같은 IP라도 API 키마다 독립된 한도를 가져야 함")
+
+		i := 0
+		assert.Equal(t, 2, allowedCount(t, 4, func() *httptest.ResponseRecorder {
+			i++
+			return sendAs(reloader, "/public/a", "10.0.0.2", map[string]string{"X-API-Key": fmt.Sprintf("random-%d", i)})
+		}), "인증하지 않은 키 값을 바꿔 한도를 우회할 수 없어야 함")
+
+		require.NotEmpty(t, store.Keys())
+		for _, key := range store.Keys() {
+			assert.NotContains(t, key, "-key", "저장소 키에 API 키 원문이 없어야 함: %s", key)
+			assert.NotContains(t, key, "random-", "저장소 키에 인증하지 않은 헤더 값이 없어야 함: %s", key)
+		}
+	})
+
+	t.Run("NamedPolicySharedAcrossRoutes", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/a/*path","targetURL":"%[1]s","methods":["GET"],"stripPrefix":"/a",
+				"rateLimits":[{"name":"shared","key":"ip","window":60,"limit":3}]},
+			{"path":"/b/*path","targetURL":"%[1]s","methods":["GET"],"stripPrefix":"/b",
+				"rateLimits":[{"name":"shared","key":"ip","window":60,"limit":3}]}
+		]}`, backend.URL)
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+		reloader := newTestReloader(t, routesPath)
+
+		allowed := allowedCount(t, 2, func() *httptest.ResponseRecorder { return get(reloader, "/a/x") }) +
+			allowedCount(t, 2, func() *httptest.ResponseRecorder { return get(reloader, "/b/x") })
+		assert.Equal(t, 3, allowed, "같은 이름의 정책은 라우트 간에 한도를 공유해야 함")
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRateLimitRoutes(t, routesPath, backend.URL, false,
+			`[{"name":"per-ip","key":"ip","window":60,"limit":2},{"name":"burst","key":"ip","algorithm":"sliding-window","window":10,"limit":5}]`)
+		reloader := newTestReloader(t, routesPath)
+
+		w := get(reloader, "/rl/a")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"), "남은 요청 수가 가장 적은 정책 기준")
+		assert.Equal(t, "1", w.Header().Get("X-RateLimit-Remaining"))
+		reset, err := strconv.ParseInt(w.Header().Get("X-RateLimit-Reset"), 10, 64)
+		require.NoError(t, err)
+		assert.InDelta(t, time.Now().Add(30*time.Second).Unix(), reset, 2, "토큰 1개가 보충되는 시각")
+		assert.Equal(t, `"per-ip";r=1;t=30, "burst";r=4;t=10`, w.Header().Get("RateLimit"))
+		assert.Equal(t, `"per-ip";q=2;w=60, "burst";q=5;w=10`, w.Header().Get("RateLimit-Policy"))
+		assert.Empty(t, w.Header().Get("Retry-After"))
+
+		get(reloader, "/rl/a")
+		w = get(reloader, "/rl/a")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "0", w.Header().Get("X-RateLimit-Remaining"))
+		assert.Equal(t, "30", w.Header().Get("Retry-After"), "토큰 보충 시간으로 계산해야 함")
+		assert.Contains(t, w.Body.String(), `"retry_after":30`)
+	})
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		backend := newBackend(t, "ok")
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRateLimitRoutes(t, routesPath, backend.URL, false, `[{"key":"ip","window":60,"limit":2}]`)
+		reloader := newTestReloader(t, routesPath)
+
+		writeRateLimitRoutes(t, routesPath, backend.URL, false, `[{"key":"header","window":60,"limit":2}]`)
+		assert.Error(t, reloader.Reload(), "header 키에는 header 이름이 필요함")
+
+		writeRateLimitRoutes(t, routesPath, backend.URL, false, `[{"key":"ip","window":0,"limit":2}]`)
+		assert.Error(t, reloader.Reload(), "window는 0보다 커야 함")
+	})
+}
+
+// keyRecordingStore는 속도 제한 저장소에 사용된 키를 기록합니다.
+type keyRecordingStore struct {
+	*ratelimiter.MemoryStore
+	mu   sync.Mutex
+	keys []string
+}
+
+func (s *keyRecordingStore) TokenBucket(ctx context.Context, key string, capacity int, rate float64, n int) (ratelimiter.Result, error) {
+	s.mu.Lock()
+	s.keys = append(s.keys, key)
+	s.mu.Unlock()
+	return s.MemoryStore.TokenBucket(ctx, key, capacity, rate, n)
+}
+
+// Keys는 기록된 키를 반환합니다.
+func (s *keyRecordingStore) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.keys...)
+}
@@ -0,0 +1,205 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/config"
+	"github.com/isinthesky/api-gateway/internal/handler"
+	"github.com/isinthesky/api-gateway/pkg/cache"
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+	"github.com/isinthesky/api-gateway/pkg/loadbalancer"
+)
+
+// writeRoutes는 단일 라우트를 가진 라우트 구성 파일을 작성합니다.
+func writeRoutes(t *testing.T, path, targetURL string) {
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/svc/*path","targetURL":"%s","methods":["GET"],"stripPrefix":"/svc","requireAuth":false,"cacheable":false,"timeout":5}
+	]}`, targetURL)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// newTestReloader는 테스트용 라우트 리로더를 생성합니다. configure로 기본 설정을 변경할 수 있습니다.
+func newTestReloader(t *testing.T, routesPath string, configure ...func(*config.Config)) *handler.RouteReloader {
+	return newTestReloaderWith(t, routesPath, nil, configure...)
+}
+
+// newTestReloaderWith는 라우트 등록 전에 setup으로 핸들러를 설정하는 테스트용 라우트 리로더를 생성합니다.
+func newTestReloaderWith(t *testing.T, routesPath string, setup func(*handler.RouteHandler), configure ...func(*config.Config)) *handler.RouteReloader {
+	cfg := &config.Config{
+		AllowedOrigins:   []string{"*"},
+		RoutesConfigPath: routesPath,
+		CacheTTL:         time.Minute,
+		JWTSecret:        "test-secret",
+		JWTIssuer:        "test-issuer",
+	}
+	for _, apply := range configure {
+		apply(cfg)
+	}
+
+	cacheProvider := cache.New(time.Minute)
+	t.Cleanup(cacheProvider.Close)
+
+	routeHandler := handler.NewRouteHandler(
+		loadbalancer.NewSingle("http://localhost:1"),
+		circuitbreaker.NewRegistry(circuitbreaker.Config{}),
+		cacheProvider,
+		cfg,
+	)
+	t.Cleanup(routeHandler.Close)
+	if setup != nil {
+		setup(routeHandler)
+	}
+
+	reloader, err := handler.NewRouteReloader(routesPath, func(routes *config.RoutesConfig) (*gin.Engine, error) {
+		router := gin.New()
+		if err := routeHandler.RegisterRoutesFrom(router, routes); err != nil {
+			return nil, err
+		}
+		return router, nil
+	})
+	require.NoError(t, err)
+	t.Cleanup(reloader.Stop)
+
+	return reloader
+}
+
+// newBackend는 고정된 본문을 응답하는 테스트 백엔드를 생성합니다.
+func newBackend(t *testing.T, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(handler http.Handler, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRouteReloader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ReloadSwapsRouteTable", func(t *testing.T) {
+		backendA := newBackend(t, "backend-a")
+		backendB := newBackend(t, "backend-b")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRoutes(t, routesPath, backendA.URL)
+
+		reloader := newTestReloader(t, routesPath)
+
+		w := get(reloader, "/svc/hello")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "backend-a", w.Body.String(), "초기 라우트 테이블로 전달되어야 함")
+
+		// 구성 변경 후 리로드
+		writeRoutes(t, routesPath, backendB.URL)
+		require.NoError(t, reloader.Reload())
+
+		w = get(reloader, "/svc/hello")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "backend-b", w.Body.String(), "새 라우트 테이블로 전달되어야 함")
+	})
+
+	t.Run("InvalidFileKeepsOldRoutes", func(t *testing.T) {
+		backend := newBackend(t, "backend-ok")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRoutes(t, routesPath, backend.URL)
+
+		reloader := newTestReloader(t, routesPath)
+		oldEngine := reloader.Engine()
+
+		// 파싱할 수 없는 구성
+		require.NoError(t, os.WriteFile(routesPath, []byte(`{"routes":[`), 0644))
+		assert.Error(t, reloader.Reload(), "잘못된 구성은 거부되어야 함")
+
+		// 검증에 실패하는 구성 (메서드 없음)
+		require.NoError(t, os.WriteFile(routesPath, []byte(`{"routes":[{"path":"/svc/*path","targetURL":"http://x"}]}`), 0644))
+		assert.Error(t, reloader.Reload(), "검증 실패 구성은 거부되어야 함")
+
+		assert.Same(t, oldEngine, reloader.Engine(), "기존 라우트 테이블이 유지되어야 함")
+
+		w := get(reloader, "/svc/hello")
+		assert.Equal(t, "backend-ok", w.Body.String(), "기존 라우트가 계속 동작해야 함")
+	})
+
+	t.Run("RegistrationPanicIsRejected", func(t *testing.T) {
+		backend := newBackend(t, "backend-ok")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRoutes(t, routesPath, backend.URL)
+
+		reloader := newTestReloader(t, routesPath)
+		oldEngine := reloader.Engine()
+
+		// gin 라우트 트리에서 충돌하는 와일드카드 경로
+		content := fmt.Sprintf(`{"routes":[
+			{"path":"/svc/*path","targetURL":"%s","methods":["GET"]},
+			{"path":"/svc/:id","targetURL":"%s","methods":["GET"]}
+		]}`, backend.URL, backend.URL)
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+		assert.Error(t, reloader.Reload(), "라우트 등록 패닉은 오류로 변환되어야 함")
+		assert.Same(t, oldEngine, reloader.Engine(), "기존 라우트 테이블이 유지되어야 함")
+	})
+
+	t.Run("WatchDetectsChanges", func(t *testing.T) {
+		backendA := newBackend(t, "backend-a")
+		backendB := newBackend(t, "backend-b")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRoutes(t, routesPath, backendA.URL)
+
+		reloader := newTestReloader(t, routesPath)
+		reloader.Watch(20 * time.Millisecond)
+
+		writeRoutes(t, routesPath, backendB.URL)
+
+		assert.Eventually(t, func() bool {
+			return get(reloader, "/svc/hello").Body.String() == "backend-b"
+		}, 2*time.Second, 20*time.Millisecond, "파일 변경 시 자동으로 리로드되어야 함")
+	})
+
+	t.Run("ChangeDuringBuild", func(t *testing.T) {
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRoutes(t, routesPath, "http://backend-a")
+
+		// 첫 구성 중에 파일이 바뀌면 읽은 내용의 체크섬만 기록되어 다음 감시에서 변경을 감지해야 함
+		built := make(chan string, 10)
+		var once sync.Once
+		reloader, err := handler.NewRouteReloader(routesPath, func(routes *config.RoutesConfig) (*gin.Engine, error) {
+			once.Do(func() { writeRoutes(t, routesPath, "http://backend-b") })
+			built <- routes.Routes[0].TargetURL
+			return gin.New(), nil
+		})
+		require.NoError(t, err)
+		t.Cleanup(reloader.Stop)
+		assert.Equal(t, "http://backend-a", <-built)
+
+		reloader.Watch(20 * time.Millisecond)
+		select {
+		case target := <-built:
+			assert.Equal(t, "http://backend-b", target)
+		case <-time.After(2 * time.Second):
+			t.Fatal("구성 중에 바뀐 파일을 다시 읽어야 함")
+		}
+	})
+}
@@ -0,0 +1,194 @@
+// +build unit
+
+package handler_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRetryRoutes는 재시도 정책을 가진 라우트 구성을 작성합니다.
+func writeRetryRoutes(t *testing.T, path, targetURL, retry string) {
+	content := fmt.Sprintf(`{"routes":[
+		{"path":"/r/*path","targetURL":"%s","methods":["GET","POST"],"stripPrefix":"/r","timeout":5,
+			"retry":%s}
+	]}`, targetURL, retry)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// newFlakyBackend는 처음 failures번은 503을 응답하고 이후에는 요청 본문을 그대로 응답하는 백엔드를 생성합니다.
+func newFlakyBackend(t *testing.T, failures int32, calls *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func post(handler http.Handler, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRetryPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("RetriesOnDifferentTarget", func(t *testing.T) {
+		backend := newBackend(t, "backend-ok")
+
+		content := fmt.Sprintf(`{
+			"upstreams":[{"name":"pool","strategy":"round-robin","targets":[
+				{"url":"%s","weight":1},
+				{"url":"%s","weight":1}
+			]}],
+			"routes":[
+				{"path":"/svc/*path","upstream":"pool","targetURL":"/","methods":["GET"],"stripPrefix":"/svc","timeout":5,
+					"retry":{"attempts":2,"backoffBase":1}}
+			]
+		}`, closedBackendURL(), backend.URL)
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+		reloader := newTestReloader(t, routesPath)
+
+		retried := 0
+		for i := 0; i < 4; i++ {
+			w := get(reloader, "/svc/hello")
+			require.Equal(t, http.StatusOK, w.Code, "연결이 거부되면 다른 대상 서버로 재시도해야 함")
+			assert.Equal(t, "backend-ok", w.Body.String())
+			if w.Header().Get("X-Retry-Count") == "1" {
+				retried++
+			}
+		}
+		assert.Positive(t, retried, "닫힌 대상이 선택된 요청은 재시도되어야 함")
+	})
+
+	t.Run("RetriesConfiguredStatus", func(t *testing.T) {
+		var calls int32
+		backend := newFlakyBackend(t, 2, &calls)
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRetryRoutes(t, routesPath, backend.URL, `{"attempts":3,"backoffBase":1}`)
+		reloader := newTestReloader(t, routesPath)
+
+		w := get(reloader, "/r/hello")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-Retry-Count"))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("GivesUpAfterMaxAttempts", func(t *testing.T) {
+		var calls int32
+		backend := newFlakyBackend(t, 10, &calls)
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRetryRoutes(t, routesPath, backend.URL, `{"attempts":2,"backoffBase":1}`)
+		reloader := newTestReloader(t, routesPath)
+
+		w := get(reloader, "/r/hello")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, "마지막 시도의 응답을 전달해야 함")
+		assert.Equal(t, "1", w.Header().Get("X-Retry-Count"))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("NonIdempotentNotRetried", func(t *testing.T) {
+		var calls int32
+		backend := newFlakyBackend(t, 1, &calls)
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRetryRoutes(t, routesPath, backend.URL, `{"attempts":3,"backoffBase":1}`)
+		reloader := newTestReloader(t, routesPath)
+
+		w := post(reloader, "/r/hello", "payload")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, "POST는 기본적으로 재시도하지 않아야 함")
+		assert.Equal(t, "0", w.Header().Get("X-Retry-Count"))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("NonIdempotentOptInReplaysBody", func(t *testing.T) {
+		var calls int32
+		backend := newFlakyBackend(t, 1, &calls)
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRetryRoutes(t, routesPath, backend.URL, `{"attempts":3,"backoffBase":1,"nonIdempotent":true}`)
+		reloader := newTestReloader(t, routesPath)
+
+		w := post(reloader, "/r/hello", "payload")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "payload", w.Body.String(), "재시도 시 요청 본문이 다시 전송되어야 함")
+		assert.Equal(t, "1", w.Header().Get("X-Retry-Count"))
+	})
+
+	t.Run("LargeBodyNotRetried", func(t *testing.T) {
+		var calls int32
+		backend := newFlakyBackend(t, 1, &calls)
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRetryRoutes(t, routesPath, backend.URL, `{"attempts":3,"backoffBase":1,"nonIdempotent":true,"maxBodySize":4}`)
+		reloader := newTestReloader(t, routesPath)
+
+		w := post(reloader, "/r/hello", "payload")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code, "버퍼 한도를 넘는 본문은 재시도하지 않아야 함")
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		// 한도를 넘어 재시도하지 않더라도 본문은 온전히 전달되어야 함
+		w = post(reloader, "/r/hello", "payload")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "payload", w.Body.String())
+	})
+
+	t.Run("PerTryTimeout", func(t *testing.T) {
+		var calls int32
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				select {
+				case <-time.After(2 * time.Second):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			w.Write([]byte("fast"))
+		}))
+		t.Cleanup(backend.Close)
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRetryRoutes(t, routesPath, backend.URL, `{"attempts":2,"backoffBase":1,"perTryTimeout":100}`)
+		reloader := newTestReloader(t, routesPath)
+
+		start := time.Now()
+		w := get(reloader, "/r/hello")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "fast", w.Body.String())
+		assert.Equal(t, "1", w.Header().Get("X-Retry-Count"))
+		assert.Less(t, time.Since(start), time.Second, "시도별 타임아웃이 지나면 재시도해야 함")
+	})
+
+	t.Run("InvalidRetryConfig", func(t *testing.T) {
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRetryRoutes(t, routesPath, "http://localhost:1", `{"attempts":2}`)
+		reloader := newTestReloader(t, routesPath)
+
+		writeRetryRoutes(t, routesPath, "http://localhost:1", `{"errors":["unknown"]}`)
+		assert.Error(t, reloader.Reload(), "지원하지 않는 재시도 오류 유형은 거부되어야 함")
+	})
+}
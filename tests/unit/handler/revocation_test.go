@@ -0,0 +1,144 @@
+// +build unit
+
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/auth"
+	"github.com/isinthesky/api-gateway/internal/handler"
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
+)
+
+// revoke는 관리자 토큰으로 토큰 폐기 API를 호출합니다.
+func revoke(handler http.Handler, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/admin/revocations", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestTokenRevocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	backend := newBackend(t, "ok")
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[{"path":"/api/*path","targetURL":"%s","methods":["GET"],"requireAuth":true}]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+	reloader := newTestReloader(t, routesPath)
+	adminToken := signClaims(t, jwt.MapClaims{"sub": "admin-1", "jti": "admin-token", "roles": []string{"admin"}})
+
+	t.Run("RequiresAdminRole", func(t *testing.T) {
+		userToken := signClaims(t, jwt.MapClaims{"jti": "user-token", "roles": []string{"user"}})
+		assert.Equal(t, http.StatusForbidden, revoke(reloader, userToken, `{"jti":"admin-token"}`).Code)
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/api/x", userToken).Code)
+	})
+
+	t.Run("RevokeByJTI", func(t *testing.T) {
+		token := signClaims(t, jwt.MapClaims{"jti": "token-1"})
+		other := signClaims(t, jwt.MapClaims{"jti": "token-2"})
+		require.Equal(t, http.StatusOK, getWithToken(reloader, "/api/x", token).Code)
+
+		w := revoke(reloader, adminToken, `{"jti":"token-1"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/api/x", token).Code)
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/api/x", other).Code, "다른 토큰에는 영향이 없어야 함")
+	})
+
+	t.Run("RevokeByTokenExpiresWithToken", func(t *testing.T) {
+		expiresAt := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+		token := signClaims(t, jwt.MapClaims{"exp": expiresAt.Unix()})
+
+		w := revoke(reloader, adminToken, fmt.Sprintf(`{"token":%q}`, token))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body struct {
+			Revoked map[string]string `json:"revoked"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.True(t, strings.HasPrefix(body.Revoked["jti"], "sha256:"), "jti가 없는 토큰은 토큰 해시로 폐기해야 함")
+		assert.Equal(t, expiresAt.Format(time.RFC3339), body.Revoked["expiresAt"], "폐기 항목은 토큰의 exp에 만료되어야 함")
+
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/api/x", token).Code)
+
+		// 쿠키로 전달한 토큰도 거부
+		w = sendWith(reloader, http.MethodGet, "/admin/circuit-breakers", map[string]string{"Cookie": "access_token=" + token})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("RevokeSubject", func(t *testing.T) {
+		before := signClaims(t, jwt.MapClaims{"sub": "user-2", "jti": "laptop", "iat": time.Now().Add(-time.Minute).Unix()})
+		w := revoke(reloader, adminToken, `{"subject":"user-2"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		after := signClaims(t, jwt.MapClaims{"sub": "user-2", "jti": "phone", "iat": time.Now().Unix()})
+		assert.Equal(t, http.StatusUnauthorized, getWithToken(reloader, "/api/x", before).Code, "폐기 전에 발급된 토큰은 거부해야 함")
+		assert.Equal(t, http.StatusOK, getWithToken(reloader, "/api/x", after).Code, "폐기 후 다시 로그인한 토큰은 허용해야 함")
+	})
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"Empty":           `{}`,
+			"MultipleTargets": `{"jti":"a","subject":"b"}`,
+			"InvalidToken":    `{"token":"not-a-jwt"}`,
+			"PastExpiresAt":   `{"jti":"a","expiresAt":"2000-01-01T00:00:00Z"}`,
+			"Malformed":       `{`,
+		} {
+			assert.Equal(t, http.StatusBadRequest, revoke(reloader, adminToken, body).Code, name)
+		}
+	})
+}
+
+// failingRevocationStore는 항상 실패하는 폐기 목록 저장소입니다.
+type failingRevocationStore struct{}
+
+var _ auth.RevocationStore = failingRevocationStore{}
+
+func (failingRevocationStore) Revoke(ctx context.Context, key string, revokedAt, expiresAt time.Time) error {
+	return errors.New("저장소 장애")
+}
+
+func (failingRevocationStore) RevokedAt(ctx context.Context, key string) (time.Time, error) {
+	return time.Time{}, errors.New("저장소 장애")
+}
+
+func (failingRevocationStore) Close() error { return nil }
+
+func TestTokenRevocationStoreFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	backend := newBackend(t, "ok")
+	routesPath := filepath.Join(t.TempDir(), "routes.json")
+	content := fmt.Sprintf(`{"routes":[{"path":"/api/*path","targetURL":"%s","methods":["GET"],"requireAuth":true}]}`, backend.URL)
+	require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+	token := signClaims(t, jwt.MapClaims{"jti": "token-1"})
+
+	for mode, expected := range map[ratelimiter.FailureMode]int{
+		ratelimiter.FailOpen:   http.StatusOK,
+		ratelimiter.FailClosed: http.StatusServiceUnavailable,
+	} {
+		reloader := newTestReloaderWith(t, routesPath, func(h *handler.RouteHandler) {
+			h.SetRevocationStore(failingRevocationStore{}, mode)
+		})
+		assert.Equal(t, expected, getWithToken(reloader, "/api/x", token).Code, string(mode))
+	}
+}
@@ -0,0 +1,128 @@
+// +build unit
+
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeUpstreamRoutes는 두 대상 서버를 가진 업스트림 라우트 구성을 작성합니다.
+func writeUpstreamRoutes(t *testing.T, path, strategy string, targets ...string) {
+	content := fmt.Sprintf(`{
+		"upstreams":[{"name":"pool","strategy":"%s","targets":[
+			{"url":"%s","weight":1},
+			{"url":"%s","weight":1}
+		]}],
+		"routes":[
+			{"path":"/svc/*path","upstream":"pool","targetURL":"/","methods":["GET"],"stripPrefix":"/svc","timeout":5}
+		]
+	}`, strategy, targets[0], targets[1])
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestUpstreamRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("RoundRobinDistributes", func(t *testing.T) {
+		backendA := newBackend(t, "backend-a")
+		backendB := newBackend(t, "backend-b")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeUpstreamRoutes(t, routesPath, "round-robin", backendA.URL, backendB.URL)
+
+		reloader := newTestReloader(t, routesPath)
+
+		seen := make(map[string]int)
+		for i := 0; i < 4; i++ {
+			seen[get(reloader, "/svc/hello").Body.String()]++
+		}
+
+		assert.Equal(t, 2, seen["backend-a"], "요청이 두 대상에 고르게 분산되어야 함")
+		assert.Equal(t, 2, seen["backend-b"], "요청이 두 대상에 고르게 분산되어야 함")
+	})
+
+	t.Run("LeastConnectionReleasesConns", func(t *testing.T) {
+		backendA := newBackend(t, "backend-a")
+		backendB := newBackend(t, "backend-b")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeUpstreamRoutes(t, routesPath, "least-connection", backendA.URL, backendB.URL)
+
+		reloader := newTestReloader(t, routesPath)
+
+		// 응답이 끝날 때마다 연결이 반환되므로 순차 요청은 항상 첫 번째 대상으로 전달됨
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, "backend-a", get(reloader, "/svc/hello").Body.String(), "응답 완료 후 활성 연결 수가 반환되어야 함")
+		}
+	})
+
+	t.Run("UnknownUpstreamRejected", func(t *testing.T) {
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		writeRoutes(t, routesPath, "http://localhost:1")
+		reloader := newTestReloader(t, routesPath)
+
+		content := `{"routes":[{"path":"/svc/*path","upstream":"missing","methods":["GET"]}]}`
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+		assert.Error(t, reloader.Reload(), "정의되지 않은 업스트림 참조는 거부되어야 함")
+	})
+	t.Run("PassiveHealthEjectsFailingTarget", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(failing.Close)
+		backend := newBackend(t, "backend-ok")
+
+		routesPath := filepath.Join(t.TempDir(), "routes.json")
+		content := fmt.Sprintf(`{
+			"upstreams":[{"name":"pool","strategy":"round-robin",
+				"passiveHealth":{"maxFailures":2,"cooldown":60},
+				"targets":[{"url":"%s"},{"url":"%s"}]}],
+			"routes":[
+				{"path":"/svc/*path","upstream":"pool","targetURL":"/","methods":["GET"],"stripPrefix":"/svc","timeout":5}
+			]
+		}`, failing.URL, backend.URL)
+		require.NoError(t, os.WriteFile(routesPath, []byte(content), 0644))
+
+		reloader := newTestReloader(t, routesPath)
+
+		// 라운드 로빈으로 실패 대상에 두 번 전달되면 제외됨
+		for i := 0; i < 4; i++ {
+			get(reloader, "/svc/hello")
+		}
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, "backend-ok", get(reloader, "/svc/hello").Body.String(), "제외된 대상으로 전달되지 않아야 함")
+		}
+
+		w := get(reloader, "/health")
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var body struct {
+			Status    string `json:"status"`
+			Upstreams map[string][]struct {
+				URL     string `json:"url"`
+				Healthy bool   `json:"healthy"`
+				Ejected bool   `json:"ejected"`
+			} `json:"upstreams"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		targets := body.Upstreams["pool"]
+		require.Len(t, targets, 2, "업스트림 대상 상태가 /health에 포함되어야 함")
+		assert.Equal(t, failing.URL, targets[0].URL)
+		assert.True(t, targets[0].Ejected, "실패 대상은 제외 상태로 보고되어야 함")
+		assert.True(t, targets[1].Healthy)
+		assert.Equal(t, "ok", body.Status, "정상 대상이 남아 있으면 ok여야 함")
+	})
+}
@@ -196,7 +196,6 @@ func TestCircuitBreakerCustomConfig(t *testing.T) {
 	})
 
 	// 초기 설정 확인
-	metrics := cb.GetMetrics()
 	assert.Equal(t, float64(0.3), cb.Config().ErrorThreshold, "오류 임계값이 정확해야 함")
 	assert.Equal(t, 10, cb.Config().MinRequests, "최소 요청 수가 정확해야 함")
 	assert.Equal(t, 2*time.Second, cb.Config().TimeoutDuration, "타임아웃이 정확해야 함")
@@ -0,0 +1,192 @@
+// +build unit
+
+package circuitbreaker_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+	"github.com/isinthesky/api-gateway/tests/mocks"
+)
+
+// callFunc는 장애 서버의 경로를 호출하는 서킷 브레이커 실행 함수를 반환합니다.
+func callFunc(ctx context.Context, url string) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+		return resp, nil
+	}
+}
+
+// classifierConfig는 2회 요청 후 오류율 50% 이상이면 열리는 설정에 실패 분류를 더합니다.
+func classifierConfig(apply func(*circuitbreaker.Config)) circuitbreaker.Config {
+	config := circuitbreaker.Config{
+		ErrorThreshold:   0.5,
+		MinRequests:      2,
+		TimeoutDuration:  time.Minute,
+		HalfOpenMaxReqs:  1,
+		SuccessThreshold: 1,
+	}
+	apply(&config)
+	return config
+}
+
+func TestFailureClassifier(t *testing.T) {
+	fs := mocks.NewFaultServer()
+	defer fs.Close()
+
+	ctx := context.Background()
+
+	t.Run("StatusCodes", func(t *testing.T) {
+		cb := circuitbreaker.New(classifierConfig(func(c *circuitbreaker.Config) {
+			c.FailureStatusCodes = []int{500, 502, 503}
+		}))
+
+		// 5xx 응답은 오류 없이 반환되지만 실패로 기록됨
+		for i := 0; i < 2; i++ {
+			result, err := cb.Execute(callFunc(ctx, fs.URL()+"/api/status/503"))
+			require.NoError(t, err, "응답 상태 코드 분류는 호출 결과를 바꾸지 않아야 함")
+			assert.Equal(t, http.StatusServiceUnavailable, result.(*http.Response).StatusCode)
+		}
+
+		assert.Equal(t, "open", cb.GetState(), "실패 상태 코드가 반복되면 서킷이 열려야 함")
+
+		_, err := cb.Execute(callFunc(ctx, fs.URL()+"/api/status/200"))
+		assert.ErrorIs(t, err, circuitbreaker.ErrCircuitOpen)
+	})
+
+	t.Run("UnlistedStatusIsSuccess", func(t *testing.T) {
+		cb := circuitbreaker.New(classifierConfig(func(c *circuitbreaker.Config) {
+			c.FailureStatusCodes = []int{503}
+		}))
+
+		for i := 0; i < 3; i++ {
+			_, err := cb.Execute(callFunc(ctx, fs.URL()+"/api/status/404"))
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, "closed", cb.GetState(), "지정되지 않은 상태 코드는 성공으로 기록되어야 함")
+		assert.Equal(t, int64(3), cb.GetMetrics()["success_count"])
+	})
+
+	t.Run("SlowCall", func(t *testing.T) {
+		cb := circuitbreaker.New(classifierConfig(func(c *circuitbreaker.Config) {
+			c.SlowCallDuration = 50 * time.Millisecond
+		}))
+
+		_, err := cb.Execute(callFunc(ctx, fs.URL()+"/api/delay/0"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), cb.GetMetrics()["success_count"], "빠른 응답은 성공으로 기록되어야 함")
+
+		_, err = cb.Execute(callFunc(ctx, fs.URL()+"/api/delay/100"))
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), cb.GetMetrics()["failure_count"], "지연 임계값을 넘은 응답은 실패로 기록되어야 함")
+		assert.Equal(t, "open", cb.GetState(), "오류율 50%에 도달하면 서킷이 열려야 함")
+	})
+
+	t.Run("ErrorTypes", func(t *testing.T) {
+		cb := circuitbreaker.New(classifierConfig(func(c *circuitbreaker.Config) {
+			c.FailureErrorTypes = []string{circuitbreaker.ErrorTypeConnection}
+		}))
+
+		// 호출자 취소는 실패로 기록되지 않음
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		for i := 0; i < 3; i++ {
+			_, err := cb.Execute(callFunc(canceled, fs.URL()+"/api/status/200"))
+			require.Error(t, err)
+		}
+		assert.Equal(t, "closed", cb.GetState(), "지정되지 않은 오류 유형은 기록되지 않아야 함")
+		assert.Equal(t, int64(0), cb.GetMetrics()["total_requests"])
+
+		// 연결 종료는 실패로 기록됨
+		for i := 0; i < 2; i++ {
+			_, err := cb.Execute(callFunc(ctx, fs.URL()+"/api/connection-reset"))
+			require.Error(t, err)
+		}
+		assert.Equal(t, "open", cb.GetState(), "지정된 오류 유형은 실패로 기록되어야 함")
+	})
+
+	t.Run("IgnoredInHalfOpen", func(t *testing.T) {
+		cb := circuitbreaker.New(classifierConfig(func(c *circuitbreaker.Config) {
+			c.FailureErrorTypes = []string{circuitbreaker.ErrorTypeConnection}
+			c.TimeoutDuration = 50 * time.Millisecond
+		}))
+
+		for i := 0; i < 2; i++ {
+			_, _ = cb.Execute(callFunc(ctx, fs.URL()+"/api/connection-reset"))
+		}
+		require.Equal(t, "open", cb.GetState())
+		time.Sleep(60 * time.Millisecond)
+
+		// 기록되지 않는 결과는 반열림 상태의 시험 요청 슬롯을 차지하지 않아야 함
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		for i := 0; i < 3; i++ {
+			_, err := cb.Execute(callFunc(canceled, fs.URL()+"/api/status/200"))
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+		assert.Equal(t, "half-open", cb.GetState())
+
+		_, err := cb.Execute(callFunc(ctx, fs.URL()+"/api/status/200"))
+		require.NoError(t, err, "취소된 요청 이후에도 시험 요청을 허용해야 함")
+		assert.Equal(t, "closed", cb.GetState())
+	})
+
+	t.Run("DefaultCountsAllErrors", func(t *testing.T) {
+		cb := circuitbreaker.New(classifierConfig(func(c *circuitbreaker.Config) {}))
+
+		for i := 0; i < 2; i++ {
+			_, _ = cb.Execute(failureFunc)
+		}
+		assert.Equal(t, "open", cb.GetState(), "오류 유형을 지정하지 않으면 모든 오류가 실패여야 함")
+	})
+}
+
+func TestErrorType(t *testing.T) {
+	fs := mocks.NewFaultServer()
+	defer fs.Close()
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, timeoutErr := callFunc(timeoutCtx, fs.URL()+"/api/delay/500")()
+	_, resetErr := callFunc(context.Background(), fs.URL()+"/api/connection-reset")()
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Deadline", timeoutErr, circuitbreaker.ErrorTypeTimeout},
+		{"WrappedCanceled", fmt.Errorf("프록시 요청 실패: %w", context.Canceled), circuitbreaker.ErrorTypeCanceled},
+		{"ConnectionReset", resetErr, circuitbreaker.ErrorTypeConnection},
+		{"Other", errors.New("unknown"), circuitbreaker.ErrorTypeOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, tt.err)
+			assert.Equal(t, tt.want, circuitbreaker.ErrorType(tt.err))
+		})
+	}
+
+	assert.True(t, circuitbreaker.IsValidErrorType("timeout"))
+	assert.False(t, circuitbreaker.IsValidErrorType("5xx"))
+}
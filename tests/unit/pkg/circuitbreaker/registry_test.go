@@ -0,0 +1,74 @@
+// +build unit
+
+package circuitbreaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+)
+
+func TestRegistry(t *testing.T) {
+	config := circuitbreaker.Config{
+		ErrorThreshold:   0.5,
+		MinRequests:      2,
+		TimeoutDuration:  time.Minute,
+		HalfOpenMaxReqs:  1,
+		SuccessThreshold: 1,
+	}
+
+	t.Run("IsolatedByKey", func(t *testing.T) {
+		registry := circuitbreaker.NewRegistry(config)
+
+		failing := registry.Get("route:a", config)
+		healthy := registry.Get("route:b", config)
+
+		for i := 0; i < 2; i++ {
+			_, _ = failing.Execute(failureFunc)
+		}
+
+		assert.Equal(t, "open", failing.GetState(), "실패한 키의 서킷은 열려야 함")
+		assert.Equal(t, "closed", healthy.GetState(), "다른 키의 서킷은 영향을 받지 않아야 함")
+		assert.Same(t, failing, registry.Get("route:a", config), "같은 키와 설정은 같은 서킷 브레이커를 반환해야 함")
+	})
+
+	t.Run("ConfigChangeReplacesBreaker", func(t *testing.T) {
+		registry := circuitbreaker.NewRegistry(config)
+
+		old := registry.Get("route:a", config)
+		for i := 0; i < 2; i++ {
+			_, _ = old.Execute(failureFunc)
+		}
+
+		changed := config
+		changed.MinRequests = 10
+		replaced := registry.Get("route:a", changed)
+
+		assert.NotSame(t, old, replaced, "설정이 바뀌면 새 서킷 브레이커를 생성해야 함")
+		assert.Equal(t, "closed", replaced.GetState())
+		assert.Equal(t, 10, replaced.Config().MinRequests)
+	})
+
+	t.Run("RetainAndKeys", func(t *testing.T) {
+		registry := circuitbreaker.NewRegistry(config)
+		registry.Get("route:b", config)
+		registry.Get("route:a", config)
+		registry.Get("target:http://x", config)
+
+		assert.Equal(t, []string{"route:a", "route:b", "target:http://x"}, registry.Keys(), "키는 정렬되어 반환되어야 함")
+
+		registry.Retain(func(key string) bool { return key != "route:b" })
+
+		assert.Equal(t, []string{"route:a", "target:http://x"}, registry.Keys())
+		_, ok := registry.Lookup("route:b")
+		assert.False(t, ok, "제거된 키는 조회되지 않아야 함")
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		registry := circuitbreaker.NewRegistry(config)
+		assert.Equal(t, config, registry.Defaults())
+	})
+}
@@ -0,0 +1,118 @@
+// +build unit
+
+package circuitbreaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/isinthesky/api-gateway/pkg/circuitbreaker"
+)
+
+// slowSuccessFunc는 지정된 시간 후 성공하는 함수를 반환합니다.
+func slowSuccessFunc(d time.Duration) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		time.Sleep(d)
+		return "success", nil
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	t.Run("CountWindowForgetsOldCalls", func(t *testing.T) {
+		cb := circuitbreaker.New(circuitbreaker.Config{
+			ErrorThreshold:  0.5,
+			MinRequests:     5,
+			TimeoutDuration: time.Minute,
+			WindowType:      circuitbreaker.WindowTypeCount,
+			WindowSize:      10,
+		})
+
+		// 오랜 정상 기간
+		for i := 0; i < 100; i++ {
+			_, _ = cb.Execute(successFunc)
+		}
+		metrics := cb.GetMetrics()
+		assert.Equal(t, int64(10), metrics["total_requests"], "최근 N개 호출만 집계되어야 함")
+		assert.Equal(t, "count", metrics["window_type"])
+
+		// 장애 발생: 누적 카운터였다면 5/105로 열리지 않음
+		for i := 0; i < 4; i++ {
+			_, _ = cb.Execute(failureFunc)
+		}
+		assert.Equal(t, "closed", cb.GetState(), "윈도우 내 오류율 40%에서는 닫힘 유지")
+
+		_, _ = cb.Execute(failureFunc)
+		assert.Equal(t, "open", cb.GetState(), "윈도우 내 오류율 50%에서 열려야 함")
+		assert.Equal(t, 0.5, cb.GetMetrics()["error_rate"])
+	})
+
+	t.Run("TimeWindowExpiresBuckets", func(t *testing.T) {
+		cb := circuitbreaker.New(circuitbreaker.Config{
+			ErrorThreshold:  0.5,
+			MinRequests:     4,
+			TimeoutDuration: time.Minute,
+			WindowType:      circuitbreaker.WindowTypeTime,
+			WindowDuration:  200 * time.Millisecond,
+			WindowBuckets:   4,
+		})
+
+		for i := 0; i < 3; i++ {
+			_, _ = cb.Execute(failureFunc)
+		}
+		assert.Equal(t, int64(3), cb.GetMetrics()["failure_count"])
+
+		// 윈도우 길이가 지나면 이전 버킷은 집계에서 제외됨
+		time.Sleep(300 * time.Millisecond)
+		assert.Equal(t, int64(0), cb.GetMetrics()["total_requests"], "만료된 버킷은 집계되지 않아야 함")
+
+		// 이전 실패가 만료되었으므로 1번 실패로는 열리지 않음
+		for i := 0; i < 3; i++ {
+			_, _ = cb.Execute(successFunc)
+		}
+		_, _ = cb.Execute(failureFunc)
+		assert.Equal(t, "closed", cb.GetState(), "만료된 실패는 오류율에 포함되지 않아야 함")
+		assert.Equal(t, 0.25, cb.GetMetrics()["error_rate"])
+	})
+
+	t.Run("SlowCallRate", func(t *testing.T) {
+		cb := circuitbreaker.New(circuitbreaker.Config{
+			ErrorThreshold:        0.5,
+			MinRequests:           4,
+			TimeoutDuration:       time.Minute,
+			SlowCallDuration:      20 * time.Millisecond,
+			SlowCallRateThreshold: 0.75,
+		})
+
+		_, _ = cb.Execute(successFunc)
+		for i := 0; i < 2; i++ {
+			_, _ = cb.Execute(slowSuccessFunc(30 * time.Millisecond))
+		}
+
+		metrics := cb.GetMetrics()
+		assert.Equal(t, int64(2), metrics["slow_call_count"])
+		assert.Equal(t, int64(0), metrics["failure_count"], "지연 호출 비율 임계값이 있으면 지연 호출은 실패가 아님")
+		assert.Equal(t, "closed", cb.GetState())
+
+		_, _ = cb.Execute(slowSuccessFunc(30 * time.Millisecond))
+		assert.Equal(t, "open", cb.GetState(), "지연 호출 비율이 임계값에 도달하면 열려야 함")
+		assert.Equal(t, 0.75, cb.GetMetrics()["slow_call_rate"])
+		assert.Contains(t, cb.FormatState(), "slow call rate: 75.00%")
+	})
+
+	t.Run("SetWindow", func(t *testing.T) {
+		var config circuitbreaker.Config
+
+		config.SetWindow(circuitbreaker.WindowTypeTime, 30)
+		assert.Equal(t, circuitbreaker.WindowTypeTime, config.WindowType)
+		assert.Equal(t, 30*time.Second, config.WindowDuration, "time 윈도우 크기는 초 단위여야 함")
+
+		config.SetWindow(circuitbreaker.WindowTypeCount, 50)
+		assert.Equal(t, 50, config.WindowSize, "count 윈도우 크기는 호출 수여야 함")
+
+		cb := circuitbreaker.New(circuitbreaker.Config{})
+		assert.Equal(t, circuitbreaker.WindowTypeCount, cb.Config().WindowType, "기본 윈도우는 count여야 함")
+		assert.Equal(t, 100, cb.Config().WindowSize)
+	})
+}
@@ -0,0 +1,164 @@
+// +build unit
+
+package concurrency_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/pkg/concurrency"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("FixedLimitRejectsWithoutQueue", func(t *testing.T) {
+		limiter := concurrency.New(concurrency.Config{Limit: 2})
+
+		first, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+		_, err = limiter.Acquire(ctx)
+		require.NoError(t, err)
+
+		_, err = limiter.Acquire(ctx)
+		assert.ErrorIs(t, err, concurrency.ErrLimitExceeded, "대기열이 없으면 바로 거부해야 함")
+
+		first.Release(concurrency.OutcomeSuccess)
+		_, err = limiter.Acquire(ctx)
+		assert.NoError(t, err, "슬롯이 반환되면 획득할 수 있어야 함")
+		assert.Equal(t, concurrency.Stats{Limit: 2, InFlight: 2}, limiter.Stats())
+	})
+
+	t.Run("QueueGrantsInOrder", func(t *testing.T) {
+		limiter := concurrency.New(concurrency.Config{Limit: 1, QueueSize: 2, QueueTimeout: time.Second})
+
+		token, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		var order []int
+		var wg sync.WaitGroup
+		for i := 1; i <= 2; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				waiting, err := limiter.Acquire(ctx)
+				if !assert.NoError(t, err) {
+					return
+				}
+				mu.Lock()
+				order = append(order, i)
+				mu.Unlock()
+				waiting.Release(concurrency.OutcomeSuccess)
+			}(i)
+			// 대기열 진입 순서 보장
+			require.Eventually(t, func() bool { return limiter.Stats().Queued == i }, time.Second, time.Millisecond)
+		}
+
+		_, err = limiter.Acquire(ctx)
+		assert.ErrorIs(t, err, concurrency.ErrLimitExceeded, "대기열이 가득 차면 거부해야 함")
+
+		token.Release(concurrency.OutcomeSuccess)
+		wg.Wait()
+		assert.Equal(t, []int{1, 2}, order, "대기한 순서대로 슬롯을 할당해야 함")
+		assert.Equal(t, 0, limiter.Stats().InFlight)
+	})
+
+	t.Run("QueueTimeout", func(t *testing.T) {
+		limiter := concurrency.New(concurrency.Config{Limit: 1, QueueSize: 1, QueueTimeout: 30 * time.Millisecond})
+		_, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = limiter.Acquire(ctx)
+		assert.ErrorIs(t, err, concurrency.ErrQueueTimeout)
+		assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+		assert.Equal(t, concurrency.Stats{Limit: 1, InFlight: 1}, limiter.Stats(), "시간이 지난 요청은 대기열에서 제거되어야 함")
+	})
+
+	t.Run("ContextCanceled", func(t *testing.T) {
+		limiter := concurrency.New(concurrency.Config{Limit: 1, QueueSize: 1, QueueTimeout: time.Second})
+		_, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err = limiter.Acquire(canceled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("AIMDBacksOffOnSlowCalls", func(t *testing.T) {
+		limiter := concurrency.New(concurrency.Config{
+			Mode: concurrency.ModeAIMD, Limit: 10, MinLimit: 2, BackoffRatio: 0.5, LatencyThreshold: 10 * time.Millisecond,
+		})
+
+		token, err := limiter.Acquire(ctx)
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		token.Release(concurrency.OutcomeSuccess)
+		assert.Equal(t, 5, limiter.Stats().Limit, "느린 요청은 한도를 줄여야 함")
+
+		for i := 0; i < 3; i++ {
+			token, err = limiter.Acquire(ctx)
+			require.NoError(t, err)
+			token.Release(concurrency.OutcomeDropped)
+		}
+		assert.Equal(t, 2, limiter.Stats().Limit, "최소 한도 아래로 줄지 않아야 함")
+
+		// 한도를 절반 이상 사용 중일 때 빠른 응답은 한도를 늘림
+		tokens := make([]*concurrency.Token, 2)
+		for i := range tokens {
+			tokens[i], err = limiter.Acquire(ctx)
+			require.NoError(t, err)
+		}
+		for _, token := range tokens {
+			token.Release(concurrency.OutcomeSuccess)
+		}
+		assert.Equal(t, 4, limiter.Stats().Limit)
+	})
+
+	t.Run("GradientReducesLimitWhenLatencyRises", func(t *testing.T) {
+		limiter := concurrency.New(concurrency.Config{Mode: concurrency.ModeGradient, Limit: 20, Tolerance: 1})
+
+		run := func(latency time.Duration, n int) {
+			tokens := make([]*concurrency.Token, n)
+			for i := range tokens {
+				token, err := limiter.Acquire(ctx)
+				require.NoError(t, err)
+				tokens[i] = token
+			}
+			time.Sleep(latency)
+			for _, token := range tokens {
+				token.Release(concurrency.OutcomeSuccess)
+			}
+		}
+
+		// 평균 지연 시간 학습
+		for i := 0; i < 5; i++ {
+			run(time.Millisecond, 10)
+		}
+		before := limiter.Stats().Limit
+
+		// 지연 시간이 크게 늘어나면 한도 감소
+		run(50*time.Millisecond, before)
+		assert.Less(t, limiter.Stats().Limit, before, "지연 시간이 늘어나면 한도를 줄여야 함")
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	registry := concurrency.NewRegistry()
+
+	cfg := concurrency.Config{Limit: 5}
+	first := registry.Get("route:a", cfg)
+	assert.Same(t, first, registry.Get("route:a", cfg), "설정이 같으면 같은 제한기를 사용해야 함")
+	assert.NotSame(t, first, registry.Get("route:a", concurrency.Config{Limit: 6}), "설정이 바뀌면 새 제한기를 생성해야 함")
+
+	registry.Get("route:b", cfg)
+	registry.Retain(func(key string) bool { return key == "route:b" })
+	assert.Equal(t, []string{"route:b"}, registry.Keys())
+}
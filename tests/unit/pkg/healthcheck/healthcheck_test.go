@@ -0,0 +1,183 @@
+// +build unit
+
+package healthcheck_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/pkg/healthcheck"
+	"github.com/isinthesky/api-gateway/pkg/loadbalancer"
+)
+
+// newBalancer는 주어진 대상들로 라운드 로빈 로드 밸런서를 생성합니다.
+func newBalancer(urls ...string) loadbalancer.LoadBalancer {
+	return loadbalancer.NewRoundRobin(urls)
+}
+
+// isHealthy는 로드 밸런서에서 대상 서버의 정상 여부를 반환합니다.
+func isHealthy(lb loadbalancer.LoadBalancer, url string) bool {
+	for _, target := range lb.GetTargets() {
+		if target.URL == url {
+			return target.Healthy
+		}
+	}
+	return false
+}
+
+func TestActiveHealthCheck(t *testing.T) {
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/healthz", r.URL.Path, "설정한 헬스 체크 경로로 요청해야 함")
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	lb := newBalancer(server.URL)
+	checker := healthcheck.New()
+	defer checker.Stop()
+
+	checker.SetGroups([]healthcheck.Group{{
+		Name:      "svc",
+		Targets:   []string{server.URL},
+		Balancers: []loadbalancer.LoadBalancer{lb},
+		Active: &healthcheck.Config{
+			Path:               "/healthz",
+			Interval:           time.Hour, // 주기 검사는 사용하지 않고 CheckNow로 검사
+			Timeout:            time.Second,
+			HealthyThreshold:   2,
+			UnhealthyThreshold: 2,
+		},
+	}})
+
+	t.Run("FallAfterThreshold", func(t *testing.T) {
+		status.Store(http.StatusServiceUnavailable)
+
+		checker.CheckNow()
+		assert.True(t, isHealthy(lb, server.URL), "실패 1회로는 비정상 전환되지 않아야 함")
+
+		checker.CheckNow()
+		assert.False(t, isHealthy(lb, server.URL), "연속 실패가 기준에 도달하면 비정상 전환되어야 함")
+
+		_, err := lb.NextTarget()
+		assert.ErrorIs(t, err, loadbalancer.ErrNoAvailableTargets, "비정상 대상은 선택되지 않아야 함")
+
+		statuses := checker.Status()["svc"]
+		require.Len(t, statuses, 1)
+		assert.False(t, statuses[0].Healthy)
+		assert.NotEmpty(t, statuses[0].LastError, "실패 사유가 기록되어야 함")
+	})
+
+	t.Run("RiseAfterThreshold", func(t *testing.T) {
+		status.Store(http.StatusOK)
+
+		checker.CheckNow()
+		assert.False(t, isHealthy(lb, server.URL), "성공 1회로는 정상 전환되지 않아야 함")
+
+		checker.CheckNow()
+		assert.True(t, isHealthy(lb, server.URL), "연속 성공이 기준에 도달하면 정상 전환되어야 함")
+		assert.True(t, checker.Status()["svc"][0].Healthy)
+	})
+}
+
+func TestPassiveHealthCheck(t *testing.T) {
+	const (
+		target1 = "http://server1.example.com"
+		target2 = "http://server2.example.com"
+	)
+
+	lb := newBalancer(target1, target2)
+	checker := healthcheck.New()
+	defer checker.Stop()
+
+	checker.SetGroups([]healthcheck.Group{{
+		Name:      "svc",
+		Targets:   []string{target1, target2},
+		Balancers: []loadbalancer.LoadBalancer{lb},
+		Passive: &healthcheck.PassiveConfig{
+			MaxFailures: 3,
+			Cooldown:    100 * time.Millisecond,
+		},
+	}})
+
+	t.Run("SuccessResetsFailures", func(t *testing.T) {
+		checker.ReportResult("svc", target1, false)
+		checker.ReportResult("svc", target1, false)
+		checker.ReportResult("svc", target1, true)
+		checker.ReportResult("svc", target1, false)
+
+		assert.True(t, isHealthy(lb, target1), "성공 응답은 연속 실패 횟수를 초기화해야 함")
+	})
+
+	t.Run("EjectAndRestore", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			checker.ReportResult("svc", target1, false)
+		}
+
+		assert.False(t, isHealthy(lb, target1), "연속 실패 시 대상이 제외되어야 함")
+		assert.True(t, isHealthy(lb, target2), "다른 대상은 영향을 받지 않아야 함")
+
+		statuses := checker.Status()["svc"]
+		require.Len(t, statuses, 2)
+		assert.True(t, statuses[0].Ejected)
+		assert.False(t, statuses[0].EjectedUntil.IsZero(), "제외 만료 시각이 보고되어야 함")
+
+		for i := 0; i < 4; i++ {
+			target, err := lb.NextTarget()
+			require.NoError(t, err)
+			assert.Equal(t, target2, target, "제외된 대상은 선택되지 않아야 함")
+		}
+
+		// 복귀는 타이머 고루틴에서 일어나므로 잠금을 사용하는 NextTarget으로 확인
+		assert.Eventually(t, func() bool {
+			target, err := lb.NextTarget()
+			return err == nil && target == target1
+		}, 2*time.Second, 10*time.Millisecond, "쿨다운 후 대상이 복귀해야 함")
+		assert.False(t, checker.Status()["svc"][0].Ejected)
+	})
+
+	t.Run("UnknownGroupIgnored", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			checker.ReportResult("unknown", target1, false)
+			checker.ReportResult("svc", "http://unknown.example.com", false)
+		})
+	})
+}
+
+func TestSetGroupsPreservesState(t *testing.T) {
+	const target = "http://server1.example.com"
+
+	spec := func(lb loadbalancer.LoadBalancer) []healthcheck.Group {
+		return []healthcheck.Group{{
+			Name:      "svc",
+			Targets:   []string{target},
+			Balancers: []loadbalancer.LoadBalancer{lb},
+			Passive:   &healthcheck.PassiveConfig{MaxFailures: 1, Cooldown: time.Hour},
+		}}
+	}
+
+	checker := healthcheck.New()
+	defer checker.Stop()
+
+	oldLB := newBalancer(target)
+	checker.SetGroups(spec(oldLB))
+	checker.ReportResult("svc", target, false)
+	require.False(t, isHealthy(oldLB, target))
+
+	// 리로드로 새 로드 밸런서가 생성되어도 제외 상태가 유지되어야 함
+	newLB := newBalancer(target)
+	checker.SetGroups(spec(newLB))
+	assert.False(t, isHealthy(newLB, target), "기존 대상 상태가 새 로드 밸런서에 반영되어야 함")
+
+	// 그룹 제거 시 상태도 제거됨
+	checker.SetGroups(nil)
+	assert.Empty(t, checker.Status())
+}
@@ -227,3 +227,59 @@ func TestSingleTarget(t *testing.T) {
 		assert.Error(t, err, "지원되지 않는 작업에서 오류가 발생해야 함")
 	})
 }
+
+func TestNewWithStrategy(t *testing.T) {
+	t.Run("KnownStrategies", func(t *testing.T) {
+		for _, strategy := range []string{"", loadbalancer.StrategyRoundRobin, loadbalancer.StrategyWeighted, loadbalancer.StrategyLeastConnection} {
+			lb, err := loadbalancer.NewWithStrategy(strategy)
+			assert.NoError(t, err, "지원되는 전략은 생성에 성공해야 함: %s", strategy)
+
+			// 대상 추가 후 선택 가능해야 함
+			assert.NoError(t, lb.AddTarget("http://server1.example.com", 2))
+			target, err := lb.NextTarget()
+			assert.NoError(t, err)
+			assert.Equal(t, "http://server1.example.com", target)
+		}
+	})
+
+	t.Run("UnknownStrategy", func(t *testing.T) {
+		_, err := loadbalancer.NewWithStrategy("random")
+		assert.Error(t, err, "지원되지 않는 전략은 오류가 발생해야 함")
+	})
+}
+
+func TestReleaseConn(t *testing.T) {
+	t.Run("LeastConnection", func(t *testing.T) {
+		lb := loadbalancer.NewLeastConnection([]string{
+			"http://server1.example.com",
+			"http://server2.example.com",
+		})
+
+		// 연결을 반환하면 항상 첫 번째 대상이 선택되어야 함
+		for i := 0; i < 3; i++ {
+			target, err := lb.NextTarget()
+			assert.NoError(t, err)
+			assert.Equal(t, "http://server1.example.com", target, "연결 반환 후에는 최소 연결 대상이 동일해야 함")
+			loadbalancer.ReleaseConn(lb, target)
+		}
+
+		for _, target := range lb.GetTargets() {
+			assert.Equal(t, int64(0), target.ActiveConns, "모든 연결이 반환되어야 함")
+		}
+	})
+
+	t.Run("SingleTarget", func(t *testing.T) {
+		lb := loadbalancer.NewSingle("http://server.example.com")
+
+		target, err := lb.NextTarget()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), lb.GetTargets()[0].ActiveConns, "선택 시 활성 연결 수가 증가해야 함")
+
+		loadbalancer.ReleaseConn(lb, target)
+		assert.Equal(t, int64(0), lb.GetTargets()[0].ActiveConns, "반환 시 활성 연결 수가 감소해야 함")
+
+		// 음수로 내려가지 않아야 함
+		loadbalancer.ReleaseConn(lb, target)
+		assert.Equal(t, int64(0), lb.GetTargets()[0].ActiveConns, "활성 연결 수는 음수가 될 수 없음")
+	})
+}
@@ -158,6 +158,28 @@ func TestTokenBucket(t *testing.T) {
 		assert.Equal(t, 0, count, "모든 토큰이 소진되어야 함")
 		assert.False(t, allowed, "토큰이 없으면 요청 불가능해야 함")
 	})
+
+	t.Run("Status", func(t *testing.T) {
+		// 10초 동안 10개 (초당 1개 보충), 순간 허용량 2
+		limiter := ratelimiter.New(10*time.Second, 10)
+		defer limiter.Stop()
+		limiter.SetBurst(2)
+
+		status := limiter.AllowNStatus("k", 1)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 2, status.Limit)
+		assert.Equal(t, 1, status.Remaining)
+		assert.Equal(t, 2*time.Second, status.Window, "빈 버킷이 가득 차는 시간")
+		assert.InDelta(t, time.Second, status.ResetAfter, float64(50*time.Millisecond))
+
+		limiter.Allow("k")
+		status = limiter.AllowNStatus("k", 1)
+		assert.False(t, status.Allowed)
+		assert.Equal(t, 0, status.Remaining)
+		assert.InDelta(t, time.Second, status.RetryAfter, float64(50*time.Millisecond), "토큰 1개 보충 시간")
+
+		assert.Equal(t, status.Remaining, limiter.Status("k").Remaining, "Status는 토큰을 사용하지 않아야 함")
+	})
 }
 
 func TestSlidingWindow(t *testing.T) {
@@ -238,4 +260,22 @@ func TestSlidingWindow(t *testing.T) {
 			assert.True(t, allowed, "클리너 동작 후 키 %s의 요청은 허용되어야 함", key)
 		}
 	})
+
+	t.Run("Status", func(t *testing.T) {
+		limiter := ratelimiter.NewSlidingWindow(time.Second, 2)
+		defer limiter.Stop()
+
+		status := limiter.AllowNStatus("k", 1)
+		assert.True(t, status.Allowed)
+		assert.Equal(t, 2, status.Limit)
+		assert.Equal(t, 1, status.Remaining)
+		assert.Equal(t, time.Second, status.Window)
+
+		time.Sleep(100 * time.Millisecond)
+		limiter.Allow("k")
+		status = limiter.AllowNStatus("k", 1)
+		assert.False(t, status.Allowed)
+		assert.InDelta(t, 900*time.Millisecond, status.RetryAfter, float64(50*time.Millisecond), "가장 오래된 요청이 윈도우를 벗어날 때까지")
+		assert.InDelta(t, time.Second, status.ResetAfter, float64(50*time.Millisecond), "마지막 요청이 윈도우를 벗어날 때까지")
+	})
 }
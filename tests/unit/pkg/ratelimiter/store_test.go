@@ -0,0 +1,175 @@
+// +build unit
+
+package ratelimiter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/pkg/ratelimiter"
+	"github.com/isinthesky/api-gateway/tests/mocks"
+)
+
+// newRedisFake는 테스트용 Redis 프로토콜 서버를 시작합니다.
+func newRedisFake(t *testing.T, password string) *mocks.RedisServer {
+	server, err := mocks.NewRedisServer(password)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("TokenBucketResult", func(t *testing.T) {
+		store := ratelimiter.NewMemoryStore(0)
+		defer store.Close()
+
+		// 용량 2, 초당 10개 보충
+		result, err := store.TokenBucket(ctx, "k", 2, 10, 2)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.InDelta(t, 200*time.Millisecond, result.ResetAfter, float64(10*time.Millisecond), "가득 찰 때까지 200ms")
+
+		result, err = store.TokenBucket(ctx, "k", 2, 10, 1)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.InDelta(t, 100*time.Millisecond, result.RetryAfter, float64(10*time.Millisecond), "토큰 1개 보충까지 100ms")
+	})
+
+	t.Run("PeekDoesNotConsume", func(t *testing.T) {
+		store := ratelimiter.NewMemoryStore(0)
+		defer store.Close()
+
+		for i := 0; i < 3; i++ {
+			result, err := store.SlidingLog(ctx, "k", 1, time.Second, 0)
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 1, result.Remaining)
+		}
+	})
+
+	t.Run("SlidingLogRetryAfter", func(t *testing.T) {
+		store := ratelimiter.NewMemoryStore(0)
+		defer store.Close()
+
+		_, err := store.SlidingLog(ctx, "k", 1, 200*time.Millisecond, 1)
+		require.NoError(t, err)
+
+		result, err := store.SlidingLog(ctx, "k", 1, 200*time.Millisecond, 1)
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.InDelta(t, 200*time.Millisecond, result.RetryAfter, float64(20*time.Millisecond))
+	})
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("SharedAcrossInstances", func(t *testing.T) {
+		server := newRedisFake(t, "")
+
+		// 같은 저장소를 사용하는 세 게이트웨이 인스턴스
+		var limiters []*ratelimiter.TokenBucket
+		for i := 0; i < 3; i++ {
+			store := ratelimiter.NewRedisStore(ratelimiter.RedisConfig{Addr: server.Addr()})
+			t.Cleanup(func() { store.Close() })
+			limiters = append(limiters, ratelimiter.NewWithStore(store, time.Minute, 3, ratelimiter.FailOpen))
+		}
+
+		allowed := 0
+		for i := 0; i < 9; i++ {
+			if limiters[i%3].Allow("client") {
+				allowed++
+			}
+		}
+		assert.Equal(t, 3, allowed, "한도는 인스턴스 전체에 적용되어야 함")
+
+		remaining, ok := limiters[0].Peek("client")
+		assert.Equal(t, 0, remaining)
+		assert.False(t, ok)
+
+		limiters[1].Reset("client")
+		assert.True(t, limiters[2].Allow("client"), "리셋 후 요청은 허용되어야 함")
+	})
+
+	t.Run("SlidingLog", func(t *testing.T) {
+		server := newRedisFake(t, "")
+		store := ratelimiter.NewRedisStore(ratelimiter.RedisConfig{Addr: server.Addr()})
+		defer store.Close()
+
+		limiter := ratelimiter.NewSlidingWindowWithStore(store, 100*time.Millisecond, 2, ratelimiter.FailOpen)
+		assert.True(t, limiter.Allow("k"))
+		assert.True(t, limiter.Allow("k"))
+		assert.False(t, limiter.Allow("k"))
+
+		count, ok := limiter.Peek("k")
+		assert.Equal(t, 2, count)
+		assert.False(t, ok)
+
+		time.Sleep(150 * time.Millisecond)
+		assert.True(t, limiter.Allow("k"), "윈도우 경과 후 요청은 허용되어야 함")
+	})
+
+	t.Run("ScriptReloadedAfterFlush", func(t *testing.T) {
+		server := newRedisFake(t, "")
+		store := ratelimiter.NewRedisStore(ratelimiter.RedisConfig{Addr: server.Addr()})
+		defer store.Close()
+
+		// 처음에는 스크립트가 없으므로 EVALSHA 실패 후 EVAL로 실행
+		result, err := store.TokenBucket(ctx, "k", 5, 1, 1)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 4, result.Remaining)
+		assert.Equal(t, 1, server.Calls("EVAL"))
+
+		// 이후에는 캐시된 스크립트를 EVALSHA로 실행
+		_, err = store.TokenBucket(ctx, "k", 5, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, server.Calls("EVAL"))
+		assert.Equal(t, 2, server.Calls("EVALSHA"))
+
+		server.FlushScripts()
+		result, err = store.TokenBucket(ctx, "k", 5, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Remaining)
+		assert.Equal(t, 2, server.Calls("EVAL"))
+	})
+
+	t.Run("Auth", func(t *testing.T) {
+		server := newRedisFake(t, "secret")
+
+		store := ratelimiter.NewRedisStore(ratelimiter.RedisConfig{Addr: server.Addr(), Password: "secret"})
+		defer store.Close()
+		assert.NoError(t, store.Ping(ctx))
+
+		wrong := ratelimiter.NewRedisStore(ratelimiter.RedisConfig{Addr: server.Addr(), Password: "wrong"})
+		defer wrong.Close()
+		assert.Error(t, wrong.Ping(ctx))
+	})
+
+	t.Run("FailureModes", func(t *testing.T) {
+		server := newRedisFake(t, "")
+		store := ratelimiter.NewRedisStore(ratelimiter.RedisConfig{Addr: server.Addr(), Timeout: 50 * time.Millisecond})
+		defer store.Close()
+
+		open := ratelimiter.NewWithStore(store, time.Minute, 1, ratelimiter.FailOpen)
+		closed := ratelimiter.NewWithStore(store, time.Minute, 1, ratelimiter.FailClosed)
+		require.True(t, open.Allow("a"))
+		require.True(t, closed.Allow("b"))
+
+		// 저장소에 접근할 수 없는 경우
+		server.Close()
+
+		for i := 0; i < 3; i++ {
+			assert.True(t, open.Allow("a"), "fail open이면 저장소 장애 시 요청을 허용해야 함")
+			assert.False(t, closed.Allow("b"), "fail closed이면 저장소 장애 시 요청을 거부해야 함")
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package proxy_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/proxy"
+)
+
+func TestForwardRequestIdentity(t *testing.T) {
+	var received http.Header
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+	}))
+	defer backend.Close()
+
+	spoofed := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+		req.Header.Set("X-User-Id", "admin")
+		req.Header.Set("X-Internal-Token", "forged")
+		req.Header.Set("X-Tenant", "evil")
+		req.Header.Set("X-Other", "kept")
+		return req
+	}
+
+	t.Run("StripsDefaultHeadersWithoutIdentity", func(t *testing.T) {
+		resp, err := proxy.ForwardRequest(context.Background(), spoofed(), backend.URL, false, "")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Empty(t, received.Get("X-User-Id"), "클라이언트가 보낸 사용자 정보 헤더는 제거해야 함")
+		assert.Empty(t, received.Get("X-Internal-Token"))
+		assert.Equal(t, "kept", received.Get("X-Other"))
+	})
+
+	t.Run("ReplacesWithVerifiedIdentity", func(t *testing.T) {
+		ctx := proxy.WithIdentity(context.Background(), &proxy.Identity{
+			Strip:  []string{"X-User-Id", "X-Tenant"},
+			Header: http.Header{"X-User-Id": {"user-1"}},
+		})
+		resp, err := proxy.ForwardRequest(ctx, spoofed(), backend.URL, false, "")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, []string{"user-1"}, received.Values("X-User-Id"), "검증된 값만 전달해야 함")
+		assert.Empty(t, received.Get("X-Tenant"), "설정한 헤더도 제거해야 함")
+		assert.Equal(t, "kept", received.Get("X-Other"))
+	})
+}
@@ -0,0 +1,97 @@
+package proxy_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/isinthesky/api-gateway/internal/proxy"
+)
+
+func TestTransportPool(t *testing.T) {
+	defaults := proxy.TransportConfig{DialTimeout: time.Second, MaxIdleConnsPerHost: 4}
+
+	t.Run("ReusesUnchangedConfig", func(t *testing.T) {
+		pool := proxy.NewTransportPool(defaults)
+		defer pool.Close()
+
+		first := pool.Get("svc", defaults)
+		assert.Same(t, first, pool.Get("svc", defaults), "설정이 같으면 같은 연결 풀을 사용해야 함")
+
+		changed := defaults
+		changed.MaxIdleConnsPerHost = 8
+		second := pool.Get("svc", changed)
+		assert.NotSame(t, first, second, "설정이 바뀌면 새 연결 풀을 생성해야 함")
+		assert.Equal(t, changed, second.Config())
+	})
+
+	t.Run("RetainRemovesUnused", func(t *testing.T) {
+		pool := proxy.NewTransportPool(defaults)
+		defer pool.Close()
+
+		pool.Get("a", defaults)
+		pool.Get("b", defaults)
+		pool.Retain(func(name string) bool { return name == "a" })
+
+		assert.Equal(t, []string{"a"}, pool.Names())
+	})
+
+	t.Run("KeepAliveReuseObserved", func(t *testing.T) {
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("ok"))
+		}))
+		defer backend.Close()
+
+		var mu sync.Mutex
+		var reused []bool
+		pool := proxy.NewTransportPool(defaults)
+		defer pool.Close()
+		pool.SetObserver(func(upstream string, r bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Equal(t, "svc", upstream)
+			reused = append(reused, r)
+		})
+
+		transport := pool.Get("svc", defaults)
+		for i := 0; i < 3; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+			resp, err := transport.ForwardRequest(context.Background(), req, backend.URL, false, "")
+			require.NoError(t, err)
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []bool{false, true, true}, reused, "첫 요청 이후에는 keep-alive 연결을 재사용해야 함")
+	})
+
+	t.Run("ResponseHeaderTimeout", func(t *testing.T) {
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}))
+		defer backend.Close()
+
+		cfg := defaults
+		cfg.ResponseHeaderTimeout = 50 * time.Millisecond
+		transport := proxy.NewTransport("slow", cfg)
+		defer transport.CloseIdleConnections()
+
+		req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+		start := time.Now()
+		_, err := transport.ForwardRequest(context.Background(), req, backend.URL, false, "")
+		assert.Error(t, err, "응답 헤더 타임아웃이 지나면 실패해야 함")
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+}